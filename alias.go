@@ -0,0 +1,135 @@
+package goenvconf
+
+import "sync/atomic"
+
+// DeprecationHandler receives a warning when a deprecated alias is used instead of the variable.
+type DeprecationHandler func(alias string, variable string)
+
+var deprecationHandler atomic.Pointer[DeprecationHandler]
+
+// SetDeprecationHandler sets the global handler which receives deprecation warnings of variable aliases.
+// The default handler is nil, which ignores warnings. It is safe for concurrent use.
+func SetDeprecationHandler(handler DeprecationHandler) {
+	if handler == nil {
+		deprecationHandler.Store(nil)
+
+		return
+	}
+
+	deprecationHandler.Store(&handler)
+}
+
+func notifyDeprecatedVariable(alias string, variable string) {
+	if handler := deprecationHandler.Load(); handler != nil {
+		(*handler)(alias, variable)
+	}
+}
+
+// The WithDeprecatedAlias methods return a copy of the instance with deprecated alternate variable names.
+// If the variable is unset or empty, the aliases are looked up in order and the first one which is set is used.
+// The [DeprecationHandler] receives a warning whenever an alias is used, so variables can be renamed
+// without breaking existing deployments.
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvString) WithDeprecatedAlias(aliases ...string) EnvString {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvInt) WithDeprecatedAlias(aliases ...string) EnvInt {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvBool) WithDeprecatedAlias(aliases ...string) EnvBool {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvFloat) WithDeprecatedAlias(aliases ...string) EnvFloat {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvAny) WithDeprecatedAlias(aliases ...string) EnvAny {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvStringSlice) WithDeprecatedAlias(aliases ...string) EnvStringSlice {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvIntSlice) WithDeprecatedAlias(aliases ...string) EnvIntSlice {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvFloatSlice) WithDeprecatedAlias(aliases ...string) EnvFloatSlice {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvBoolSlice) WithDeprecatedAlias(aliases ...string) EnvBoolSlice {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvMapString) WithDeprecatedAlias(aliases ...string) EnvMapString {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvMapInt) WithDeprecatedAlias(aliases ...string) EnvMapInt {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvMapFloat) WithDeprecatedAlias(aliases ...string) EnvMapFloat {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
+
+// WithDeprecatedAlias returns a copy of the instance with deprecated alternate variable names.
+func (ev EnvMapBool) WithDeprecatedAlias(aliases ...string) EnvMapBool {
+	ev.options = ev.options.clone()
+	ev.options.aliases = append(ev.options.aliases[:len(ev.options.aliases):len(ev.options.aliases)], aliases...)
+
+	return ev
+}
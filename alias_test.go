@@ -0,0 +1,73 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithDeprecatedAlias(t *testing.T) {
+	t.Setenv("TEST_ALIAS_OLD_HOST", "old.example.com")
+	t.Setenv("TEST_ALIAS_NEW_PORT", "9090")
+	t.Setenv("TEST_ALIAS_OLD_PORT", "8080")
+
+	var warnings [][2]string
+
+	SetDeprecationHandler(func(alias string, variable string) {
+		warnings = append(warnings, [2]string{alias, variable})
+	})
+	t.Cleanup(func() {
+		SetDeprecationHandler(nil)
+	})
+
+	host, err := NewEnvStringVariable("TEST_ALIAS_NEW_HOST").
+		WithDeprecatedAlias("TEST_ALIAS_OLDER_HOST", "TEST_ALIAS_OLD_HOST").
+		Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "old.example.com", host)
+	assertDeepEqual(t, [][2]string{{"TEST_ALIAS_OLD_HOST", "TEST_ALIAS_NEW_HOST"}}, warnings)
+
+	port, err := NewEnvIntVariable("TEST_ALIAS_NEW_PORT").WithDeprecatedAlias("TEST_ALIAS_OLD_PORT").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(9090), port)
+	assertDeepEqual(t, 1, len(warnings))
+
+	ports, err := NewEnvIntSlice("TEST_ALIAS_MISSING", []int64{1}).WithDeprecatedAlias("TEST_ALIAS_OLD_PORT").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{8080}, ports)
+	assertDeepEqual(t, 2, len(warnings))
+
+	ratio, err := NewEnvFloat("TEST_ALIAS_MISSING", 0.5).WithDeprecatedAlias("TEST_ALIAS_OTHER").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, 0.5, ratio)
+	assertDeepEqual(t, 2, len(warnings))
+
+	_, err = NewEnvBoolVariable("TEST_ALIAS_MISSING").WithDeprecatedAlias("TEST_ALIAS_OTHER").Get()
+	assertErrorContains(t, err, "TEST_ALIAS_MISSING: EmptyVar")
+}
+
+func TestWithDeprecatedAlias_GetCustom(t *testing.T) {
+	errGetter := errors.New("getter failed")
+	getFunc := func(name string) (string, error) {
+		switch name {
+		case "OLD_HEADERS":
+			return "a=1", nil
+		case "BROKEN":
+			return "", errGetter
+		default:
+			return "", ErrEnvironmentVariableValueRequired
+		}
+	}
+
+	headers, err := NewEnvMapStringVariable("HEADERS").WithDeprecatedAlias("OLD_HEADERS").GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]string{"a": "1"}, headers)
+
+	_, err = NewEnvMapStringVariable("HEADERS").WithDeprecatedAlias("BROKEN", "OLD_HEADERS").GetCustom(getFunc)
+	assertDeepEqual(t, true, errors.Is(err, errGetter))
+
+	base := NewEnvStringVariable("HEADERS")
+	_ = base.WithDeprecatedAlias("OLD_HEADERS")
+
+	_, err = base.GetCustom(getFunc)
+	assertErrorContains(t, err, "HEADERS: EmptyVar")
+}
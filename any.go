@@ -9,8 +9,10 @@ import (
 
 // EnvAny represents either arbitrary value or an environment reference.
 type EnvAny struct {
-	Value    any     `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    any     `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvAny creates an EnvAny instance.
@@ -43,22 +45,72 @@ func (ev EnvAny) IsZero() bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvAny) Get() (any, error) {
+	result, err := ev.get()
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvAny) get() (any, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue := os.Getenv(*ev.Variable)
 		if rawValue != "" {
-			var result any
+			return ev.parseRaw(rawValue)
+		}
+	}
 
-			err := json.Unmarshal([]byte(rawValue), &result)
+	return ev.Value, nil
+}
 
-			return result, err
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty
+// and there is no literal value.
+func (ev EnvAny) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue any) (any, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
 		}
+
+		return nil, err
 	}
 
-	return ev.Value, nil
+	if result == nil {
+		return defaultValue, nil
+	}
+
+	return result, nil
 }
 
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvAny) GetCustom(getFunc GetEnvFunc) (any, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvAny) getCustom(getFunc GetEnvFunc) (any, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue, err := getFunc(*ev.Variable)
 		if err != nil && !errors.Is(err, ErrEnvironmentVariableValueRequired) {
@@ -66,25 +118,204 @@ func (ev EnvAny) GetCustom(getFunc GetEnvFunc) (any, error) {
 		}
 
 		if rawValue != "" {
-			var result any
-
-			err := json.Unmarshal([]byte(rawValue), &result)
-
-			return result, err
+			return ev.parseRaw(rawValue)
 		}
 	}
 
 	return ev.Value, nil
 }
 
+// WithYAML returns a copy of the instance which decodes the environment value as a YAML document by the unmarshaler,
+// e.g. yaml.Unmarshal of the go.yaml.in/yaml/v3 library. JSON values keep working because YAML is a superset of JSON.
+// The package does not depend on any YAML library, so the unmarshaler must be provided by the caller.
+func (ev EnvAny) WithYAML(unmarshal func(in []byte, out any) error) EnvAny {
+	ev.options = ev.options.clone()
+	ev.options.unmarshalAny = unmarshal
+
+	return ev
+}
+
+// WithRawStringFallback returns a copy of the instance which returns the raw environment value as a string
+// if it cannot be decoded, e.g. a plain word like hello. By default, Get and GetCustom return the decoding error.
+func (ev EnvAny) WithRawStringFallback() EnvAny {
+	ev.options = ev.options.clone()
+	ev.options.rawStringFallback = true
+
+	return ev
+}
+
 // Equal checks if this instance equals the target value.
+// Values are compared structurally, and numbers of different types are equal if they represent the same number,
+// e.g. int 1, float64 1 decoded from JSON and json.Number "1".
 func (ev EnvAny) Equal(target EnvAny) bool {
-	isSameValue := (ev.Value == nil && target.Value == nil) ||
-		(ev.Value != nil && target.Value != nil && reflect.DeepEqual(ev.Value, target.Value))
-	if !isSameValue {
+	if !equalAny(ev.Value, target.Value) {
 		return false
 	}
 
 	return (ev.Variable == nil && target.Variable == nil) ||
 		(ev.Variable != nil && target.Variable != nil && *ev.Variable == *target.Variable)
 }
+
+// equalAny compares arbitrary values structurally. Numbers are compared by value regardless of their types,
+// and maps and slices are compared element by element.
+func equalAny(a any, b any) bool { //nolint:cyclop
+	switch x := a.(type) {
+	case nil:
+		return b == nil
+	case string:
+		y, ok := b.(string)
+
+		return ok && x == y
+	case bool:
+		y, ok := b.(bool)
+
+		return ok && x == y
+	case map[string]any:
+		if y, ok := b.(map[string]any); ok {
+			return equalAnyMap(x, y)
+		}
+	case []any:
+		if y, ok := b.([]any); ok {
+			return equalAnySlice(x, y)
+		}
+	}
+
+	if b == nil {
+		return false
+	}
+
+	if x, ok := toAnyNumber(a); ok {
+		y, ok := toAnyNumber(b)
+
+		return ok && x.equal(y)
+	}
+
+	return equalAnyReflect(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func equalAnyMap(a map[string]any, b map[string]any) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, x := range a {
+		y, ok := b[key]
+		if !ok || !equalAny(x, y) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalAnySlice(a []any, b []any) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !equalAny(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalAnyReflect compares maps and slices of other types structurally, and falls back to reflect.DeepEqual.
+func equalAnyReflect(a reflect.Value, b reflect.Value) bool {
+	switch {
+	case isListKind(a.Kind()) && isListKind(b.Kind()):
+		if a.Len() != b.Len() {
+			return false
+		}
+
+		for i := range a.Len() {
+			if !equalAny(a.Index(i).Interface(), b.Index(i).Interface()) {
+				return false
+			}
+		}
+
+		return true
+	case a.Kind() == reflect.Map && b.Kind() == reflect.Map && a.Type().Key() == b.Type().Key():
+		if a.Len() != b.Len() {
+			return false
+		}
+
+		for iter := a.MapRange(); iter.Next(); {
+			y := b.MapIndex(iter.Key())
+			if !y.IsValid() || !equalAny(iter.Value().Interface(), y.Interface()) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
+func isListKind(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// anyNumber is a normalized number for comparison.
+type anyNumber struct {
+	kind  reflect.Kind
+	int   int64
+	uint  uint64
+	float float64
+}
+
+// toAnyNumber normalizes numbers to int64, uint64 or float64. Integral json.Number values become int64.
+func toAnyNumber(value any) (anyNumber, bool) {
+	if number, ok := value.(json.Number); ok {
+		if i, err := number.Int64(); err == nil {
+			return anyNumber{kind: reflect.Int64, int: i}, true
+		}
+
+		f, err := number.Float64()
+
+		return anyNumber{kind: reflect.Float64, float: f}, err == nil
+	}
+
+	reflectValue := reflect.ValueOf(value)
+
+	switch {
+	case reflectValue.CanInt():
+		return anyNumber{kind: reflect.Int64, int: reflectValue.Int()}, true
+	case reflectValue.CanUint():
+		return anyNumber{kind: reflect.Uint64, uint: reflectValue.Uint()}, true
+	case reflectValue.CanFloat():
+		return anyNumber{kind: reflect.Float64, float: reflectValue.Float()}, true
+	default:
+		return anyNumber{}, false
+	}
+}
+
+// equal checks if both numbers represent the same value.
+func (n anyNumber) equal(target anyNumber) bool {
+	switch {
+	case n.kind == reflect.Int64 && target.kind == reflect.Int64:
+		return n.int == target.int
+	case n.kind == reflect.Uint64 && target.kind == reflect.Uint64:
+		return n.uint == target.uint
+	case n.kind == reflect.Int64 && target.kind == reflect.Uint64:
+		return n.int >= 0 && uint64(n.int) == target.uint
+	case n.kind == reflect.Uint64 && target.kind == reflect.Int64:
+		return target.equal(n)
+	default:
+		return n.toFloat() == target.toFloat()
+	}
+}
+
+func (n anyNumber) toFloat() float64 {
+	switch n.kind {
+	case reflect.Int64:
+		return float64(n.int)
+	case reflect.Uint64:
+		return float64(n.uint)
+	default:
+		return n.float
+	}
+}
@@ -1,9 +1,13 @@
 package goenvconf
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
+
+	"go.yaml.in/yaml/v3"
 )
 
 func TestEnvAny(t *testing.T) {
@@ -293,6 +297,42 @@ func TestEnvAny_Equal(t *testing.T) {
 			Target:   NewEnvAnyValue(42),
 			Expected: false,
 		},
+		{
+			Name:     "int_vs_json_float",
+			Input:    NewEnvAnyValue(map[string]any{"port": 8080, "ratios": []any{int64(1), 0.5}}),
+			Target:   NewEnvAnyValue(map[string]any{"port": float64(8080), "ratios": []any{float64(1), 0.5}}),
+			Expected: true,
+		},
+		{
+			Name:     "json_number",
+			Input:    NewEnvAnyValue(json.Number("42")),
+			Target:   NewEnvAnyValue(uint8(42)),
+			Expected: true,
+		},
+		{
+			Name:     "different_numbers",
+			Input:    NewEnvAnyValue(-1),
+			Target:   NewEnvAnyValue(uint64(1<<64 - 1)),
+			Expected: false,
+		},
+		{
+			Name:     "typed_slice_vs_any_slice",
+			Input:    NewEnvAnyValue([]int{1, 2}),
+			Target:   NewEnvAnyValue([]any{1.0, 2.0}),
+			Expected: true,
+		},
+		{
+			Name:     "typed_map_vs_any_map",
+			Input:    NewEnvAnyValue(map[string]string{"a": "b"}),
+			Target:   NewEnvAnyValue(map[string]any{"a": "b"}),
+			Expected: true,
+		},
+		{
+			Name:     "different_map_lengths",
+			Input:    NewEnvAnyValue(map[string]int{"a": 1}),
+			Target:   NewEnvAnyValue(map[string]int{"a": 1, "b": 2}),
+			Expected: false,
+		},
 		{
 			Name:     "nil_value_vs_non_nil",
 			Input:    NewEnvAnyVariable("MY_VAR"),
@@ -310,3 +350,76 @@ func TestEnvAny_Equal(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvAny_WithYAML(t *testing.T) {
+	t.Setenv("YAML_ANY", "database:\n  host: db\n  ports: [5432, 5433]\n")
+	t.Setenv("JSON_ANY", `{"foo": "bar"}`)
+	t.Setenv("INVALID_YAML_ANY", "a: [")
+
+	_, err := NewEnvAnyVariable("YAML_ANY").Get()
+	assertErrorContains(t, err, "invalid character")
+
+	result, err := NewEnvAnyVariable("YAML_ANY").WithYAML(yaml.Unmarshal).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]any{"database": map[string]any{"host": "db", "ports": []any{5432, 5433}}}, result)
+
+	result, err = NewEnvAnyVariable("JSON_ANY").WithYAML(yaml.Unmarshal).Strict().GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]any{"foo": "bar"}, result)
+
+	_, err = NewEnvAnyVariable("INVALID_YAML_ANY").WithYAML(yaml.Unmarshal).Get()
+	assertErrorContains(t, err, "yaml:")
+}
+
+func TestEnvAny_WithRawStringFallback(t *testing.T) {
+	t.Setenv("RAW_ANY", "hello")
+	t.Setenv("NUMBER_ANY", "42")
+
+	_, err := NewEnvAnyVariable("RAW_ANY").Get()
+	assertErrorContains(t, err, "invalid character")
+
+	result, err := NewEnvAnyVariable("RAW_ANY").WithRawStringFallback().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "hello", result)
+
+	result, err = NewEnvAnyVariable("RAW_ANY").WithRawStringFallback().Strict().GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, "hello", result)
+
+	result, err = NewEnvAnyVariable("NUMBER_ANY").WithRawStringFallback().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, float64(42), result)
+}
+
+func newBenchmarkEnvAny() EnvAny {
+	return NewEnvAnyValue(map[string]any{
+		"database": map[string]any{"host": "db", "port": float64(5432), "replicas": []any{"a", "b", "c"}},
+		"features": map[string]any{"cache": true, "ratio": 0.5, "limits": []any{float64(1), float64(2), float64(3)}},
+	})
+}
+
+func BenchmarkEnvAny_Equal(b *testing.B) {
+	source := newBenchmarkEnvAny()
+	target := newBenchmarkEnvAny()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if !source.Equal(target) {
+			b.Fatal("expected equal values")
+		}
+	}
+}
+
+func BenchmarkEnvAny_EqualDeepEqual(b *testing.B) {
+	source := newBenchmarkEnvAny()
+	target := newBenchmarkEnvAny()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if !reflect.DeepEqual(source.Value, target.Value) {
+			b.Fatal("expected equal values")
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package goenvconf
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// anyParseCache caches the decoded JSON documents of EnvAny variables by variable name,
+// so hot paths which read large JSON blobs on every Get do not decode them again.
+// Each variable holds one entry, which is replaced when the raw value of the variable changes.
+// Decoding errors are not cached.
+var anyParseCache sync.Map
+
+type anyParseCacheEntry struct {
+	rawValue string
+	value    any
+}
+
+// parseRaw decodes the environment value of the instance. Values decoded by the default JSON unmarshaler
+// are cached by the variable name and copied on every hit, so callers never share the same map or slice.
+func (ev EnvAny) parseRaw(rawValue string) (any, error) {
+	if ev.Variable == nil || *ev.Variable == "" || (ev.options != nil && ev.options.unmarshalAny != nil) {
+		return ev.options.parseAny(rawValue)
+	}
+
+	if cached, ok := anyParseCache.Load(*ev.Variable); ok {
+		entry, _ := cached.(anyParseCacheEntry)
+		if entry.rawValue == rawValue {
+			return cloneAnyValue(entry.value), nil
+		}
+	}
+
+	var result any
+
+	if err := json.Unmarshal([]byte(rawValue), &result); err != nil {
+		return ev.options.parseAny(rawValue)
+	}
+
+	anyParseCache.Store(*ev.Variable, anyParseCacheEntry{
+		rawValue: rawValue,
+		value:    cloneAnyValue(result),
+	})
+
+	return result, nil
+}
+
+// cloneAnyValue deeply copies a decoded JSON value.
+func cloneAnyValue(value any) any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(typedValue))
+
+		for key, item := range typedValue {
+			result[key] = cloneAnyValue(item)
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(typedValue))
+
+		for i, item := range typedValue {
+			result[i] = cloneAnyValue(item)
+		}
+
+		return result
+	case nil, string, float64, bool:
+		return typedValue
+	default:
+		return cloneResolvedValue(typedValue)
+	}
+}
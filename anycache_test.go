@@ -0,0 +1,98 @@
+package goenvconf
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestEnvAny_ParseCache(t *testing.T) {
+	anyParseCache.Clear()
+	t.Cleanup(anyParseCache.Clear)
+
+	values := map[string]string{"ANY_CACHE": `{"hosts": ["a", "b"], "port": 80}`}
+	getFunc := newBinderGetter(values)
+	envAny := NewEnvAnyVariable("ANY_CACHE")
+
+	result, err := envAny.GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]any{"hosts": []any{"a", "b"}, "port": float64(80)}, result)
+
+	// mutations of a resolved value must not leak into the cache.
+	result.(map[string]any)["hosts"].([]any)[0] = "changed"
+
+	result, err = envAny.GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]any{"hosts": []any{"a", "b"}, "port": float64(80)}, result)
+
+	_, ok := anyParseCache.Load("ANY_CACHE")
+	assertDeepEqual(t, true, ok)
+
+	// the entry is replaced when the raw value changes.
+	values["ANY_CACHE"] = `[1, 2]`
+
+	result, err = envAny.GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, []any{float64(1), float64(2)}, result)
+
+	// decoding errors are not cached and the raw string fallback still applies.
+	values["ANY_CACHE"] = `{invalid`
+
+	_, err = envAny.GetCustom(getFunc)
+	assertErrorContains(t, err, "invalid character")
+
+	result, err = envAny.WithRawStringFallback().GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, `{invalid`, result)
+
+	// values decoded by custom unmarshalers are never cached.
+	anyParseCache.Clear()
+
+	values["ANY_CACHE"] = `true`
+
+	result, err = envAny.WithYAML(json.Unmarshal).GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, true, result)
+
+	_, ok = anyParseCache.Load("ANY_CACHE")
+	assertDeepEqual(t, false, ok)
+}
+
+func newBenchmarkJSONBlob() string {
+	items := make([]string, 500)
+
+	for i := range items {
+		items[i] = `{"id": ` + strconv.Itoa(i) + `, "name": "item` + strconv.Itoa(i) + `", "enabled": true, "tags": ["a", "b"]}`
+	}
+
+	return `{"items": [` + strings.Join(items, ",") + `]}`
+}
+
+func BenchmarkEnvAny_GetCustomCached(b *testing.B) {
+	getFunc := newBinderGetter(map[string]string{"JSON_BLOB": newBenchmarkJSONBlob()})
+	envAny := NewEnvAnyVariable("JSON_BLOB")
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := envAny.GetCustom(getFunc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkEnvAny_GetCustomUncached is the baseline of BenchmarkEnvAny_GetCustomCached,
+// which decodes the JSON blob on every call.
+func BenchmarkEnvAny_GetCustomUncached(b *testing.B) {
+	getFunc := newBinderGetter(map[string]string{"JSON_BLOB": newBenchmarkJSONBlob()})
+	envAny := NewEnvAnyVariable("JSON_BLOB").WithYAML(json.Unmarshal)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := envAny.GetCustom(getFunc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package goenvconf
+
+import (
+	"errors"
+	"log/slog"
+	"reflect"
+	"sync"
+)
+
+// NamedGetter is a getter with a name, e.g. "vault" or "dotenv", which is reported as the source of resolved values.
+type NamedGetter struct {
+	Name   string
+	GetEnv GetEnvFunc
+	// Lister lists the variable names of the getter for prefix scans.
+	// The names of the process environment are listed if nil and the getter is [GetOSEnv] or [SnapshotOSEnv].
+	Lister EnvLister
+}
+
+// WithGetters returns a copy of the binder which looks up variables in the named getters in order.
+// The first getter which does not return [ErrEnvironmentVariableValueRequired] answers,
+// and its name is reported in the Getter field of resolved fields. It replaces the getter and the batch getter of the binder.
+func (b Binder) WithGetters(getters ...NamedGetter) *Binder {
+	b.getters = getters
+	b.prefetch = nil
+	b.getFunc = func(name string) (string, error) {
+		value, _, err := b.lookup(name)
+
+		return value, err
+	}
+
+	return &b
+}
+
+// lookup gets the value of the variable and returns the name of the getter which answers.
+func (b Binder) lookup(name string) (string, string, error) {
+	if len(b.getters) == 0 {
+		value, err := b.getFunc(name)
+
+		return value, "", err
+	}
+
+	for _, getter := range b.getters {
+		value, err := getter.GetEnv(name)
+		if err == nil || !errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return value, getter.Name, err
+		}
+	}
+
+	return "", "", ErrEnvironmentVariableValueRequired
+}
+
+// ResolutionReport is the audit trail of a binding pass, which records where the value of every Env field comes from,
+// so operators can answer "where did this value come from?" in production incidents.
+type ResolutionReport struct {
+	// Fields are resolved fields in the declaration order of the config struct, including failed fields.
+	Fields []ResolvedField
+}
+
+// Field returns the resolved field at the path, e.g. Server.Port.
+func (rr *ResolutionReport) Field(path string) (ResolvedField, bool) {
+	for _, field := range rr.Fields {
+		if field.Path == path {
+			return field, true
+		}
+	}
+
+	return ResolvedField{}, false
+}
+
+// LogValue implements the slog.LogValuer interface. Every field is logged as a group of its source,
+// variable, getter and error. Values are never logged, because they may be secrets.
+func (rr *ResolutionReport) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(rr.Fields))
+
+	for _, field := range rr.Fields {
+		fieldAttrs := []slog.Attr{slog.String("source", string(field.Source))}
+
+		if field.Variable != "" {
+			fieldAttrs = append(fieldAttrs, slog.String("env", field.Variable))
+		}
+
+		if field.Getter != "" {
+			fieldAttrs = append(fieldAttrs, slog.String("getter", field.Getter))
+		}
+
+		if field.Err != nil {
+			fieldAttrs = append(fieldAttrs, slog.String("error", field.Err.Error()))
+		}
+
+		attrs = append(attrs, slog.Attr{Key: field.Path, Value: slog.GroupValue(fieldAttrs...)})
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// BindWithReport binds the source config into the target struct like [Binder.Bind],
+// and returns the resolution report of Env fields which are bound. The report is returned even if the binding fails.
+func (b Binder) BindWithReport(target any, source any) (*ResolutionReport, error) {
+	b.report = &auditRecorder{fields: map[string]ResolvedField{}}
+	err := b.Bind(target, source)
+
+	report := &ResolutionReport{}
+
+	sourceValue := reflect.Indirect(reflect.ValueOf(source))
+	if sourceValue.Kind() != reflect.Struct {
+		return report, err
+	}
+
+	for _, field := range collectEnvFields(sourceValue) {
+		if resolved, ok := b.report.fields[field.path]; ok {
+			report.Fields = append(report.Fields, resolved)
+		}
+	}
+
+	return report, err
+}
+
+// TraceResolve resolves the Env value by the getter like the Resolve method, and returns the resolved field
+// with the source of the value or the error, for opt-in source attribution of individual values.
+// The getter defaults to [GetOSEnv] if nil.
+func TraceResolve(ev EnvValue, getFunc GetEnvFunc) ResolvedField {
+	_, resolved := NewBinder(getFunc).traceEnvValue(ev, nil)
+
+	return resolved
+}
+
+// auditRecorder records resolved fields by path. It is safe for concurrent use.
+type auditRecorder struct {
+	mu     sync.Mutex
+	fields map[string]ResolvedField
+}
+
+func (ar *auditRecorder) record(field ResolvedField) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	ar.fields[field.Path] = field
+}
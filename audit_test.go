@@ -0,0 +1,115 @@
+package goenvconf
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestBinder_BindWithReport(t *testing.T) {
+	config := binderConfig{
+		Server: binderServerConfig{
+			Host:    NewEnvString("SERVER_HOST", "localhost"),
+			Port:    NewEnvIntVariable("SERVER_PORT").WithCandidateVariables("PORT"),
+			Origins: NewEnvStringSliceVariable("ORIGINS"),
+		},
+		Debug:    toPtr(NewEnvBoolVariable("DEBUG")),
+		Ratio:    NewEnvFloatVariable("RATIO"),
+		Optional: NewEnvStringVariable("OPTIONAL"),
+	}
+
+	binder := NewBinder(nil).WithGetters(
+		NamedGetter{Name: "overrides", GetEnv: newBinderGetter(map[string]string{"DEBUG": "true"})},
+		NamedGetter{Name: "vault", GetEnv: newBinderGetter(map[string]string{"PORT": "9090", "DEBUG": "false", "RATIO": "x", "ORIGINS": "a"})},
+	)
+
+	var target binderTarget
+
+	report, err := binder.BindWithReport(&target, config)
+	assertErrorContains(t, err, "Ratio (RATIO): strconv.ParseFloat")
+	assertDeepEqual(t, []ResolvedField{
+		{Path: "Server.Host", Value: "localhost", Source: SourceLiteral},
+		{Path: "Server.Port", Value: int64(9090), Source: SourceVariable, Variable: "PORT", Getter: "vault"},
+		{Path: "Server.Origins", Value: []string{"a"}, Source: SourceVariable, Variable: "ORIGINS", Getter: "vault"},
+		{Path: "Debug", Value: true, Source: SourceVariable, Variable: "DEBUG", Getter: "overrides"},
+		{Path: "Ratio", Source: SourceError, Variable: "RATIO", Err: report.Fields[4].Err},
+		{Path: "Limits", Source: SourceUnset},
+		{Path: "Extra", Source: SourceUnset},
+		{Path: "Optional", Source: SourceError, Variable: "OPTIONAL", Err: report.Fields[7].Err},
+	}, report.Fields)
+
+	field, ok := report.Field("Server.Port")
+	assertDeepEqual(t, true, ok)
+	assertDeepEqual(t, "vault", field.Getter)
+
+	_, ok = report.Field("Unknown")
+	assertDeepEqual(t, false, ok)
+
+	text := logText(slog.Any("report", report))
+	assertDeepEqual(t, true, strings.Contains(text, "report.Server.Port.source=env report.Server.Port.env=PORT report.Server.Port.getter=vault"))
+	assertDeepEqual(t, true, strings.Contains(text, "report.Ratio.source=error report.Ratio.env=RATIO report.Ratio.error="))
+	assertDeepEqual(t, false, strings.Contains(text, "9090"))
+
+	// the concurrent mode records the same report.
+	concurrentReport, err := binder.WithConcurrency(4).BindWithReport(&target, config)
+	assertErrorContains(t, err, "Ratio (RATIO)")
+	assertDeepEqual(t, len(report.Fields), len(concurrentReport.Fields))
+
+	for i, field := range report.Fields {
+		assertDeepEqual(t, field.Path, concurrentReport.Fields[i].Path)
+		assertDeepEqual(t, field.Source, concurrentReport.Fields[i].Source)
+	}
+
+	_, err = binder.BindWithReport(&target, "invalid")
+	assertErrorContains(t, err, ErrInvalidBindTarget.Error())
+}
+
+func TestBinder_BindWithReport_scannedVariables(t *testing.T) {
+	type scanConfig struct {
+		Headers EnvMapString
+		List    EnvStringSlice
+	}
+
+	type scanTarget struct {
+		Headers map[string]string
+		List    []string
+	}
+
+	values := map[string]string{"HDR_A": "1", "HDR_B": "2", "LST_0": "x", "LST_1": "y"}
+	binder := NewBinder(nil).WithGetters(NamedGetter{Name: "vault", GetEnv: newBinderGetter(values)}).
+		WithEnvLister(EnvListerFunc(func() []string {
+			return []string{"HDR_A", "HDR_B", "LST_0", "LST_1"}
+		}))
+
+	var target scanTarget
+
+	report, err := binder.BindWithReport(&target, scanConfig{
+		Headers: NewEnvMapStringVariable("HDR").WithPrefixScan(nil),
+		List:    NewEnvStringSliceVariable("LST").WithIndexedVariables(),
+	})
+	assertNilError(t, err)
+	assertDeepEqual(t, []ResolvedField{
+		{Path: "Headers", Value: map[string]string{"A": "1", "B": "2"}, Source: SourceVariable, Variable: "HDR_A", Getter: "vault"},
+		{Path: "List", Value: []string{"x", "y"}, Source: SourceVariable, Variable: "LST_0", Getter: "vault"},
+	}, report.Fields)
+
+	var buf strings.Builder
+
+	assertNilError(t, binder.DumpResolved(scanConfig{
+		Headers: NewEnvMapStringVariable("HDR").WithPrefixScan(nil),
+	}, &buf))
+	assertDeepEqual(t, true, strings.Contains(buf.String(), `"source": "env"`))
+}
+
+func TestTraceResolve(t *testing.T) {
+	getFunc := newBinderGetter(map[string]string{"FOO": "bar", "NUM": "x"})
+
+	assertDeepEqual(t, ResolvedField{Value: "bar", Source: SourceVariable, Variable: "FOO"}, TraceResolve(NewEnvStringVariable("FOO"), getFunc))
+	assertDeepEqual(t, ResolvedField{Value: "baz", Source: SourceLiteral}, TraceResolve(NewEnvString("UNSET", "baz"), getFunc))
+	assertDeepEqual(t, ResolvedField{Source: SourceUnset}, TraceResolve(EnvString{}, getFunc))
+
+	resolved := TraceResolve(NewEnvIntVariable("NUM"), getFunc)
+	assertDeepEqual(t, SourceError, resolved.Source)
+	assertDeepEqual(t, "NUM", resolved.Variable)
+	assertErrorContains(t, resolved.Err, "invalid syntax")
+}
@@ -0,0 +1,152 @@
+package goenvconf
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// The WithBase64 methods declare that the environment value is base64-encoded, e.g. a multi-line PEM key
+// or a JSON blob passed through systems which mangle newlines. The value is decoded before parsing.
+// Standard and URL-safe alphabets are accepted, with or without padding. Literal values are never decoded.
+
+// DecodeBase64 decodes the input with the standard or URL-safe base64 alphabet, with or without padding.
+// Leading and trailing whitespace and line breaks are ignored.
+func DecodeBase64(input string) ([]byte, error) {
+	cleaned := strings.NewReplacer("\n", "", "\r", "").Replace(strings.TrimSpace(input))
+
+	var err error
+
+	for _, encoding := range []*base64.Encoding{
+		base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding,
+	} {
+		var result []byte
+
+		result, err = encoding.DecodeString(cleaned)
+		if err == nil {
+			return result, nil
+		}
+	}
+
+	return nil, err
+}
+
+// wrapBase64Decoding wraps the getter to decode the value of the variable from base64.
+func wrapBase64Decoding(variable string, getFunc GetEnvFunc) GetEnvFunc {
+	return func(name string) (string, error) {
+		value, err := getFunc(name)
+		if name != variable || err != nil || value == "" {
+			return value, err
+		}
+
+		decoded, err := DecodeBase64(value)
+		if err != nil {
+			return "", NewParseEnvFailedError("the environment variable value is not valid base64", name)
+		}
+
+		return string(decoded), nil
+	}
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvString) WithBase64() EnvString {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvInt) WithBase64() EnvInt {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvBool) WithBase64() EnvBool {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvFloat) WithBase64() EnvFloat {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvAny) WithBase64() EnvAny {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvStringSlice) WithBase64() EnvStringSlice {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvIntSlice) WithBase64() EnvIntSlice {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvFloatSlice) WithBase64() EnvFloatSlice {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvBoolSlice) WithBase64() EnvBoolSlice {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvMapString) WithBase64() EnvMapString {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvMapInt) WithBase64() EnvMapInt {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvMapFloat) WithBase64() EnvMapFloat {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
+
+// WithBase64 returns a copy of the instance which decodes the environment value from base64 before parsing.
+func (ev EnvMapBool) WithBase64() EnvMapBool {
+	ev.options = ev.options.clone()
+	ev.options.base64 = true
+
+	return ev
+}
@@ -0,0 +1,63 @@
+package goenvconf
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestDecodeBase64(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected string
+		ErrorMsg string
+	}{
+		{Input: base64.StdEncoding.EncodeToString([]byte("a\nb")), Expected: "a\nb"},
+		{Input: base64.RawStdEncoding.EncodeToString([]byte("ab")), Expected: "ab"},
+		{Input: base64.URLEncoding.EncodeToString([]byte{0xfb, 0xff}), Expected: "\xfb\xff"},
+		{Input: base64.RawURLEncoding.EncodeToString([]byte{0xfb, 0xff, 0xfe, 0x01}), Expected: "\xfb\xff\xfe\x01"},
+		{Input: " aGVs\nbG8=\n", Expected: "hello"},
+		{Input: "not base64!", ErrorMsg: "illegal base64 data"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			result, err := DecodeBase64(tc.Input)
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, string(result))
+			}
+		})
+	}
+}
+
+func TestWithBase64(t *testing.T) {
+	t.Setenv("BASE64_ANY", base64.StdEncoding.EncodeToString([]byte(`{"a": [1, 2]}`)))
+	t.Setenv("BASE64_STRING", base64.StdEncoding.EncodeToString([]byte("line1\nline2")))
+	t.Setenv("BASE64_INT", "NDI=")
+	t.Setenv("BASE64_INVALID", "%%%")
+	t.Setenv("HOST", "localhost")
+
+	anyValue, err := NewEnvAnyVariable("BASE64_ANY").WithBase64().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]any{"a": []any{float64(1), float64(2)}}, anyValue)
+
+	str, err := NewEnvStringVariable("BASE64_STRING").WithBase64().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "line1\nline2", str)
+
+	str, err = NewEnvString("BASE64_MISSING", "http://${HOST}").WithBase64().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "http://localhost", str)
+
+	intValue, err := NewEnvIntVariable("BASE64_INT").WithBase64().Strict().GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(42), intValue)
+
+	_, err = NewEnvMapStringVariable("BASE64_INVALID").WithBase64().Get()
+	assertErrorContains(t, err, "ParseEnvFailed: the environment variable value is not valid base64. Hint: BASE64_INVALID")
+
+	_, err = NewEnvIntVariable("BASE64_INT").Get()
+	assertErrorContains(t, err, "invalid syntax")
+}
@@ -0,0 +1,94 @@
+package goenvconf
+
+import (
+	"context"
+	"reflect"
+	"slices"
+)
+
+// BatchGetter is implemented by getters which get values of many environment variables in one call,
+// e.g. one round trip to Vault or AWS SSM instead of one round trip per variable.
+// Variables which do not exist are omitted from the result.
+type BatchGetter interface {
+	GetMany(ctx context.Context, keys []string) (map[string]string, error)
+}
+
+// BatchGetEnvFunc abstracts a custom function to get values of many environment variables in one call.
+// It implements [BatchGetter].
+type BatchGetEnvFunc func(ctx context.Context, keys []string) (map[string]string, error)
+
+// GetMany gets values of the variables by calling the function.
+func (fn BatchGetEnvFunc) GetMany(ctx context.Context, keys []string) (map[string]string, error) {
+	return fn(ctx, keys)
+}
+
+// PrefetchGetEnvFunc gets values of the variables with one GetMany call and returns a getter of the results.
+// Prefetched variables which do not exist return [ErrEnvironmentVariableValueRequired] without another call.
+// Other variables, e.g. numbered variables of indexed slices, are fetched by GetMany one at a time.
+func PrefetchGetEnvFunc(ctx context.Context, getter BatchGetter, keys []string) (GetEnvFunc, error) {
+	keys = slices.Compact(slices.Sorted(slices.Values(keys)))
+	values := map[string]string{}
+
+	if len(keys) > 0 {
+		results, err := getter.GetMany(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			if value, ok := results[key]; ok {
+				values[key] = value
+			}
+		}
+	}
+
+	return func(name string) (string, error) {
+		if value, ok := values[name]; ok {
+			return value, nil
+		}
+
+		if _, found := slices.BinarySearch(keys, name); found {
+			return "", ErrEnvironmentVariableValueRequired
+		}
+
+		results, err := getter.GetMany(ctx, []string{name})
+		if err != nil {
+			return "", err
+		}
+
+		value, ok := results[name]
+		if !ok {
+			return "", ErrEnvironmentVariableValueRequired
+		}
+
+		return value, nil
+	}, nil
+}
+
+// WithBatchGetter returns a copy of the binder which prefetches all variables declared by Env fields of the config
+// with one GetMany call per Bind, ValidateOnly or Resolve call. The batch getter replaces the getter of the binder.
+func (b Binder) WithBatchGetter(ctx context.Context, getter BatchGetter) *Binder {
+	b.prefetch = func(keys []string) (GetEnvFunc, error) {
+		return PrefetchGetEnvFunc(ctx, getter, keys)
+	}
+
+	return &b
+}
+
+// prefetchGetFunc returns the getter of prefetched variables of the config if the binder has a batch getter,
+// or the getter of the binder otherwise.
+func (b Binder) prefetchGetFunc(config reflect.Value) (GetEnvFunc, error) {
+	if b.prefetch == nil {
+		return b.getFunc, nil
+	}
+
+	var keys []string
+
+	walkEnvFields(config, "", func(_ string, field reflect.Value) {
+		if ev, ok := field.Interface().(EnvValue); ok {
+			keys = append(keys, ev.Variables()...)
+		}
+	})
+
+	return b.prefetch(keys)
+}
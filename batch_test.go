@@ -0,0 +1,103 @@
+package goenvconf
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// newCountingBatchGetter creates a batch getter of the map which records the keys of every call.
+func newCountingBatchGetter(values map[string]string, calls *[][]string) BatchGetEnvFunc {
+	return func(_ context.Context, keys []string) (map[string]string, error) {
+		*calls = append(*calls, keys)
+		results := map[string]string{}
+
+		for _, key := range keys {
+			if value, ok := values[key]; ok {
+				results[key] = value
+			}
+		}
+
+		return results, nil
+	}
+}
+
+func TestPrefetchGetEnvFunc(t *testing.T) {
+	var calls [][]string
+
+	getter := newCountingBatchGetter(map[string]string{"A": "1", "C": "3"}, &calls)
+
+	getFunc, err := PrefetchGetEnvFunc(context.Background(), getter, []string{"B", "A", "B"})
+	assertNilError(t, err)
+	assertDeepEqual(t, [][]string{{"A", "B"}}, calls)
+
+	value, err := getFunc("A")
+	assertNilError(t, err)
+	assertDeepEqual(t, "1", value)
+
+	_, err = getFunc("B")
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+	assertDeepEqual(t, 1, len(calls))
+
+	value, err = getFunc("C")
+	assertNilError(t, err)
+	assertDeepEqual(t, "3", value)
+
+	_, err = getFunc("D")
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+	assertDeepEqual(t, [][]string{{"A", "B"}, {"C"}, {"D"}}, calls)
+
+	_, err = PrefetchGetEnvFunc(context.Background(), BatchGetEnvFunc(func(context.Context, []string) (map[string]string, error) {
+		return nil, errors.New("connection refused")
+	}), []string{"A"})
+	assertErrorContains(t, err, "connection refused")
+}
+
+func TestBinder_WithBatchGetter(t *testing.T) {
+	var calls [][]string
+
+	config := binderConfig{
+		Server: binderServerConfig{
+			Host:    NewEnvString("SERVER_HOST", "localhost"),
+			Port:    NewEnvIntVariable("SERVER_PORT").WithDeprecatedAlias("PORT"),
+			Origins: NewEnvStringSliceVariable("ORIGINS"),
+		},
+		Debug: toPtr(NewEnvBoolVariable("DEBUG")),
+		Extra: NewEnvAnyVariable("EXTRA"),
+	}
+
+	getter := newCountingBatchGetter(map[string]string{
+		"PORT":    "9090",
+		"DEBUG":   "true",
+		"ORIGINS": "a,b",
+		"EXTRA":   `{"foo":"bar"}`,
+	}, &calls)
+	binder := NewBinder(nil).WithBatchGetter(context.Background(), getter)
+
+	var target binderTarget
+
+	assertNilError(t, binder.Bind(&target, config))
+	assertDeepEqual(t, binderServerTarget{Host: "localhost", Port: 9090, Origins: []string{"a", "b"}}, target.Server)
+	assertDeepEqual(t, toPtr(true), target.Debug)
+	assertDeepEqual(t, any(map[string]any{"foo": "bar"}), target.Extra)
+	assertDeepEqual(t, [][]string{{"DEBUG", "EXTRA", "ORIGINS", "PORT", "SERVER_HOST", "SERVER_PORT"}}, calls)
+
+	assertNilError(t, binder.ValidateOnly(config))
+	assertDeepEqual(t, 2, len(calls))
+
+	resolved, err := binder.Resolve(config)
+	assertNilError(t, err)
+	port, _ := resolved.Get("Server.Port")
+	assertDeepEqual(t, int64(9090), port)
+	assertDeepEqual(t, 3, len(calls))
+
+	binder = NewBinder(nil).WithBatchGetter(context.Background(), BatchGetEnvFunc(func(context.Context, []string) (map[string]string, error) {
+		return nil, errors.New("connection refused")
+	}))
+
+	assertErrorContains(t, binder.Bind(&target, config), "connection refused")
+	assertErrorContains(t, binder.ValidateOnly(config), "connection refused")
+
+	_, err = binder.Resolve(config)
+	assertErrorContains(t, err, "connection refused")
+}
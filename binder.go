@@ -0,0 +1,469 @@
+package goenvconf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidBindTarget occurs when the bind target is not a non-nil pointer to a struct.
+var ErrInvalidBindTarget = errors.New("the bind target must be a non-nil pointer to a struct")
+
+// StructValidator validates a struct of resolved plain values, e.g. *validator.Validate of [go-playground/validator].
+//
+// [go-playground/validator]: https://github.com/go-playground/validator
+type StructValidator interface {
+	Struct(s any) error
+}
+
+// Binder resolves Env fields of a config struct into a struct of plain values.
+type Binder struct {
+	getFunc   GetEnvFunc
+	validator StructValidator
+	cache     *parseCache
+	prefetch  func(keys []string) (GetEnvFunc, error)
+	// concurrency is the maximum number of goroutines which resolve Env fields.
+	concurrency int
+	// results are Env fields which are resolved concurrently ahead of binding, by field path.
+	results map[string]envFieldResult
+	// getters are named getters which are looked up in order instead of the getter, for source attribution.
+	getters []NamedGetter
+	// report records the resolved fields of a binding pass if not nil.
+	report *auditRecorder
+	// hooks are called after every Env field resolution, after package-level hooks.
+	hooks []ResolveHook
+	// envLister lists variable names for prefix-scanned maps which have no lister.
+	envLister EnvLister
+}
+
+// NewBinder creates a [Binder] with the getter. The getter defaults to [GetOSEnv] if nil.
+func NewBinder(getFunc GetEnvFunc) *Binder {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return &Binder{
+		getFunc: getFunc,
+	}
+}
+
+// WithStructValidator returns a copy of the binder which validates the target struct after binding.
+// The validator reads the tags of the target struct, e.g. `validate:"min=1"` for go-playground/validator.
+func (b Binder) WithStructValidator(validator StructValidator) *Binder {
+	b.validator = validator
+
+	return &b
+}
+
+// Bind resolves fields of the source config into fields of the target struct with the same name.
+//
+// Env fields are resolved by the getter and converted to the type of the target field, e.g. EnvInt to int.
+// Nested structs are bound recursively and other fields are copied if the types are compatible.
+// Zero Env instances are skipped. All resolution errors are returned together as [ConfigErrors].
+// If the binding succeeds, the struct validator runs on the target and its violations are returned as [ConfigErrors].
+func (b Binder) Bind(target any, source any) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Pointer || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return ErrInvalidBindTarget
+	}
+
+	sourceValue := reflect.Indirect(reflect.ValueOf(source))
+	if sourceValue.Kind() != reflect.Struct {
+		return fmt.Errorf("%w, got %T", ErrInvalidBindTarget, source)
+	}
+
+	getFunc, err := b.prefetchGetFunc(sourceValue)
+	if err != nil {
+		return err
+	}
+
+	b.getFunc = getFunc
+	b.cache = newParseCache()
+
+	if b.concurrency > 1 {
+		fields := collectEnvFields(sourceValue)
+		results := b.resolveEnvFields(fields, b.cache)
+		b.results = make(map[string]envFieldResult, len(fields))
+
+		for i, field := range fields {
+			b.results[field.path] = results[i]
+		}
+	}
+
+	errs := b.bindStruct(targetValue.Elem(), sourceValue, "")
+
+	if b.validator != nil && len(errs) == 0 {
+		errs = append(errs, toValidationConfigErrors(b.validator.Struct(target), sourceValue)...)
+	}
+
+	return errs.toError()
+}
+
+// ValidateOnly resolves all Env fields of the config without binding them, and returns all errors together.
+func (b Binder) ValidateOnly(config any) error {
+	value := reflect.Indirect(reflect.ValueOf(config))
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("%w, got %T", ErrInvalidBindTarget, config)
+	}
+
+	getFunc, err := b.prefetchGetFunc(value)
+	if err != nil {
+		return err
+	}
+
+	var errs ConfigErrors
+
+	b.getFunc = getFunc
+	fields := collectEnvFields(value)
+	results := b.resolveEnvFields(fields, newParseCache())
+
+	for i, field := range fields {
+		b.notifyResolve(field.value, results[i].resolved)
+
+		err := results[i].err
+		if err != nil && !errors.Is(err, ErrEnvironmentValueRequired) {
+			errs = append(errs, ConfigError{Path: field.path, Variable: envVariableName(field.value), Err: err})
+		}
+	}
+
+	return errs.toError()
+}
+
+func (b Binder) bindStruct(target reflect.Value, source reflect.Value, path string) ConfigErrors {
+	var errs ConfigErrors
+
+	targetType := target.Type()
+
+	for i := range targetType.NumField() {
+		field := targetType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		sourceField := source.FieldByName(field.Name)
+		if !sourceField.IsValid() {
+			continue
+		}
+
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		if err := b.bindField(target.Field(i), sourceField, fieldPath); err != nil {
+			errs = append(errs, err...)
+		}
+	}
+
+	return errs
+}
+
+func (b Binder) bindField(target reflect.Value, source reflect.Value, path string) ConfigErrors {
+	if source.Kind() == reflect.Pointer {
+		if source.IsNil() {
+			return nil
+		}
+
+		source = source.Elem()
+	}
+
+	resolved, isEnv, err := b.resolveBindValue(source, path)
+
+	switch {
+	case isEnv && errors.Is(err, ErrEnvironmentValueRequired):
+		return nil
+	case isEnv && err != nil:
+		return ConfigErrors{{Path: path, Variable: envVariableName(source), Err: err}}
+	case isEnv:
+	case source.Kind() == reflect.Struct && !source.Type().AssignableTo(target.Type()):
+		structTarget := target
+		if structTarget.Kind() == reflect.Pointer {
+			if structTarget.IsNil() {
+				structTarget.Set(reflect.New(structTarget.Type().Elem()))
+			}
+
+			structTarget = structTarget.Elem()
+		}
+
+		if structTarget.Kind() == reflect.Struct {
+			return b.bindStruct(structTarget, source, path)
+		}
+	}
+
+	variable := envVariableName(source)
+	if isEnv {
+		source = reflect.ValueOf(resolved)
+	}
+
+	if err := assignValue(target, source); err != nil {
+		return ConfigErrors{{Path: path, Variable: variable, Err: err}}
+	}
+
+	return nil
+}
+
+// resolveBindValue resolves the source value if it is an Env instance, or returns the result which is resolved ahead of binding.
+// The resolved field is recorded if the binder has a report, and passed to resolve hooks.
+func (b Binder) resolveBindValue(source reflect.Value, path string) (any, bool, error) {
+	result, ok := b.results[path]
+	if !ok {
+		result, ok = b.resolveEnv(source.Interface(), path, b.cache)
+		if !ok {
+			return nil, false, nil
+		}
+	}
+
+	if b.report != nil {
+		b.report.record(result.resolved)
+	}
+
+	b.notifyResolve(source, result.resolved)
+
+	return result.value, true, result.err
+}
+
+// resolveEnv resolves the value if it is an Env instance.
+// The source of the value is traced if the binder has a report or resolve hooks.
+func (b Binder) resolveEnv(value any, path string, cache *parseCache) (envFieldResult, bool) {
+	ev, ok := value.(EnvValue)
+	if !ok {
+		return envFieldResult{}, false
+	}
+
+	if !b.traces() {
+		result, _, err := resolveEnvValue(b.withEnvLister(ev), b.getFunc, cache)
+
+		return envFieldResult{value: result, err: redactEnvError(ev, err)}, true
+	}
+
+	result, resolved := b.traceEnvValue(ev, cache)
+	resolved.Path = path
+	result.resolved = resolved
+
+	return result, true
+}
+
+// resolveEnvValue resolves the value if the input is an Env instance. Parsed values are shared by the cache if not nil.
+func resolveEnvValue(value any, getFunc GetEnvFunc, cache *parseCache) (any, bool, error) {
+	ev, ok := value.(EnvValue)
+	if !ok {
+		return nil, false, nil
+	}
+
+	result, err := cache.resolve(ev, getFunc)
+
+	return result, true, err
+}
+
+// assignValue assigns the source value to the target with compatible type conversions,
+// e.g. int64 to int, []int64 to []int32 or a value to a pointer.
+func assignValue(target reflect.Value, source reflect.Value) error { //nolint:cyclop
+	if !source.IsValid() {
+		return nil
+	}
+
+	if source.Type().AssignableTo(target.Type()) {
+		target.Set(source)
+
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Pointer:
+		result := reflect.New(target.Type().Elem())
+		if err := assignValue(result.Elem(), source); err != nil {
+			return err
+		}
+
+		target.Set(result)
+
+		return nil
+	case reflect.Slice:
+		if source.Kind() != reflect.Slice {
+			break
+		}
+
+		result := reflect.MakeSlice(target.Type(), source.Len(), source.Len())
+
+		for i := range source.Len() {
+			if err := assignValue(result.Index(i), source.Index(i)); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+
+		target.Set(result)
+
+		return nil
+	case reflect.Map:
+		if source.Kind() != reflect.Map || !source.Type().Key().AssignableTo(target.Type().Key()) {
+			break
+		}
+
+		result := reflect.MakeMapWithSize(target.Type(), source.Len())
+		item := reflect.New(target.Type().Elem()).Elem()
+
+		for iter := source.MapRange(); iter.Next(); {
+			item.SetZero()
+
+			if err := assignValue(item, iter.Value()); err != nil {
+				return fmt.Errorf("[%v]: %w", iter.Key(), err)
+			}
+
+			result.SetMapIndex(iter.Key(), item)
+		}
+
+		target.Set(result)
+
+		return nil
+	default:
+		if isSameKindGroup(target.Kind(), source.Kind()) {
+			if isOverflow(target, source) {
+				return NewParseEnvFailedError("the value overflows "+target.Type().String(), fmt.Sprint(source))
+			}
+
+			target.Set(source.Convert(target.Type()))
+
+			return nil
+		}
+	}
+
+	return NewParseEnvFailedError(
+		fmt.Sprintf("cannot assign %s to %s", source.Type(), target.Type()),
+		"",
+	)
+}
+
+var kindGroups = [][]reflect.Kind{
+	{reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64},
+	{reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr},
+	{reflect.Float32, reflect.Float64},
+	{reflect.String},
+	{reflect.Bool},
+}
+
+// isSameKindGroup checks if both kinds belong to the same group of numbers, strings or booleans.
+func isSameKindGroup(target reflect.Kind, source reflect.Kind) bool {
+	for _, group := range kindGroups {
+		if slices.Contains(group, target) {
+			return slices.Contains(group, source)
+		}
+	}
+
+	return false
+}
+
+// isOverflow checks if the source number overflows the target type of the same kind group.
+func isOverflow(target reflect.Value, source reflect.Value) bool {
+	switch {
+	case source.CanInt():
+		return target.OverflowInt(source.Int())
+	case source.CanUint():
+		return target.OverflowUint(source.Uint())
+	case source.CanFloat():
+		return target.OverflowFloat(source.Float())
+	default:
+		return false
+	}
+}
+
+// walkEnvFields calls the function for every Env field of the struct recursively. Nil pointers are skipped.
+func walkEnvFields(value reflect.Value, path string, fn func(path string, field reflect.Value)) {
+	valueType := value.Type()
+
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldValue := reflect.Indirect(value.Field(i))
+		if !fieldValue.IsValid() {
+			continue
+		}
+
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		if isEnvType(fieldValue.Type()) {
+			fn(fieldPath, fieldValue)
+		} else if fieldValue.Kind() == reflect.Struct {
+			walkEnvFields(fieldValue, fieldPath, fn)
+		}
+	}
+}
+
+// isEnvType checks if the type implements [EnvValue].
+func isEnvType(valueType reflect.Type) bool {
+	return valueType.Implements(envValueType)
+}
+
+// envVariableName returns the variable name of an Env value, or an empty string if not set.
+func envVariableName(value reflect.Value) string {
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+
+	variable, ok := value.FieldByName("Variable").Interface().(*string)
+	if !ok || variable == nil {
+		return ""
+	}
+
+	return *variable
+}
+
+// toValidationConfigErrors converts errors returned by the struct validator, e.g. validator.ValidationErrors.
+// The field path is read from the Namespace method of each error if exists, without the root struct name.
+func toValidationConfigErrors(err error, source reflect.Value) ConfigErrors {
+	if err == nil {
+		return nil
+	}
+
+	var errs []error
+
+	value := reflect.ValueOf(err)
+	if value.Kind() == reflect.Slice {
+		for i := range value.Len() {
+			if item, ok := value.Index(i).Interface().(error); ok {
+				errs = append(errs, item)
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return ConfigErrors{{Err: err}}
+	}
+
+	results := make(ConfigErrors, len(errs))
+
+	for i, item := range errs {
+		results[i] = ConfigError{Err: item}
+
+		if namespaced, ok := item.(interface{ Namespace() string }); ok {
+			_, results[i].Path, _ = strings.Cut(namespaced.Namespace(), ".")
+			results[i].Variable = lookupEnvVariableName(source, results[i].Path)
+		}
+	}
+
+	return results
+}
+
+// lookupEnvVariableName returns the variable name of the Env field at the path of the source struct.
+func lookupEnvVariableName(source reflect.Value, path string) string {
+	for name := range strings.SplitSeq(path, ".") {
+		source = reflect.Indirect(source)
+		if source.Kind() != reflect.Struct {
+			return ""
+		}
+
+		source = source.FieldByName(name)
+		if !source.IsValid() {
+			return ""
+		}
+	}
+
+	return envVariableName(reflect.Indirect(source))
+}
@@ -0,0 +1,192 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type binderServerConfig struct {
+	Host    EnvString
+	Port    EnvInt
+	Origins EnvStringSlice
+}
+
+type binderConfig struct {
+	Server   binderServerConfig
+	Debug    *EnvBool
+	Ratio    EnvFloat
+	Limits   EnvMapInt
+	Extra    EnvAny
+	Timeout  time.Duration
+	Optional EnvString
+	Ignored  EnvString
+}
+
+type binderServerTarget struct {
+	Host    string `validate:"required,hostname"`
+	Port    int    `validate:"min=1,max=65535"`
+	Origins []string
+}
+
+type binderTarget struct {
+	Server   binderServerTarget
+	Debug    *bool
+	Ratio    float32
+	Limits   map[string]int32
+	Extra    any
+	Timeout  time.Duration
+	Optional string
+	Missing  string
+}
+
+func newBinderGetter(values map[string]string) GetEnvFunc {
+	return func(name string) (string, error) {
+		if value, ok := values[name]; ok {
+			return value, nil
+		}
+
+		return "", ErrEnvironmentVariableValueRequired
+	}
+}
+
+func TestBinder(t *testing.T) {
+	config := binderConfig{
+		Server: binderServerConfig{
+			Host:    NewEnvString("SERVER_HOST", "localhost"),
+			Port:    NewEnvInt("SERVER_PORT", 8080),
+			Origins: NewEnvStringSliceVariable("ORIGINS"),
+		},
+		Debug:   toPtr(NewEnvBoolVariable("DEBUG")),
+		Ratio:   NewEnvFloatValue(0.5),
+		Limits:  NewEnvMapIntValue(map[string]int64{"a": 1}),
+		Extra:   NewEnvAnyVariable("EXTRA"),
+		Timeout: time.Second,
+	}
+
+	getFunc := newBinderGetter(map[string]string{
+		"SERVER_PORT": "9090",
+		"ORIGINS":     "a,b",
+		"DEBUG":       "true",
+		"EXTRA":       `{"foo":"bar"}`,
+	})
+
+	var target binderTarget
+
+	assertNilError(t, NewBinder(getFunc).WithStructValidator(validator.New()).Bind(&target, config))
+	assertDeepEqual(t, binderTarget{
+		Server: binderServerTarget{
+			Host:    "localhost",
+			Port:    9090,
+			Origins: []string{"a", "b"},
+		},
+		Debug:   toPtr(true),
+		Ratio:   0.5,
+		Limits:  map[string]int32{"a": 1},
+		Extra:   map[string]any{"foo": "bar"},
+		Timeout: time.Second,
+	}, target)
+}
+
+func TestBinder_Errors(t *testing.T) {
+	config := binderConfig{
+		Server: binderServerConfig{
+			Host: NewEnvStringVariable("SERVER_HOST"),
+			Port: NewEnvIntVariable("SERVER_PORT"),
+		},
+		Limits: NewEnvMapIntValue(map[string]int64{"a": 1 << 40}),
+	}
+
+	var target binderTarget
+
+	err := NewBinder(newBinderGetter(map[string]string{"SERVER_PORT": "abc"})).Bind(&target, &config)
+	assertDeepEqual(t, `3 config errors:
+  - Server.Host: SERVER_HOST: EmptyVar: the environment variable value is empty
+  - Server.Port (SERVER_PORT): strconv.ParseInt: parsing "abc": invalid syntax
+  - Limits: [a]: ParseEnvFailed: the value overflows int32. Hint: 1099511627776`, err.Error())
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+
+	var configErrs ConfigErrors
+	assertDeepEqual(t, true, errors.As(err, &configErrs))
+	assertDeepEqual(t, "Server.Port", configErrs[1].Path)
+	assertDeepEqual(t, "SERVER_PORT", configErrs[1].Variable)
+
+	assertDeepEqual(t, ErrInvalidBindTarget, NewBinder(nil).Bind(target, config))
+	assertErrorContains(t, NewBinder(nil).Bind(&target, "foo"), ErrInvalidBindTarget.Error())
+}
+
+func TestBinder_StructValidator(t *testing.T) {
+	config := binderConfig{
+		Server: binderServerConfig{
+			Host: NewEnvStringValue("invalid host"),
+			Port: NewEnvIntValue(0),
+		},
+	}
+
+	var target binderTarget
+
+	err := NewBinder(nil).WithStructValidator(validator.New()).Bind(&target, config)
+	assertErrorContains(t, err, "'binderTarget.Server.Host' Error:Field validation for 'Host' failed on the 'hostname' tag")
+	assertErrorContains(t, err, "'binderTarget.Server.Port' Error:Field validation for 'Port' failed on the 'min' tag")
+
+	var validationErr validator.FieldError
+	assertDeepEqual(t, true, errors.As(err, &validationErr))
+
+	var configErrs ConfigErrors
+	assertDeepEqual(t, true, errors.As(err, &configErrs))
+	assertDeepEqual(t, 2, len(configErrs))
+	assertDeepEqual(t, "Server.Host", configErrs[0].Path)
+}
+
+func TestBinder_ValidateOnly(t *testing.T) {
+	config := binderConfig{
+		Server: binderServerConfig{
+			Host: NewEnvStringVariable("SERVER_HOST").Required(),
+			Port: NewEnvIntInRange("SERVER_PORT", 8080, 1, 65535),
+		},
+		Debug:  toPtr(NewEnvBoolVariable("DEBUG")),
+		Limits: NewEnvMapIntVariable("LIMITS"),
+	}
+
+	getFunc := newBinderGetter(map[string]string{
+		"SERVER_HOST": "",
+		"SERVER_PORT": "0",
+		"DEBUG":       "true",
+		"LIMITS":      "a",
+	})
+
+	err := NewBinder(getFunc).ValidateOnly(&config)
+	assertDeepEqual(t, `3 config errors:
+  - Server.Host: SERVER_HOST: ValidationFailed: the value must not be empty
+  - Server.Port: SERVER_PORT: ValidationFailed: the value must be greater than or equal to 1. Hint: 0
+  - Limits (LIMITS): ParseEnvFailed: invalid string map syntax, expected: <key1>=<value1>;<key2>=<value2>. Hint: a`, err.Error())
+
+	assertNilError(t, NewBinder(newBinderGetter(map[string]string{"SERVER_HOST": "localhost", "DEBUG": "1"})).ValidateOnly(config))
+	assertErrorContains(t, NewBinder(nil).ValidateOnly("foo"), ErrInvalidBindTarget.Error())
+}
+
+func TestConfigErrors(t *testing.T) {
+	err := ConfigErrors{{Path: "Port", Variable: "PORT", Err: ErrEnvironmentVariableValueRequired}}
+	assertDeepEqual(t, "Port (PORT): EmptyVar: the environment variable value is empty", err.Error())
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+	assertDeepEqual(t, nil, ConfigErrors{}.toError())
+}
+
+func TestAssignValue_Errors(t *testing.T) {
+	var target struct {
+		Name string
+		Port uint
+	}
+
+	err := NewBinder(nil).Bind(&target, struct {
+		Name EnvInt
+		Port EnvInt
+	}{
+		Name: NewEnvIntValue(1),
+		Port: NewEnvIntValue(1),
+	})
+	assertErrorContains(t, err, "Name: ParseEnvFailed: cannot assign int64 to string")
+	assertErrorContains(t, err, "Port: ParseEnvFailed: cannot assign int64 to uint")
+}
@@ -0,0 +1,51 @@
+package goenvconf
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseBoolLenient parses a boolean value case-insensitively. Besides the values accepted by [strconv.ParseBool],
+// it accepts operator-friendly spellings: yes, y, on, enable and enabled for true,
+// and no, n, off, disable and disabled for false.
+func ParseBoolLenient(input string) (bool, error) {
+	trimmed := trimSpace(input)
+
+	// Fast path: every value accepted by strconv.ParseBool has the same meaning.
+	if result, err := strconv.ParseBool(trimmed); err == nil {
+		return result, nil
+	}
+
+	switch strings.ToLower(trimmed) {
+	case "1", "t", "true", "y", "yes", "on", "enable", "enabled":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off", "disable", "disabled":
+		return false, nil
+	default:
+		return false, NewParseEnvFailedError("invalid boolean syntax", input)
+	}
+}
+
+// WithLenientBool returns a copy of the instance which parses the environment value with [ParseBoolLenient].
+func (ev EnvBool) WithLenientBool() EnvBool {
+	ev.options = ev.options.clone()
+	ev.options.lenientBool = true
+
+	return ev
+}
+
+// WithLenientBool returns a copy of the instance which parses environment items with [ParseBoolLenient].
+func (ev EnvBoolSlice) WithLenientBool() EnvBoolSlice {
+	ev.options = ev.options.clone()
+	ev.options.lenientBool = true
+
+	return ev
+}
+
+// WithLenientBool returns a copy of the instance which parses environment values with [ParseBoolLenient].
+func (ev EnvMapBool) WithLenientBool() EnvMapBool {
+	ev.options = ev.options.clone()
+	ev.options.lenientBool = true
+
+	return ev
+}
@@ -0,0 +1,84 @@
+package goenvconf
+
+import (
+	"testing"
+)
+
+func TestParseBoolLenient(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected bool
+		ErrorMsg string
+	}{
+		{Input: "true", Expected: true},
+		{Input: " YES ", Expected: true},
+		{Input: "On", Expected: true},
+		{Input: "enabled", Expected: true},
+		{Input: "y", Expected: true},
+		{Input: "1", Expected: true},
+		{Input: "FALSE"},
+		{Input: "no"},
+		{Input: "Off"},
+		{Input: "disabled"},
+		{Input: "0"},
+		{Input: "", ErrorMsg: "ParseEnvFailed: invalid boolean syntax"},
+		{Input: "maybe", ErrorMsg: "ParseEnvFailed: invalid boolean syntax. Hint: maybe"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			result, err := ParseBoolLenient(tc.Input)
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestWithLenientBool(t *testing.T) {
+	t.Setenv("LENIENT_BOOL", "yes")
+	t.Setenv("LENIENT_BOOL_SLICE", "on, Off,enabled")
+	t.Setenv("LENIENT_BOOL_MAP", "a=yes;b=no")
+
+	_, err := NewEnvBoolVariable("LENIENT_BOOL").Get()
+	assertErrorContains(t, err, "invalid syntax")
+
+	value, err := NewEnvBoolVariable("LENIENT_BOOL").WithLenientBool().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, true, value)
+
+	value, err = NewEnvBoolVariable("LENIENT_BOOL").WithLenientBool().Strict().GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, true, value)
+
+	_, err = NewEnvBoolSliceVariable("LENIENT_BOOL_SLICE").Get()
+	assertErrorContains(t, err, "failed to parse LENIENT_BOOL_SLICE: invalid boolean slice syntax")
+
+	values, err := NewEnvBoolSliceVariable("LENIENT_BOOL_SLICE").WithLenientBool().GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, []bool{true, false, true}, values)
+
+	values, err = NewEnvBoolSliceVariable("LENIENT_BOOL_SLICE").WithLenientBool().Strict().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []bool{true, false, true}, values)
+
+	t.Setenv("LENIENT_BOOL_SLICE", `["yes", "off", true]`)
+
+	values, err = NewEnvBoolSliceVariable("LENIENT_BOOL_SLICE").WithLenientBool().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []bool{true, false, true}, values)
+
+	_, err = NewEnvMapBoolVariable("LENIENT_BOOL_MAP").Get()
+	assertErrorContains(t, err, "invalid boolean map syntax")
+
+	mapValues, err := NewEnvMapBoolVariable("LENIENT_BOOL_MAP").WithLenientBool().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]bool{"a": true, "b": false}, mapValues)
+
+	mapValues, err = NewEnvMapBoolVariable("LENIENT_BOOL_MAP").WithLenientBool().Strict().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]bool{"a": true, "b": false}, mapValues)
+}
@@ -0,0 +1,85 @@
+package goenvconf
+
+import (
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// envAnyBSON is the BSON document of [EnvAny]. It is a separate type so that encoding it does not recurse
+// into the BSON methods of EnvAny.
+type envAnyBSON struct {
+	Value    any     `bson:"value,omitempty"`
+	Variable *string `bson:"env,omitempty"`
+}
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface.
+func (ev EnvAny) MarshalBSONValue() (byte, []byte, error) {
+	typ, data, err := bson.MarshalValue(envAnyBSON{
+		Value:    ev.Value,
+		Variable: ev.Variable,
+	})
+
+	return byte(typ), data, err
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface.
+// Embedded documents and arrays of the value are decoded as map[string]any and []any like JSON
+// instead of bson.D and bson.A, so decoded instances equal the ones decoded from JSON or YAML.
+func (ev *EnvAny) UnmarshalBSONValue(typ byte, data []byte) error {
+	bsonType := bson.Type(typ)
+
+	switch bsonType {
+	case bson.TypeNull:
+		*ev = EnvAny{}
+
+		return nil
+	case 0:
+		// top-level documents, e.g. bson.Unmarshal into an EnvAny, are passed without a type.
+		bsonType = bson.TypeEmbeddedDocument
+	}
+
+	var raw envAnyBSON
+
+	err := bson.UnmarshalValue(bsonType, data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*ev = EnvAny{
+		Value:    normalizeBSONValue(raw.Value),
+		Variable: raw.Variable,
+	}
+
+	return nil
+}
+
+// normalizeBSONValue converts BSON documents and arrays to plain Go maps and slices recursively.
+func normalizeBSONValue(value any) any {
+	switch v := value.(type) {
+	case bson.D:
+		result := make(map[string]any, len(v))
+
+		for _, elem := range v {
+			result[elem.Key] = normalizeBSONValue(elem.Value)
+		}
+
+		return result
+	case bson.M:
+		result := make(map[string]any, len(v))
+
+		for key, elem := range v {
+			result[key] = normalizeBSONValue(elem)
+		}
+
+		return result
+	case bson.A:
+		result := make([]any, len(v))
+
+		for i, elem := range v {
+			result[i] = normalizeBSONValue(elem)
+		}
+
+		return result
+	default:
+		return value
+	}
+}
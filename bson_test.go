@@ -0,0 +1,99 @@
+package goenvconf
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type bsonTestConfig struct {
+	Name     EnvString      `bson:"name"`
+	Port     EnvInt         `bson:"port"`
+	Debug    EnvBool        `bson:"debug"`
+	Ratio    EnvFloat       `bson:"ratio"`
+	Extra    EnvAny         `bson:"extra"`
+	Hosts    EnvStringSlice `bson:"hosts"`
+	Ports    EnvIntSlice    `bson:"ports"`
+	Weights  EnvFloatSlice  `bson:"weights"`
+	Flags    EnvBoolSlice   `bson:"flags"`
+	Headers  EnvMapString   `bson:"headers"`
+	Limits   EnvMapInt      `bson:"limits"`
+	Scores   EnvMapFloat    `bson:"scores"`
+	Features EnvMapBool     `bson:"features"`
+	Pointer  *EnvString     `bson:"pointer,omitempty"`
+}
+
+func TestBSON_RoundTrip(t *testing.T) {
+	input := bsonTestConfig{
+		Name:     NewEnvString("APP_NAME", "app"),
+		Port:     NewEnvIntValue(9007199254740993),
+		Debug:    NewEnvBoolVariable("DEBUG"),
+		Ratio:    NewEnvFloat("RATIO", 0.5),
+		Extra:    NewEnvAny("EXTRA", "foo"),
+		Hosts:    NewEnvStringSlice("HOSTS", []string{"a", "b"}),
+		Ports:    NewEnvIntSliceValue([]int64{1, 2}),
+		Weights:  NewEnvFloatSliceVariable("WEIGHTS"),
+		Flags:    NewEnvBoolSliceValue([]bool{true}),
+		Headers:  NewEnvMapString("HEADERS", map[string]string{"foo": "bar"}),
+		Limits:   NewEnvMapIntValue(map[string]int64{"a": 1}),
+		Scores:   NewEnvMapFloatVariable("SCORES"),
+		Features: NewEnvMapBoolValue(map[string]bool{"x": true}),
+		Pointer:  toPtr(NewEnvStringVariable("POINTER")),
+	}
+
+	bytes, err := bson.Marshal(input)
+	assertNilError(t, err)
+
+	var output bsonTestConfig
+
+	assertNilError(t, bson.Unmarshal(bytes, &output))
+	assertDeepEqual(t, input, output)
+
+	var document bson.M
+
+	assertNilError(t, bson.Unmarshal(bytes, &document))
+	assertDeepEqual(t, bson.D{{Key: "env", Value: "DEBUG"}}, document["debug"])
+	assertDeepEqual(t, bson.D{{Key: "value", Value: int64(9007199254740993)}}, document["port"])
+}
+
+func TestBSON_EnvAnyDocument(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Input EnvAny
+	}{
+		{
+			Name:  "object",
+			Input: NewEnvAnyValue(map[string]any{"a": 1, "b": []any{"x"}}),
+		},
+		{
+			Name:  "array",
+			Input: NewEnvAny("EXTRA", []any{"x", map[string]any{"y": true}, []any{1.5}}),
+		},
+		{
+			Name:  "zero",
+			Input: EnvAny{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			bytes, err := bson.Marshal(bsonTestConfig{Extra: tc.Input})
+			assertNilError(t, err)
+
+			var output bsonTestConfig
+
+			assertNilError(t, bson.Unmarshal(bytes, &output))
+
+			if !tc.Input.Equal(output.Extra) {
+				t.Fatalf("expected %#v, got %#v", tc.Input, output.Extra)
+			}
+		})
+	}
+
+	var output EnvAny
+
+	bytes, err := bson.Marshal(bson.D{{Key: "value", Value: bson.D{{Key: "a", Value: bson.A{"x"}}}}})
+	assertNilError(t, err)
+	assertNilError(t, bson.Unmarshal(bytes, &output))
+	assertDeepEqual(t, map[string]any{"a": []any{"x"}}, output.Value)
+}
@@ -0,0 +1,300 @@
+package goenvconf
+
+// Builder constructs Env instances fluently as an alternative to the constructors, e.g.
+//
+//	port := Env("PORT").Default(8080).Min(1).Max(65535).Int()
+//
+// Builder methods return a copy of the builder, so a partial builder can be shared. The default value is converted
+// to the type of the built instance like config decoders do, e.g. "8080" or 8080 for EnvInt, and "a,b" or []string{"a", "b"}
+// for EnvStringSlice. Invalid options, e.g. a default value which cannot be converted or Min on EnvBool, are recorded
+// in the built instance and reported by its Validate method.
+type Builder struct {
+	variable   string
+	value      any
+	candidates []string
+	aliases    []string
+	minValue   any
+	maxValue   any
+	required   bool
+	secret     bool
+	strict     bool
+}
+
+// Env creates a [Builder] with a variable name.
+func Env(name string) Builder {
+	return Builder{variable: name}
+}
+
+// Literal creates a [Builder] with a literal value.
+func Literal(value any) Builder {
+	return Builder{value: value}
+}
+
+// Default returns a copy of the builder with the literal value, which is used if the variable is unset.
+func (b Builder) Default(value any) Builder {
+	b.value = value
+
+	return b
+}
+
+// Candidates returns a copy of the builder with candidate variables. See WithCandidateVariables.
+func (b Builder) Candidates(names ...string) Builder {
+	b.candidates = append(b.candidates[:len(b.candidates):len(b.candidates)], names...)
+
+	return b
+}
+
+// DeprecatedAlias returns a copy of the builder with deprecated aliases. See WithDeprecatedAlias.
+func (b Builder) DeprecatedAlias(aliases ...string) Builder {
+	b.aliases = append(b.aliases[:len(b.aliases):len(b.aliases)], aliases...)
+
+	return b
+}
+
+// Min returns a copy of the builder which requires the resolved value to be greater than or equal to the value.
+// It is supported by EnvInt and EnvFloat only.
+func (b Builder) Min(value any) Builder {
+	b.minValue = value
+
+	return b
+}
+
+// Max returns a copy of the builder which requires the resolved value to be less than or equal to the value.
+// It is supported by EnvInt and EnvFloat only.
+func (b Builder) Max(value any) Builder {
+	b.maxValue = value
+
+	return b
+}
+
+// Required returns a copy of the builder which requires the resolved value to be non-empty.
+// It is supported by EnvString only.
+func (b Builder) Required() Builder {
+	b.required = true
+
+	return b
+}
+
+// Secret returns a copy of the builder whose literal value is masked in logs.
+func (b Builder) Secret() Builder {
+	b.secret = true
+
+	return b
+}
+
+// Strict returns a copy of the builder which resolves the value in strict mode.
+func (b Builder) Strict() Builder {
+	b.strict = true
+
+	return b
+}
+
+// rawInput returns the raw object form of the builder, which is decoded by the decoder of the target type.
+func (b Builder) rawInput() map[string]any {
+	result := map[string]any{}
+
+	if b.variable != "" {
+		result[envObjectVariableKey] = b.variable
+	}
+
+	if b.value != nil {
+		result[envObjectValueKey] = b.value
+	}
+
+	return result
+}
+
+// variablePtr returns the pointer of the variable name, or nil if not set.
+func (b Builder) variablePtr() *string {
+	if b.variable == "" {
+		return nil
+	}
+
+	variable := b.variable
+
+	return &variable
+}
+
+// options returns the options of the built instance with the decoding error recorded if not nil.
+func (b Builder) options(typeName string, err error) *envOptions {
+	var result *envOptions
+
+	if err != nil {
+		result = withError(result, err)
+	}
+
+	if len(b.candidates) > 0 || len(b.aliases) > 0 || b.secret || b.strict {
+		result = result.clone()
+		result.candidates = b.candidates
+		result.aliases = b.aliases
+		result.secret = b.secret
+		result.strict = b.strict
+	}
+
+	if (b.minValue != nil || b.maxValue != nil) && typeName != "EnvInt" && typeName != "EnvFloat" {
+		result = withError(result, NewParseEnvFailedError("Min and Max are not supported by "+typeName, ""))
+	}
+
+	if b.required && typeName != "EnvString" {
+		result = withError(result, NewParseEnvFailedError("Required is not supported by "+typeName, ""))
+	}
+
+	return result
+}
+
+// String builds an [EnvString] instance.
+func (b Builder) String() EnvString {
+	result, err := decodeEnvString(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvString", err)
+
+	if b.required {
+		result = result.Required()
+	}
+
+	return result
+}
+
+// Int builds an [EnvInt] instance.
+func (b Builder) Int() EnvInt {
+	result, err := decodeEnvInt(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvInt", err)
+
+	if b.minValue != nil {
+		minValue, err := convertToInt64(b.minValue)
+		if err != nil {
+			result.options = withError(result.options, err)
+		} else {
+			result = result.WithMin(minValue)
+		}
+	}
+
+	if b.maxValue != nil {
+		maxValue, err := convertToInt64(b.maxValue)
+		if err != nil {
+			result.options = withError(result.options, err)
+		} else {
+			result = result.WithMax(maxValue)
+		}
+	}
+
+	return result
+}
+
+// Float builds an [EnvFloat] instance.
+func (b Builder) Float() EnvFloat {
+	result, err := decodeEnvFloat(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvFloat", err)
+
+	if b.minValue != nil {
+		minValue, err := convertToFloat64(b.minValue)
+		if err != nil {
+			result.options = withError(result.options, err)
+		} else {
+			result = result.WithMin(minValue)
+		}
+	}
+
+	if b.maxValue != nil {
+		maxValue, err := convertToFloat64(b.maxValue)
+		if err != nil {
+			result.options = withError(result.options, err)
+		} else {
+			result = result.WithMax(maxValue)
+		}
+	}
+
+	return result
+}
+
+// Bool builds an [EnvBool] instance.
+func (b Builder) Bool() EnvBool {
+	result, err := decodeEnvBool(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvBool", err)
+
+	return result
+}
+
+// Any builds an [EnvAny] instance.
+func (b Builder) Any() EnvAny {
+	result, err := decodeEnvAny(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvAny", err)
+
+	return result
+}
+
+// StringSlice builds an [EnvStringSlice] instance.
+func (b Builder) StringSlice() EnvStringSlice {
+	result, err := decodeEnvStringSlice(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvStringSlice", err)
+
+	return result
+}
+
+// IntSlice builds an [EnvIntSlice] instance.
+func (b Builder) IntSlice() EnvIntSlice {
+	result, err := decodeEnvIntSlice(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvIntSlice", err)
+
+	return result
+}
+
+// FloatSlice builds an [EnvFloatSlice] instance.
+func (b Builder) FloatSlice() EnvFloatSlice {
+	result, err := decodeEnvFloatSlice(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvFloatSlice", err)
+
+	return result
+}
+
+// BoolSlice builds an [EnvBoolSlice] instance.
+func (b Builder) BoolSlice() EnvBoolSlice {
+	result, err := decodeEnvBoolSlice(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvBoolSlice", err)
+
+	return result
+}
+
+// MapString builds an [EnvMapString] instance.
+func (b Builder) MapString() EnvMapString {
+	result, err := decodeEnvMapString(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvMapString", err)
+
+	return result
+}
+
+// MapInt builds an [EnvMapInt] instance.
+func (b Builder) MapInt() EnvMapInt {
+	result, err := decodeEnvMapInt(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvMapInt", err)
+
+	return result
+}
+
+// MapFloat builds an [EnvMapFloat] instance.
+func (b Builder) MapFloat() EnvMapFloat {
+	result, err := decodeEnvMapFloat(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvMapFloat", err)
+
+	return result
+}
+
+// MapBool builds an [EnvMapBool] instance.
+func (b Builder) MapBool() EnvMapBool {
+	result, err := decodeEnvMapBool(b.rawInput())
+	result.Variable = b.variablePtr()
+	result.options = b.options("EnvMapBool", err)
+
+	return result
+}
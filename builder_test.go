@@ -0,0 +1,75 @@
+package goenvconf
+
+import "testing"
+
+func TestBuilder(t *testing.T) {
+	port := Env("PORT").Default(8080).Min(1).Max(65535).Int()
+	assertDeepEqual(t, true, port.Equal(NewEnvInt("PORT", 8080)))
+	assertNilError(t, port.Validate())
+
+	result, err := port.GetCustom(newBinderGetter(map[string]string{}))
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(8080), result)
+
+	_, err = port.GetCustom(newBinderGetter(map[string]string{"PORT": "0"}))
+	assertErrorContains(t, err, "PORT: ValidationFailed: the value must be greater than or equal to 1. Hint: 0")
+
+	_, err = port.GetCustom(newBinderGetter(map[string]string{"PORT": "70000"}))
+	assertErrorContains(t, err, "the value must be less than or equal to 65535")
+
+	base := Env("BASE")
+	assertDeepEqual(t, NewEnvStringVariable("BASE"), base.String())
+	assertDeepEqual(t, NewEnvString("BASE", "x"), base.Default("x").String())
+	assertDeepEqual(t, NewEnvStringVariable("BASE"), base.String())
+	assertDeepEqual(t, NewEnvFloatValue(0.5), Literal(0.5).Float())
+	assertDeepEqual(t, NewEnvBool("DEBUG", true), Env("DEBUG").Default("true").Bool())
+	assertDeepEqual(t, NewEnvAny("EXTRA", map[string]any{"a": 1}), Env("EXTRA").Default(map[string]any{"a": 1}).Any())
+	assertDeepEqual(t, NewEnvStringSlice("HOSTS", []string{"a", "b"}), Env("HOSTS").Default("a,b").StringSlice())
+	assertDeepEqual(t, NewEnvIntSliceValue([]int64{1, 2}), Literal([]int{1, 2}).IntSlice())
+	assertDeepEqual(t, NewEnvFloatSliceVariable("RATIOS"), Env("RATIOS").FloatSlice())
+	assertDeepEqual(t, NewEnvBoolSliceValue([]bool{true}), Literal([]bool{true}).BoolSlice())
+	assertDeepEqual(t, NewEnvMapString("HEADERS", map[string]string{"a": "1"}), Env("HEADERS").Default("a=1").MapString())
+	assertDeepEqual(t, NewEnvMapIntValue(map[string]int64{"a": 1}), Literal(map[string]int{"a": 1}).MapInt())
+	assertDeepEqual(t, NewEnvMapFloatVariable("WEIGHTS"), Env("WEIGHTS").MapFloat())
+	assertDeepEqual(t, NewEnvMapBoolValue(map[string]bool{"a": true}), Literal(map[string]bool{"a": true}).MapBool())
+}
+
+func TestBuilder_Options(t *testing.T) {
+	host := Env("HOST").Candidates("SERVER_HOST").DeprecatedAlias("OLD_HOST").Required().Secret().Strict().String()
+	assertDeepEqual(t, []string{"HOST", "SERVER_HOST", "OLD_HOST"}, host.Variables())
+	assertDeepEqual(t, true, host.IsSecret())
+
+	_, err := host.GetCustom(newBinderGetter(map[string]string{"HOST": ""}))
+	assertErrorContains(t, err, "HOST: ValidationFailed: the value must not be empty")
+
+	result, err := host.GetCustom(newBinderGetter(map[string]string{"SERVER_HOST": "localhost"}))
+	assertNilError(t, err)
+	assertDeepEqual(t, "localhost", result)
+
+	ratio := Env("RATIO").Default(0.5).Min(0).Max(1).Float()
+
+	_, err = ratio.GetCustom(newBinderGetter(map[string]string{"RATIO": "1.5"}))
+	assertErrorContains(t, err, "the value must be less than or equal to 1")
+}
+
+func TestBuilder_Errors(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    interface{ Validate() error }
+		ErrorMsg string
+	}{
+		{Name: "invalid_default", Input: Env("PORT").Default("abc").Int(), ErrorMsg: "invalid integer value. Hint: abc"},
+		{Name: "invalid_min", Input: Env("PORT").Min("abc").Int(), ErrorMsg: "invalid integer value. Hint: abc"},
+		{Name: "invalid_max", Input: Env("RATIO").Max(true).Float(), ErrorMsg: "invalid floating-point number value. Hint: bool"},
+		{Name: "unsupported_min", Input: Env("DEBUG").Min(1).Bool(), ErrorMsg: "Min and Max are not supported by EnvBool"},
+		{Name: "unsupported_required", Input: Env("PORTS").Required().IntSlice(), ErrorMsg: "Required is not supported by EnvIntSlice"},
+		{Name: "invalid_slice", Input: Env("HOSTS").Default(1).StringSlice(), ErrorMsg: "invalid slice value"},
+		{Name: "empty", Input: Env("").String(), ErrorMsg: ErrEnvironmentValueRequired.Error()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertErrorContains(t, tc.Input.Validate(), tc.ErrorMsg)
+		})
+	}
+}
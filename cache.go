@@ -0,0 +1,256 @@
+package goenvconf
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Cached resolves a value lazily once and returns the stored result on subsequent calls,
+// so hot paths do not look up and parse environment variables on every request.
+// The error is also stored, so a failed resolution is not retried until the cache is invalidated or refreshed.
+// It is safe for concurrent use.
+type Cached[T any] struct {
+	get       func() (T, error)
+	getCustom func(getFunc GetEnvFunc) (T, error)
+	result    atomic.Pointer[cachedResult[T]]
+}
+
+type cachedResult[T any] struct {
+	once  sync.Once
+	value T
+	err   error
+}
+
+// NewCached creates a [Cached] instance which resolves the value by the function on the first call of Get.
+func NewCached[T any](get func() (T, error)) *Cached[T] {
+	return &Cached[T]{
+		get: get,
+	}
+}
+
+// newEnvCached creates a [Cached] instance of an Env instance, which can be refreshed with a getter.
+func newEnvCached[T any](get func() (T, error), getCustom func(getFunc GetEnvFunc) (T, error)) *Cached[T] {
+	return &Cached[T]{
+		get:       get,
+		getCustom: getCustom,
+	}
+}
+
+// Get resolves the value on the first call and returns the stored result on subsequent calls.
+func (c *Cached[T]) Get() (T, error) {
+	result := c.loadResult()
+
+	result.once.Do(func() {
+		result.value, result.err = c.get()
+	})
+
+	return result.value, result.err
+}
+
+// loadResult returns the stored result, or stores a new unresolved one if there is none.
+// The stored result may be cleared by Invalidate between a failed swap and the reload, so it retries until not nil.
+func (c *Cached[T]) loadResult() *cachedResult[T] {
+	for {
+		result := c.result.Load()
+		if result != nil {
+			return result
+		}
+
+		result = &cachedResult[T]{}
+		if c.result.CompareAndSwap(nil, result) {
+			return result
+		}
+	}
+}
+
+// Invalidate clears the stored result, so the next call of Get resolves the value again,
+// e.g. after secrets are rotated.
+func (c *Cached[T]) Invalidate() {
+	c.result.Store(nil)
+}
+
+// Refresh resolves the value immediately and stores the result if succeeded, e.g. on a SIGHUP-triggered reload.
+// If the resolution fails, the error is returned and the previous result is kept.
+// The value is resolved by GetCustom with the getter if not nil, otherwise by the resolver of the cache.
+// The getter is ignored if the cache is created by [NewCached].
+func (c *Cached[T]) Refresh(getFunc GetEnvFunc) (T, error) {
+	var value T
+
+	var err error
+
+	if getFunc != nil && c.getCustom != nil {
+		value, err = c.getCustom(getFunc)
+	} else {
+		value, err = c.get()
+	}
+
+	if err != nil {
+		return value, err
+	}
+
+	// Mark the result as resolved, so Get returns it without calling the resolver.
+	result := &cachedResult[T]{value: value}
+	result.once.Do(func() {})
+	c.result.Store(result)
+
+	return value, nil
+}
+
+// The Cached methods return a [Cached] instance which resolves the value by Get once.
+// The CachedCustom methods return a [Cached] instance which resolves the value by GetCustom with the getter once.
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvString) Cached() *Cached[string] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvString) CachedCustom(getFunc GetEnvFunc) *Cached[string] {
+	return newEnvCached(func() (string, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvInt) Cached() *Cached[int64] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvInt) CachedCustom(getFunc GetEnvFunc) *Cached[int64] {
+	return newEnvCached(func() (int64, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvBool) Cached() *Cached[bool] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvBool) CachedCustom(getFunc GetEnvFunc) *Cached[bool] {
+	return newEnvCached(func() (bool, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvFloat) Cached() *Cached[float64] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvFloat) CachedCustom(getFunc GetEnvFunc) *Cached[float64] {
+	return newEnvCached(func() (float64, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvAny) Cached() *Cached[any] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvAny) CachedCustom(getFunc GetEnvFunc) *Cached[any] {
+	return newEnvCached(func() (any, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvStringSlice) Cached() *Cached[[]string] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvStringSlice) CachedCustom(getFunc GetEnvFunc) *Cached[[]string] {
+	return newEnvCached(func() ([]string, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvIntSlice) Cached() *Cached[[]int64] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvIntSlice) CachedCustom(getFunc GetEnvFunc) *Cached[[]int64] {
+	return newEnvCached(func() ([]int64, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvFloatSlice) Cached() *Cached[[]float64] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvFloatSlice) CachedCustom(getFunc GetEnvFunc) *Cached[[]float64] {
+	return newEnvCached(func() ([]float64, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvBoolSlice) Cached() *Cached[[]bool] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvBoolSlice) CachedCustom(getFunc GetEnvFunc) *Cached[[]bool] {
+	return newEnvCached(func() ([]bool, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvMapString) Cached() *Cached[map[string]string] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvMapString) CachedCustom(getFunc GetEnvFunc) *Cached[map[string]string] {
+	return newEnvCached(func() (map[string]string, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvMapInt) Cached() *Cached[map[string]int64] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvMapInt) CachedCustom(getFunc GetEnvFunc) *Cached[map[string]int64] {
+	return newEnvCached(func() (map[string]int64, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvMapFloat) Cached() *Cached[map[string]float64] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvMapFloat) CachedCustom(getFunc GetEnvFunc) *Cached[map[string]float64] {
+	return newEnvCached(func() (map[string]float64, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
+
+// Cached returns a cache which resolves the value by Get once.
+func (ev EnvMapBool) Cached() *Cached[map[string]bool] {
+	return newEnvCached(ev.Get, ev.GetCustom)
+}
+
+// CachedCustom returns a cache which resolves the value by GetCustom with the getter once.
+func (ev EnvMapBool) CachedCustom(getFunc GetEnvFunc) *Cached[map[string]bool] {
+	return newEnvCached(func() (map[string]bool, error) {
+		return ev.GetCustom(getFunc)
+	}, ev.GetCustom)
+}
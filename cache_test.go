@@ -0,0 +1,149 @@
+package goenvconf
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCached(t *testing.T) {
+	t.Setenv("TEST_CACHED_PORT", "8080")
+
+	port := NewEnvIntVariable("TEST_CACHED_PORT").Cached()
+
+	result, err := port.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(8080), result)
+
+	t.Setenv("TEST_CACHED_PORT", "9090")
+
+	result, err = port.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(8080), result)
+
+	_, err = NewEnvIntVariable("TEST_CACHED_MISSING").Cached().Get()
+	assertErrorContains(t, err, "TEST_CACHED_MISSING: EmptyVar")
+}
+
+func TestCachedCustom(t *testing.T) {
+	var calls atomic.Int32
+
+	getFunc := func(name string) (string, error) {
+		calls.Add(1)
+
+		return newBinderGetter(map[string]string{"LIMITS": "a=1;b=2"})(name)
+	}
+
+	limits := NewEnvMapIntVariable("LIMITS").CachedCustom(getFunc)
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			result, err := limits.Get()
+			assertNilError(t, err)
+			assertDeepEqual(t, map[string]int64{"a": 1, "b": 2}, result)
+		}()
+	}
+
+	wg.Wait()
+	assertDeepEqual(t, int32(1), calls.Load())
+}
+
+func TestCached_Invalidate(t *testing.T) {
+	t.Setenv("TEST_CACHED_SECRET", "old")
+
+	secret := NewEnvStringVariable("TEST_CACHED_SECRET").Cached()
+
+	result, err := secret.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "old", result)
+
+	t.Setenv("TEST_CACHED_SECRET", "new")
+	secret.Invalidate()
+
+	result, err = secret.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "new", result)
+
+	t.Setenv("TEST_CACHED_SECRET", "newer")
+
+	result, err = secret.Refresh(nil)
+	assertNilError(t, err)
+	assertDeepEqual(t, "newer", result)
+
+	result, err = secret.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "newer", result)
+}
+
+func TestCached_concurrentInvalidate(t *testing.T) {
+	cached := NewCached(func() (int, error) {
+		return 1, nil
+	})
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for range 1000 {
+				result, err := cached.Get()
+				assertNilError(t, err)
+				assertDeepEqual(t, 1, result)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for range 1000 {
+				cached.Invalidate()
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestCached_Refresh(t *testing.T) {
+	port := NewEnvIntVariable("PORT").CachedCustom(newBinderGetter(map[string]string{"PORT": "8080"}))
+
+	result, err := port.Refresh(newBinderGetter(map[string]string{"PORT": "9090"}))
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(9090), result)
+
+	_, err = port.Refresh(newBinderGetter(map[string]string{"PORT": "abc"}))
+	assertErrorContains(t, err, `parsing "abc": invalid syntax`)
+
+	result, err = port.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(9090), result)
+
+	result, err = port.Refresh(nil)
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(8080), result)
+
+	var calls int
+
+	counter := NewCached(func() (int, error) {
+		calls++
+
+		return calls, nil
+	})
+
+	count, err := counter.Refresh(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, 1, count)
+
+	count, err = counter.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, 1, count)
+}
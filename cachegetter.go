@@ -0,0 +1,103 @@
+package goenvconf
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// CachingGetterOptions configures a [CachingGetter].
+type CachingGetterOptions struct {
+	// TTL is the duration for which found values are cached. Values are cached until invalidated if zero.
+	TTL time.Duration
+	// NegativeTTL is the duration for which variables that do not exist are remembered,
+	// so GetOrDefault patterns do not query the provider on every call. Missing variables are not cached if zero.
+	NegativeTTL time.Duration
+}
+
+// CachingGetter caches values of a slow getter, e.g. a remote secret store, so per-request config reads
+// do not query the provider on every call. Errors other than [ErrEnvironmentVariableValueRequired] are never cached.
+// It is safe for concurrent use. Use the GetEnv method as the [GetEnvFunc] of Env types and binders.
+type CachingGetter struct {
+	getFunc GetEnvFunc
+	options CachingGetterOptions
+	now     func() time.Time
+	mu      sync.RWMutex
+	entries map[string]cachingGetterEntry
+}
+
+type cachingGetterEntry struct {
+	value     string
+	found     bool
+	expiresAt time.Time
+}
+
+// NewCachingGetter creates a [CachingGetter] with the underlying getter. The getter defaults to [GetOSEnv] if nil.
+func NewCachingGetter(getFunc GetEnvFunc, options CachingGetterOptions) *CachingGetter {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return &CachingGetter{
+		getFunc: getFunc,
+		options: options,
+		now:     time.Now,
+		entries: map[string]cachingGetterEntry{},
+	}
+}
+
+// GetEnv returns the cached result of the variable if not expired, or gets the value from the underlying getter.
+// It implements the [GetEnvFunc] signature.
+func (c *CachingGetter) GetEnv(key string) (string, error) {
+	now := c.now()
+
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if ok && (entry.expiresAt.IsZero() || now.Before(entry.expiresAt)) {
+		if !entry.found {
+			return "", ErrEnvironmentVariableValueRequired
+		}
+
+		return entry.value, nil
+	}
+
+	value, err := c.getFunc(key)
+
+	switch {
+	case err == nil:
+		c.store(key, cachingGetterEntry{value: value, found: true}, c.options.TTL, now)
+	case errors.Is(err, ErrEnvironmentVariableValueRequired) && c.options.NegativeTTL > 0:
+		c.store(key, cachingGetterEntry{}, c.options.NegativeTTL, now)
+	}
+
+	return value, err
+}
+
+// Invalidate removes the cached result of the variable, e.g. after a secret is rotated.
+func (c *CachingGetter) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// Clear removes all cached results.
+func (c *CachingGetter) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
+
+func (c *CachingGetter) store(key string, entry cachingGetterEntry, ttl time.Duration, now time.Time) {
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+}
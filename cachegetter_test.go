@@ -0,0 +1,78 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCachingGetter(t *testing.T) {
+	values := map[string]string{"FOO": "bar"}
+	calls := map[string]int{}
+	getFunc := func(name string) (string, error) {
+		calls[name]++
+
+		if name == "BROKEN" {
+			return "", errors.New("connection refused")
+		}
+
+		return newBinderGetter(values)(name)
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	getter := NewCachingGetter(getFunc, CachingGetterOptions{TTL: time.Minute, NegativeTTL: 10 * time.Second})
+	getter.now = func() time.Time { return now }
+
+	for range 3 {
+		value, err := getter.GetEnv("FOO")
+		assertNilError(t, err)
+		assertDeepEqual(t, "bar", value)
+
+		_, err = getter.GetEnv("MISSING")
+		assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+
+		_, err = getter.GetEnv("BROKEN")
+		assertErrorContains(t, err, "connection refused")
+	}
+
+	assertDeepEqual(t, map[string]int{"FOO": 1, "MISSING": 1, "BROKEN": 3}, calls)
+
+	// negative results expire before found values.
+	values["MISSING"] = "found"
+	values["FOO"] = "baz"
+	now = now.Add(30 * time.Second)
+
+	value, err := getter.GetEnv("MISSING")
+	assertNilError(t, err)
+	assertDeepEqual(t, "found", value)
+
+	value, err = getter.GetEnv("FOO")
+	assertNilError(t, err)
+	assertDeepEqual(t, "bar", value)
+
+	getter.Invalidate("FOO")
+
+	value, err = getter.GetEnv("FOO")
+	assertNilError(t, err)
+	assertDeepEqual(t, "baz", value)
+
+	getter.Clear()
+
+	_, _ = getter.GetEnv("FOO")
+	assertDeepEqual(t, 3, calls["FOO"])
+
+	// missing variables are not cached without the negative TTL.
+	getter = NewCachingGetter(getFunc, CachingGetterOptions{})
+
+	_, _ = getter.GetEnv("NONE")
+	_, _ = getter.GetEnv("NONE")
+	assertDeepEqual(t, 2, calls["NONE"])
+
+	_, _ = getter.GetEnv("FOO")
+	_, _ = getter.GetEnv("FOO")
+	assertDeepEqual(t, 4, calls["FOO"])
+
+	result, err := NewEnvStringVariable("MISSING_DEFAULT").GetCustomOrDefault(getter.GetEnv, "fallback")
+	assertNilError(t, err)
+	assertDeepEqual(t, "fallback", result)
+}
@@ -0,0 +1,114 @@
+package goenvconf
+
+// The WithCandidateVariables methods return a copy of the instance with alternate variable names,
+// which are looked up in order if the variable is unset or empty, e.g. when hosting platforms inject
+// differently named variables for the same setting:
+//
+//	NewEnvStringVariable("DATABASE_URL").WithCandidateVariables("POSTGRES_URL", "PG_URL")
+//
+// Unlike deprecated aliases, candidate variables are equally supported names and never trigger deprecation warnings.
+// Candidate variables are looked up before deprecated aliases.
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvString) WithCandidateVariables(names ...string) EnvString {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvInt) WithCandidateVariables(names ...string) EnvInt {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvBool) WithCandidateVariables(names ...string) EnvBool {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvFloat) WithCandidateVariables(names ...string) EnvFloat {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvAny) WithCandidateVariables(names ...string) EnvAny {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvStringSlice) WithCandidateVariables(names ...string) EnvStringSlice {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvIntSlice) WithCandidateVariables(names ...string) EnvIntSlice {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvFloatSlice) WithCandidateVariables(names ...string) EnvFloatSlice {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvBoolSlice) WithCandidateVariables(names ...string) EnvBoolSlice {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvMapString) WithCandidateVariables(names ...string) EnvMapString {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvMapInt) WithCandidateVariables(names ...string) EnvMapInt {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvMapFloat) WithCandidateVariables(names ...string) EnvMapFloat {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
+
+// WithCandidateVariables returns a copy of the instance with alternate variable names which are looked up in order.
+func (ev EnvMapBool) WithCandidateVariables(names ...string) EnvMapBool {
+	ev.options = ev.options.clone()
+	ev.options.candidates = append(ev.options.candidates[:len(ev.options.candidates):len(ev.options.candidates)], names...)
+
+	return ev
+}
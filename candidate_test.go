@@ -0,0 +1,90 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithCandidateVariables(t *testing.T) {
+	t.Setenv("TEST_CANDIDATE_POSTGRES_URL", "postgres://postgres")
+	t.Setenv("TEST_CANDIDATE_PG_URL", "postgres://pg")
+	t.Setenv("TEST_CANDIDATE_OLD_URL", "postgres://old")
+
+	var warnings []string
+
+	SetDeprecationHandler(func(alias string, _ string) {
+		warnings = append(warnings, alias)
+	})
+	t.Cleanup(func() {
+		SetDeprecationHandler(nil)
+	})
+
+	databaseURL := NewEnvStringVariable("TEST_CANDIDATE_DATABASE_URL").
+		WithCandidateVariables("TEST_CANDIDATE_MISSING_URL", "TEST_CANDIDATE_POSTGRES_URL", "TEST_CANDIDATE_PG_URL").
+		WithDeprecatedAlias("TEST_CANDIDATE_OLD_URL")
+
+	result, err := databaseURL.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "postgres://postgres", result)
+	assertDeepEqual(t, []string(nil), warnings)
+	assertDeepEqual(t, []string{
+		"TEST_CANDIDATE_DATABASE_URL",
+		"TEST_CANDIDATE_MISSING_URL",
+		"TEST_CANDIDATE_POSTGRES_URL",
+		"TEST_CANDIDATE_PG_URL",
+		"TEST_CANDIDATE_OLD_URL",
+	}, databaseURL.Variables())
+
+	t.Setenv("TEST_CANDIDATE_DATABASE_URL", "postgres://primary")
+
+	result, err = databaseURL.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "postgres://primary", result)
+
+	alias, err := NewEnvStringVariable("TEST_CANDIDATE_MISSING_URL").
+		WithCandidateVariables("TEST_CANDIDATE_OTHER_URL").
+		WithDeprecatedAlias("TEST_CANDIDATE_OLD_URL").
+		Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "postgres://old", alias)
+	assertDeepEqual(t, []string{"TEST_CANDIDATE_OLD_URL"}, warnings)
+
+	port, err := NewEnvInt("TEST_CANDIDATE_MISSING_PORT", 8080).WithCandidateVariables("TEST_CANDIDATE_OTHER_PORT").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(8080), port)
+}
+
+func TestWithCandidateVariables_GetCustom(t *testing.T) {
+	errGetter := errors.New("getter failed")
+	getFunc := func(name string) (string, error) {
+		switch name {
+		case "PG_HEADERS":
+			return "a=1", nil
+		case "BROKEN":
+			return "", errGetter
+		default:
+			return "", ErrEnvironmentVariableValueRequired
+		}
+	}
+
+	headers, err := NewEnvMapStringVariable("HEADERS").WithCandidateVariables("PG_HEADERS").GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]string{"a": "1"}, headers)
+
+	_, err = NewEnvStringSliceVariable("ORIGINS").WithCandidateVariables("BROKEN", "PG_HEADERS").GetCustom(getFunc)
+	assertDeepEqual(t, true, errors.Is(err, errGetter))
+}
+
+func TestWithCandidateVariables_Validate(t *testing.T) {
+	assertNilError(t, NewEnvBoolVariable("DEBUG").WithCandidateVariables("APP_DEBUG").Validate())
+	assertErrorContains(
+		t,
+		NewEnvBoolVariable("DEBUG").WithCandidateVariables("1DEBUG").Validate(),
+		"invalid candidate variable name. Hint: 1DEBUG",
+	)
+	assertErrorContains(
+		t,
+		NewEnvBoolValue(true).WithCandidateVariables("DEBUG").Validate(),
+		"candidate variables require a variable name",
+	)
+}
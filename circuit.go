@@ -0,0 +1,164 @@
+package goenvconf
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCircuitFailureThreshold = 5
+	defaultCircuitOpenDuration     = 30 * time.Second
+)
+
+// CircuitState is the state of a [CircuitBreaker].
+type CircuitState string
+
+const (
+	// CircuitClosed passes calls to the provider.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects calls without calling the provider.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets one trial call through to check if the provider recovered.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreakerOptions configures a [CircuitBreaker].
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive provider errors which opens the circuit. Defaults to 5.
+	FailureThreshold int
+	// OpenDuration is the duration for which the circuit stays open before a trial call. Defaults to 30 seconds.
+	OpenDuration time.Duration
+	// ServeStale returns the last known good value of the variable while the circuit is open, if any.
+	ServeStale bool
+}
+
+// CircuitBreaker wraps a getter of a remote provider, e.g. Vault, and stops calling it after repeated errors,
+// so an outage degrades gracefully instead of failing every resolution after a timeout.
+// [ErrEnvironmentVariableValueRequired] is a successful answer of the provider and does not count as a failure.
+// While the circuit is open, calls return an error wrapping [ErrCircuitOpen], or the last known good value
+// if ServeStale is enabled. It is safe for concurrent use. Use the GetEnv method as the [GetEnvFunc].
+type CircuitBreaker struct {
+	getFunc  GetEnvFunc
+	options  CircuitBreakerOptions
+	now      func() time.Time
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	lastErr  error
+	values   map[string]string
+}
+
+// NewCircuitBreaker creates a [CircuitBreaker] with the underlying getter. The getter defaults to [GetOSEnv] if nil.
+func NewCircuitBreaker(getFunc GetEnvFunc, options CircuitBreakerOptions) *CircuitBreaker {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	if options.FailureThreshold <= 0 {
+		options.FailureThreshold = defaultCircuitFailureThreshold
+	}
+
+	if options.OpenDuration <= 0 {
+		options.OpenDuration = defaultCircuitOpenDuration
+	}
+
+	return &CircuitBreaker{
+		getFunc: getFunc,
+		options: options,
+		now:     time.Now,
+		state:   CircuitClosed,
+		values:  map[string]string{},
+	}
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitOpen && !cb.now().Before(cb.openedAt.Add(cb.options.OpenDuration)) {
+		return CircuitHalfOpen
+	}
+
+	return cb.state
+}
+
+// GetEnv gets the value of the variable from the provider if the circuit allows the call.
+// It implements the [GetEnvFunc] signature.
+func (cb *CircuitBreaker) GetEnv(key string) (string, error) {
+	if err := cb.allow(); err != nil {
+		return cb.rejected(key, err)
+	}
+
+	value, err := cb.getFunc(key)
+	cb.record(key, value, err)
+
+	return value, err
+}
+
+// allow checks if the call can go to the provider, and moves an expired open circuit to the half-open state.
+func (cb *CircuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitClosed:
+		return nil
+	case CircuitOpen:
+		if cb.now().Before(cb.openedAt.Add(cb.options.OpenDuration)) {
+			return cb.lastErr
+		}
+
+		cb.state = CircuitHalfOpen
+
+		return nil
+	default:
+		// Another trial call is in flight.
+		return cb.lastErr
+	}
+}
+
+func (cb *CircuitBreaker) rejected(key string, lastErr error) (string, error) {
+	if cb.options.ServeStale {
+		cb.mu.Lock()
+		value, ok := cb.values[key]
+		cb.mu.Unlock()
+
+		if ok {
+			return value, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s: %w", ErrCircuitOpen, key, lastErr)
+}
+
+func (cb *CircuitBreaker) record(key string, value string, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if err == nil || errors.Is(err, ErrEnvironmentVariableValueRequired) {
+		cb.state = CircuitClosed
+		cb.failures = 0
+
+		if cb.options.ServeStale {
+			if err == nil {
+				cb.values[key] = value
+			} else {
+				delete(cb.values, key)
+			}
+		}
+
+		return
+	}
+
+	cb.failures++
+	cb.lastErr = err
+
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.options.FailureThreshold {
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+	}
+}
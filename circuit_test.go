@@ -0,0 +1,95 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		calls  int
+		outage bool
+	)
+
+	values := map[string]string{"FOO": "bar"}
+	getFunc := func(name string) (string, error) {
+		calls++
+
+		if outage {
+			return "", errors.New("connection refused")
+		}
+
+		return newBinderGetter(values)(name)
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(getFunc, CircuitBreakerOptions{FailureThreshold: 2, OpenDuration: time.Minute, ServeStale: true})
+	breaker.now = func() time.Time { return now }
+
+	value, err := breaker.GetEnv("FOO")
+	assertNilError(t, err)
+	assertDeepEqual(t, "bar", value)
+
+	// missing variables are not provider failures.
+	for range 3 {
+		_, err = breaker.GetEnv("MISSING")
+		assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+	}
+
+	assertDeepEqual(t, CircuitClosed, breaker.State())
+
+	outage = true
+
+	for range 2 {
+		_, err = breaker.GetEnv("OTHER")
+		assertErrorContains(t, err, "connection refused")
+	}
+
+	assertDeepEqual(t, CircuitOpen, breaker.State())
+	assertDeepEqual(t, 6, calls)
+
+	// the open circuit serves the last known good value or rejects calls without the provider.
+	value, err = breaker.GetEnv("FOO")
+	assertNilError(t, err)
+	assertDeepEqual(t, "bar", value)
+
+	_, err = breaker.GetEnv("OTHER")
+	assertDeepEqual(t, true, errors.Is(err, ErrCircuitOpen))
+	assertErrorContains(t, err, "CircuitOpen: the circuit breaker of the provider is open: OTHER: connection refused")
+	assertDeepEqual(t, 6, calls)
+
+	// a failed trial call opens the circuit again.
+	now = now.Add(time.Minute)
+	assertDeepEqual(t, CircuitHalfOpen, breaker.State())
+
+	_, err = breaker.GetEnv("OTHER")
+	assertErrorContains(t, err, "connection refused")
+	assertDeepEqual(t, CircuitOpen, breaker.State())
+	assertDeepEqual(t, 7, calls)
+
+	// a successful trial call closes the circuit.
+	now = now.Add(time.Minute)
+	outage = false
+	values["FOO"] = "baz"
+
+	value, err = breaker.GetEnv("FOO")
+	assertNilError(t, err)
+	assertDeepEqual(t, "baz", value)
+	assertDeepEqual(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreaker_WithoutStale(t *testing.T) {
+	breaker := NewCircuitBreaker(func(string) (string, error) {
+		return "", errors.New("forbidden")
+	}, CircuitBreakerOptions{})
+
+	for range defaultCircuitFailureThreshold {
+		_, err := breaker.GetEnv("FOO")
+		assertErrorContains(t, err, "forbidden")
+	}
+
+	_, err := breaker.GetEnv("FOO")
+	assertDeepEqual(t, true, errors.Is(err, ErrCircuitOpen))
+	assertDeepEqual(t, false, errors.Is(err, ErrEnvironmentVariableValueRequired))
+}
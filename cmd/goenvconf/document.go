@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// readDocument reads and decodes a JSON or YAML config document from the file, or from stdin if the path is "-".
+// The format is detected by the file extension unless it is set. Documents from stdin default to YAML,
+// which also accepts JSON.
+func readDocument(path string, format string, stdin io.Reader) (any, string, error) {
+	data, err := readInput(path, stdin)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if format == "" {
+		format = detectFormat(path)
+	}
+
+	var document any
+
+	switch format {
+	case formatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&document)
+	case formatYAML:
+		err = yaml.Unmarshal(data, &document)
+	default:
+		return nil, "", fmt.Errorf("unsupported format %q, expected json or yaml", format)
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	return document, format, nil
+}
+
+// readInput reads the file, or stdin if the path is "-".
+func readInput(path string, stdin io.Reader) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(stdin)
+	}
+
+	return os.ReadFile(path)
+}
+
+// writeDocument encodes the document as indented JSON or YAML.
+func writeDocument(w io.Writer, document any, format string) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+
+		return encoder.Encode(document)
+	case formatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+
+		if err := encoder.Encode(toYAMLValue(document)); err != nil {
+			return err
+		}
+
+		return encoder.Close()
+	default:
+		return fmt.Errorf("unsupported format %q, expected json or yaml", format)
+	}
+}
+
+func detectFormat(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return formatJSON
+	}
+
+	return formatYAML
+}
+
+// toYAMLValue converts JSON numbers of the document to YAML scalar nodes, which would be encoded as strings otherwise.
+// The literal of the number is kept, so big integers do not lose precision.
+func toYAMLValue(value any) any {
+	switch typedValue := value.(type) {
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(string(typedValue), ".eE") {
+			tag = "!!float"
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: typedValue.String()}
+	case map[string]any:
+		result := make(map[string]any, len(typedValue))
+
+		for key, item := range typedValue {
+			result[key] = toYAMLValue(item)
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(typedValue))
+
+		for i, item := range typedValue {
+			result[i] = toYAMLValue(item)
+		}
+
+		return result
+	default:
+		return value
+	}
+}
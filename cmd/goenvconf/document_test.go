@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReadDocument(t *testing.T) {
+	jsonPath := writeTestFile(t, "config.JSON", `{"port": 8080}`)
+
+	document, format, err := readDocument(jsonPath, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, formatJSON, format)
+
+	var buf bytes.Buffer
+
+	if err := writeDocument(&buf, document, formatYAML); err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, "port: 8080\n", buf.String())
+
+	_, format, err = readDocument("-", "", strings.NewReader(`{"port": 8080}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, formatYAML, format)
+
+	if _, _, err := readDocument("-", "toml", strings.NewReader("")); err == nil {
+		t.Error("expected an unsupported format error")
+	}
+
+	if _, _, err := readDocument("-", formatJSON, strings.NewReader("{")); err == nil {
+		t.Error("expected a decoding error")
+	}
+
+	if err := writeDocument(&buf, document, "toml"); err == nil {
+		t.Error("expected an unsupported format error")
+	}
+}
+
+func TestToYAMLValue(t *testing.T) {
+	var buf bytes.Buffer
+
+	document := map[string]any{"id": json.Number("12345678901234567890"), "ratio": []any{json.Number("0.5")}}
+
+	if err := writeDocument(&buf, document, formatYAML); err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, "id: 12345678901234567890\nratio:\n  - 0.5\n", buf.String())
+}
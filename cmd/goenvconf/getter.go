@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/hasura/goenvconf"
+)
+
+// getterFlags are flags which configure the getter of environment variables.
+// Values of --set override dotenv files, which override each other in order and the OS environment.
+type getterFlags struct {
+	envFiles  []string
+	overrides map[string]string
+	noOSEnv   bool
+}
+
+func (gf *getterFlags) register(flags *flag.FlagSet) {
+	flags.Func("env-file", "load variables from a dotenv `file`, can be repeated", func(value string) error {
+		gf.envFiles = append(gf.envFiles, value)
+
+		return nil
+	})
+	flags.Func("set", "set a variable as `KEY=VALUE`, can be repeated", func(value string) error {
+		key, rawValue, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("expected KEY=VALUE, got %q", value)
+		}
+
+		if gf.overrides == nil {
+			gf.overrides = map[string]string{}
+		}
+
+		gf.overrides[key] = rawValue
+
+		return nil
+	})
+	flags.BoolVar(&gf.noOSEnv, "no-os-env", false, "do not read variables from the OS environment")
+}
+
+// getEnvFunc creates the getter of the flags.
+func (gf *getterFlags) getEnvFunc() (goenvconf.GetEnvFunc, error) {
+	values := map[string]string{}
+
+	for _, path := range gf.envFiles {
+		fileValues, err := readDotEnvFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		maps.Copy(values, fileValues)
+	}
+
+	maps.Copy(values, gf.overrides)
+
+	return func(name string) (string, error) {
+		if value, ok := values[name]; ok {
+			return value, nil
+		}
+
+		if gf.noOSEnv {
+			return "", goenvconf.ErrEnvironmentVariableValueRequired
+		}
+
+		return goenvconf.GetOSEnv(name)
+	}, nil
+}
+
+func readDotEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	values, err := goenvconf.ParseDotEnv(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+)
+
+func TestGetterFlags(t *testing.T) {
+	first := writeTestFile(t, "first.env", "HOST=first\nPORT=8080\n")
+	second := writeTestFile(t, "second.env", "PORT=9090\n")
+
+	t.Setenv("GOENVCONF_TEST_OS", "os")
+
+	var getter getterFlags
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	getter.register(flags)
+
+	if err := flags.Parse([]string{"-env-file", first, "-env-file", second, "-set", "HOST=override"}); err != nil {
+		t.Fatal(err)
+	}
+
+	getFunc, err := getter.getEnvFunc()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, expected := range map[string]string{"HOST": "override", "PORT": "9090", "GOENVCONF_TEST_OS": "os"} {
+		value, err := getFunc(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertEqual(t, expected, value)
+	}
+
+	getter.noOSEnv = true
+
+	getFunc, err = getter.getEnvFunc()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := getFunc("GOENVCONF_TEST_OS"); !errors.Is(err, goenvconf.ErrEnvironmentVariableValueRequired) {
+		t.Errorf("expected ErrEnvironmentVariableValueRequired, got: %v", err)
+	}
+
+	getter.envFiles = append(getter.envFiles, writeTestFile(t, "invalid.env", "1INVALID\n"))
+
+	if _, err := getter.getEnvFunc(); err == nil {
+		t.Error("expected a dotenv syntax error")
+	}
+}
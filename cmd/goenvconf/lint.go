@@ -0,0 +1,299 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hasura/goenvconf"
+	"go.yaml.in/yaml/v3"
+)
+
+// Lint rules.
+const (
+	ruleSyntax          = "syntax"
+	ruleDuplicateKey    = "duplicate-key"
+	ruleMalformedValue  = "malformed-value"
+	ruleUnknownVariable = "unknown-variable"
+	ruleNaming          = "naming"
+)
+
+// lintIssue is a problem found by the lint command.
+type lintIssue struct {
+	path    string
+	line    int
+	rule    string
+	message string
+}
+
+func (li lintIssue) String() string {
+	location := li.path
+	if li.line > 0 {
+		location += ":" + strconv.Itoa(li.line)
+	}
+
+	return location + ": " + li.message + " (" + li.rule + ")"
+}
+
+// linter collects issues of dotenv files and config documents. Dotenv values are checked against the env references
+// of all config documents after every file is read.
+type linter struct {
+	prefix     string
+	documents  int
+	issues     []lintIssue
+	references map[string][]envReference
+	dotEnvs    map[string][]goenvconf.DotEnvEntry
+	files      []string
+}
+
+// runLint checks dotenv files and config documents for duplicate keys, malformed map and slice values,
+// variables which are not referenced by any config document, and naming convention violations.
+func runLint(args []string, _ io.Reader, stdout io.Writer, stderr io.Writer) error {
+	var prefix string
+
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: goenvconf lint [flags] <file>...")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Check dotenv files and JSON or YAML config documents. Files named .env, .env.* or *.env are dotenv files.")
+		fmt.Fprintln(stderr, "Dotenv values are parsed by the type of the literal value of the env references which use them,")
+		fmt.Fprintln(stderr, "and dotenv variables must be referenced by a config document if any is given.")
+		fmt.Fprintln(stderr)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&prefix, "prefix", "", "required prefix of variable names, e.g. APP_")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+
+		return errUsage
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+
+		return errUsage
+	}
+
+	l := &linter{
+		prefix:     prefix,
+		references: map[string][]envReference{},
+		dotEnvs:    map[string][]goenvconf.DotEnvEntry{},
+	}
+
+	for _, path := range flags.Args() {
+		if err := l.lintFile(path); err != nil {
+			return err
+		}
+	}
+
+	issues := l.finish()
+
+	for _, issue := range issues {
+		fmt.Fprintln(stdout, issue.String())
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("found %d issue(s)", len(issues))
+	}
+
+	return nil
+}
+
+func (l *linter) lintFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	l.files = append(l.files, path)
+
+	if isDotEnvFile(path) {
+		entries, err := goenvconf.ParseDotEnvEntries(bytes.NewReader(data))
+		if err != nil {
+			l.report(path, 0, ruleSyntax, err.Error())
+
+			return nil
+		}
+
+		l.dotEnvs[path] = entries
+
+		return nil
+	}
+
+	l.documents++
+
+	var document yaml.Node
+
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		l.report(path, 0, ruleSyntax, err.Error())
+
+		return nil
+	}
+
+	walkDocument(&document, "", documentVisitor{
+		reference: func(node *yaml.Node, field string) {
+			l.addReference(path, node, field)
+		},
+		mapping: func(node *yaml.Node, field string) {
+			l.checkDuplicateKeys(path, node, field)
+		},
+	})
+
+	return nil
+}
+
+// addReference records the env reference of the document node, and reports invalid references and variable names.
+func (l *linter) addReference(path string, node *yaml.Node, field string) {
+	reference, err := decodeReference(path, node, field)
+	if err != nil {
+		l.report(path, node.Line, ruleMalformedValue, field+": "+err.Error())
+
+		return
+	}
+
+	if err := reference.value.Validate(); err != nil {
+		l.report(path, node.Line, ruleMalformedValue, field+": "+err.Error())
+	}
+
+	for _, name := range reference.value.Variables() {
+		l.checkName(path, node.Line, name)
+		l.references[name] = append(l.references[name], reference)
+	}
+}
+
+// checkDuplicateKeys reports keys which are defined more than once in the mapping.
+func (l *linter) checkDuplicateKeys(path string, node *yaml.Node, field string) {
+	keyLines := map[string]int{}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i]
+
+		if line, ok := keyLines[key.Value]; ok {
+			l.report(path, key.Line, ruleDuplicateKey, fmt.Sprintf("%s is already defined at line %d", joinField(field, key.Value), line))
+		} else {
+			keyLines[key.Value] = key.Line
+		}
+	}
+}
+
+// finish checks dotenv entries against the env references, and returns all issues in the file and line order.
+func (l *linter) finish() []lintIssue {
+	declared := make([]string, 0, len(l.references))
+
+	for name := range l.references {
+		declared = append(declared, name)
+	}
+
+	slices.Sort(declared)
+
+	for _, path := range l.files {
+		keyLines := map[string]int{}
+
+		for _, entry := range l.dotEnvs[path] {
+			if line, ok := keyLines[entry.Key]; ok {
+				l.report(path, entry.Line, ruleDuplicateKey, fmt.Sprintf("%s is already defined at line %d", entry.Key, line))
+			} else {
+				keyLines[entry.Key] = entry.Line
+			}
+
+			l.checkName(path, entry.Line, entry.Key)
+
+			references, ok := l.references[entry.Key]
+			if !ok && l.documents > 0 {
+				message := entry.Key + " is not referenced by any config document"
+				if suggestion := goenvconf.SuggestVariableName(entry.Key, declared); suggestion != "" {
+					message += ", did you mean " + suggestion + "?"
+				}
+
+				l.report(path, entry.Line, ruleUnknownVariable, message)
+			}
+
+			l.checkValue(path, entry, references)
+		}
+	}
+
+	fileIndexes := make(map[string]int, len(l.files))
+
+	for i, path := range l.files {
+		fileIndexes[path] = i
+	}
+
+	slices.SortStableFunc(l.issues, func(a, b lintIssue) int {
+		if a.path != b.path {
+			return fileIndexes[a.path] - fileIndexes[b.path]
+		}
+
+		return a.line - b.line
+	})
+
+	return l.issues
+}
+
+// checkValue parses the dotenv value by the env references which use the variable.
+func (l *linter) checkValue(path string, entry goenvconf.DotEnvEntry, references []envReference) {
+	reported := map[string]bool{}
+
+	getFunc := func(name string) (string, error) {
+		if name == entry.Key {
+			return entry.Value, nil
+		}
+
+		return "", goenvconf.ErrEnvironmentVariableValueRequired
+	}
+
+	for _, reference := range references {
+		_, err := reference.value.Resolve(getFunc)
+		if err == nil || errors.Is(err, goenvconf.ErrEnvironmentValueRequired) || reported[err.Error()] {
+			continue
+		}
+
+		reported[err.Error()] = true
+
+		l.report(path, entry.Line, ruleMalformedValue,
+			fmt.Sprintf("%s: %s, used by %s at %s:%d", entry.Key, err, reference.field, reference.path, reference.line))
+	}
+}
+
+// checkName reports variable names which are not in upper snake case or do not start with the prefix.
+func (l *linter) checkName(path string, line int, name string) {
+	switch {
+	case !isUpperSnakeCase(name):
+		l.report(path, line, ruleNaming, name+" is not in upper snake case, e.g. "+strings.ToUpper(name))
+	case !strings.HasPrefix(name, l.prefix):
+		l.report(path, line, ruleNaming, name+" does not start with the prefix "+l.prefix)
+	}
+}
+
+func (l *linter) report(path string, line int, rule string, message string) {
+	l.issues = append(l.issues, lintIssue{path: path, line: line, rule: rule, message: message})
+}
+
+// isDotEnvFile checks if the file name is .env, .env.<suffix> or <name>.env.
+func isDotEnvFile(path string) bool {
+	name := filepath.Base(path)
+
+	return name == ".env" || strings.HasPrefix(name, ".env.") || filepath.Ext(name) == ".env"
+}
+
+func isUpperSnakeCase(name string) bool {
+	for i, c := range name {
+		if c == '_' || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+
+		return false
+	}
+
+	return name != ""
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	configPath := writeTestFile(t, "config.yaml", `server:
+  port:
+    value: 8080
+    env: APP_PORT
+  port: 1
+origins: {value: [a, b], env: APP_ORIGINS}
+limits: {value: {a: 1}, env: APP_LIMITS}
+host: ${app_host:-localhost}
+`)
+	envPath := writeTestFile(t, ".env", `APP_PORT=abc
+APP_LIMITS=a=1;b
+APP_LIMITS=a=1;b=2
+APP_ORIGNS=a
+OTHER=1
+`)
+
+	var stdout, stderr bytes.Buffer
+
+	exitCode := run([]string{"lint", "-prefix", "APP_", configPath, envPath}, nil, &stdout, &stderr)
+	assertEqual(t, 1, exitCode)
+	assertEqual(t, "goenvconf lint: found 8 issue(s)\n", stderr.String())
+
+	expected := []string{
+		configPath + ":5: server.port is already defined at line 2 (duplicate-key)",
+		configPath + ":8: app_host is not in upper snake case, e.g. APP_HOST (naming)",
+		envPath + `:1: APP_PORT: strconv.ParseInt: parsing "abc": invalid syntax, used by server.port at ` + configPath + ":3 (malformed-value)",
+		envPath + ":2: APP_LIMITS: ParseEnvFailed: invalid string map syntax, expected: <key1>=<value1>;<key2>=<value2>. Hint: b, " +
+			"used by limits at " + configPath + ":7 (malformed-value)",
+		envPath + ":3: APP_LIMITS is already defined at line 2 (duplicate-key)",
+		envPath + ":4: APP_ORIGNS is not referenced by any config document, did you mean APP_ORIGINS? (unknown-variable)",
+		envPath + ":5: OTHER does not start with the prefix APP_ (naming)",
+		envPath + ":5: OTHER is not referenced by any config document (unknown-variable)",
+	}
+	assertEqual(t, strings.Join(expected, "\n")+"\n", stdout.String())
+
+	t.Run("clean", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		path := writeTestFile(t, "app.env", "APP_PORT=8080\nAPP_PORT_2=1\n")
+
+		exitCode := run([]string{"lint", path}, nil, &stdout, &stderr)
+		assertEqual(t, 0, exitCode)
+		assertEqual(t, "", stdout.String())
+	})
+
+	t.Run("syntax", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		exitCode := run([]string{"lint", writeTestFile(t, ".env.local", "1INVALID\n"), writeTestFile(t, "config.json", "{")},
+			nil, &stdout, &stderr)
+		assertEqual(t, 1, exitCode)
+		assertEqual(t, 2, strings.Count(stdout.String(), "(syntax)"))
+	})
+}
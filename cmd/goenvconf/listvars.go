@@ -0,0 +1,401 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hasura/goenvconf"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	outputText     = "text"
+	outputJSON     = "json"
+	outputMarkdown = "markdown"
+)
+
+// variableInfo describes an environment variable which is referenced by a config document or schema.
+type variableInfo struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Default  any      `json:"default,omitempty"`
+	Fields   []string `json:"fields"`
+}
+
+// runListVars lists every environment variable which is referenced by a config document,
+// or by the defaults of a JSON schema generated by [goenvconf.GenerateSchema].
+func runListVars(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	var (
+		inputFormat string
+		output      string
+		isSchema    bool
+	)
+
+	flags := flag.NewFlagSet("list-vars", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: goenvconf list-vars [flags] <file>")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "List environment variables which are referenced by a JSON or YAML config document with their types,")
+		fmt.Fprintln(stderr, "whether they are required, and their defaults. Types are inferred from the literal values of the document.")
+		fmt.Fprintln(stderr, `Use "-" as the file to read the document from stdin.`)
+		fmt.Fprintln(stderr)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&inputFormat, "format", "", "format of the document, json or yaml (default: detected by the file extension)")
+	flags.StringVar(&output, "output", outputText, "output format, text, json or markdown")
+	flags.BoolVar(&isSchema, "schema", false, "read the document as a JSON schema generated by goenvconf.GenerateSchema")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+
+		return errUsage
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+
+		return errUsage
+	}
+
+	path := flags.Arg(0)
+
+	var (
+		references []envReference
+		err        error
+	)
+
+	if isSchema {
+		references, err = readSchemaReferences(path, inputFormat, stdin)
+	} else {
+		references, err = readDocumentReferences(path, stdin)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return writeVariables(stdout, collectVariables(references), output)
+}
+
+// readDocumentReferences reads the env references of a config document.
+func readDocumentReferences(path string, stdin io.Reader) ([]envReference, error) {
+	data, err := readInput(path, stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		document   yaml.Node
+		references []envReference
+		errs       []error
+	)
+
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	walkDocument(&document, "", documentVisitor{
+		reference: func(node *yaml.Node, field string) {
+			reference, err := decodeReference(path, node, field)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s:%d: %s: %w", path, node.Line, field, err))
+
+				return
+			}
+
+			references = append(references, reference)
+		},
+	})
+
+	return references, errors.Join(errs...)
+}
+
+// readSchemaReferences reads the env references from the defaults of the Env properties of a JSON schema.
+// Properties without defaults are skipped because their variable names are unknown.
+func readSchemaReferences(path string, format string, stdin io.Reader) ([]envReference, error) {
+	document, _, err := readDocument(path, format, stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, ok := document.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected a JSON schema object", path)
+	}
+
+	var errs []error
+
+	references := schemaReferences(path, schema, "", &errs)
+
+	return references, errors.Join(errs...)
+}
+
+func schemaReferences(path string, schema map[string]any, field string, errs *[]error) []envReference {
+	properties, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(properties))
+
+	for key := range properties {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	var results []envReference
+
+	for _, key := range keys {
+		property, ok := properties[key].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		propertyField := joinField(field, key)
+
+		envType, isEnv := schemaEnvType(property)
+		if !isEnv {
+			results = append(results, schemaReferences(path, property, propertyField, errs)...)
+
+			continue
+		}
+
+		if property["default"] == nil {
+			continue
+		}
+
+		decoded, err := goenvconf.DecodeHook(nil, envType, property["default"])
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %s: %w", path, propertyField, err))
+
+			continue
+		}
+
+		if ev, ok := decoded.(goenvconf.EnvValue); ok {
+			results = append(results, envReference{path: path, field: propertyField, value: ev})
+		}
+	}
+
+	return results
+}
+
+// schemaEnvType returns the Env type of a property schema generated by the JSONSchema methods of Env types,
+// whose first alternative is the object with value and env properties, and the second one is the literal value.
+func schemaEnvType(property map[string]any) (reflect.Type, bool) {
+	alternatives, _ := property["anyOf"].([]any)
+	if len(alternatives) != 3 {
+		return nil, false
+	}
+
+	object, _ := alternatives[0].(map[string]any)
+	objectProperties, _ := object["properties"].(map[string]any)
+
+	if _, ok := objectProperties["env"]; !ok {
+		return nil, false
+	}
+
+	valueSchema, _ := alternatives[1].(map[string]any)
+	valueType, _ := valueSchema["type"].(string)
+
+	switch valueType {
+	case "array":
+		items, _ := valueSchema["items"].(map[string]any)
+
+		return pickEnvType(schemaKind(items), sliceEnvTypes), true
+	case "object":
+		values, _ := valueSchema["additionalProperties"].(map[string]any)
+
+		return pickEnvType(schemaKind(values), mapEnvTypes), true
+	default:
+		return pickEnvType(schemaKind(valueSchema), scalarEnvTypes), true
+	}
+}
+
+// envTypeName returns the type name of the Env type by the type of its literal value,
+// e.g. []int for EnvIntSlice and map[string]string for EnvMapString.
+func envTypeName(envType reflect.Type) string {
+	field, ok := envType.FieldByName("Value")
+	if !ok {
+		return "any"
+	}
+
+	valueType := field.Type
+	if valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	switch valueType.Kind() {
+	case reflect.Slice:
+		return "[]" + scalarTypeName(valueType.Elem())
+	case reflect.Map:
+		return "map[string]" + scalarTypeName(valueType.Elem())
+	default:
+		return scalarTypeName(valueType)
+	}
+}
+
+// scalarTypeName returns the type name of the scalar type, or any for other types.
+func scalarTypeName(valueType reflect.Type) string {
+	switch valueType.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	default:
+		return "any"
+	}
+}
+
+// schemaKind returns the scalar kind of the schema type.
+func schemaKind(schema map[string]any) int {
+	switch schema["type"] {
+	case "boolean":
+		return kindBool
+	case "integer":
+		return kindInt
+	case "number":
+		return kindFloat
+	case "string":
+		return kindString
+	default:
+		return kindOther
+	}
+}
+
+// collectVariables merges the env references by variable name, sorted by name. A variable is required
+// if any reference which uses it cannot be resolved without variables, e.g. it has no literal value.
+func collectVariables(references []envReference) []variableInfo {
+	var results []variableInfo
+
+	indexes := map[string]int{}
+
+	unset := func(string) (string, error) {
+		return "", goenvconf.ErrEnvironmentVariableValueRequired
+	}
+
+	for _, reference := range references {
+		defaultValue, err := reference.value.Resolve(unset)
+		required := err != nil || isNilValue(defaultValue)
+
+		for _, name := range reference.value.Variables() {
+			index, ok := indexes[name]
+			if !ok {
+				index = len(results)
+				indexes[name] = index
+
+				results = append(results, variableInfo{
+					Name: name,
+					Type: envTypeName(reflect.TypeOf(reference.value)),
+				})
+			}
+
+			info := &results[index]
+			info.Required = info.Required || required
+			info.Fields = append(info.Fields, reference.field)
+
+			if info.Default == nil && !required {
+				info.Default = defaultValue
+			}
+		}
+	}
+
+	slices.SortFunc(results, func(a, b variableInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return results
+}
+
+// isNilValue checks if the value is nil, or a nil slice or map.
+func isNilValue(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(value)
+
+	return (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.IsNil()
+}
+
+func writeVariables(w io.Writer, variables []variableInfo, output string) error {
+	switch output {
+	case outputText:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tTYPE\tREQUIRED\tDEFAULT\tFIELDS")
+
+		for _, info := range variables {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+				info.Name, info.Type, formatRequired(info.Required), formatDefault(info.Default), strings.Join(info.Fields, ", "))
+		}
+
+		return tw.Flush()
+	case outputJSON:
+		if variables == nil {
+			variables = []variableInfo{}
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+
+		return encoder.Encode(variables)
+	case outputMarkdown:
+		fmt.Fprintln(w, "| Variable | Type | Required | Default | Fields |")
+		fmt.Fprintln(w, "| --- | --- | --- | --- | --- |")
+
+		for _, info := range variables {
+			defaultValue := formatDefault(info.Default)
+			if defaultValue != "" {
+				defaultValue = "`" + defaultValue + "`"
+			}
+
+			fmt.Fprintf(w, "| `%s` | %s | %s | %s | %s |\n", info.Name, escapeMarkdownCell(info.Type), formatRequired(info.Required),
+				escapeMarkdownCell(defaultValue), escapeMarkdownCell(strings.Join(info.Fields, ", ")))
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported output %q, expected text, json or markdown", output)
+	}
+}
+
+func formatRequired(required bool) string {
+	if required {
+		return "yes"
+	}
+
+	return "no"
+}
+
+// formatDefault returns strings as they are, and other values in JSON.
+func formatDefault(value any) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typedValue
+	default:
+		data, err := json.Marshal(typedValue)
+		if err != nil {
+			return fmt.Sprint(typedValue)
+		}
+
+		return string(data)
+	}
+}
+
+func escapeMarkdownCell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+)
+
+func TestListVars(t *testing.T) {
+	configPath := writeTestFile(t, "config.yaml", `server:
+  host: ${HOST:-localhost}
+  port: {value: 8080, env: PORT}
+  origins: {env: ORIGINS, value: [a, b]}
+db: {env: DATABASE_URL}
+replica: {env: DATABASE_URL, value: "postgres://a|b"}
+`)
+
+	testCases := []struct {
+		Name     string
+		Args     []string
+		Expected string
+	}{
+		{
+			Name: "text",
+			Args: []string{configPath},
+			Expected: `NAME          TYPE      REQUIRED  DEFAULT         FIELDS
+DATABASE_URL  string    yes       postgres://a|b  db, replica
+HOST          string    no        localhost       server.host
+ORIGINS       []string  no        ["a","b"]       server.origins
+PORT          int       no        8080            server.port
+`,
+		},
+		{
+			Name: "markdown",
+			Args: []string{"-output", "markdown", configPath},
+			Expected: "| Variable | Type | Required | Default | Fields |\n" +
+				"| --- | --- | --- | --- | --- |\n" +
+				"| `DATABASE_URL` | string | yes | `postgres://a\\|b` | db, replica |\n" +
+				"| `HOST` | string | no | `localhost` | server.host |\n" +
+				"| `ORIGINS` | []string | no | `[\"a\",\"b\"]` | server.origins |\n" +
+				"| `PORT` | int | no | `8080` | server.port |\n",
+		},
+		{
+			Name:     "json_empty",
+			Args:     []string{"-output", "json", writeTestFile(t, "empty.json", `{"port": 8080}`)},
+			Expected: "[]\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			exitCode := run(append([]string{"list-vars"}, tc.Args...), nil, &stdout, &stderr)
+			assertEqual(t, 0, exitCode)
+			assertEqual(t, "", stderr.String())
+			assertEqual(t, tc.Expected, stdout.String())
+		})
+	}
+
+	t.Run("invalid_output", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		exitCode := run([]string{"list-vars", "-output", "xml", configPath}, nil, &stdout, &stderr)
+		assertEqual(t, 1, exitCode)
+	})
+}
+
+func TestListVars_schema(t *testing.T) {
+	type serverConfig struct {
+		Port    goenvconf.EnvInt       `json:"port"`
+		Origins goenvconf.EnvIntSlice  `json:"origins"`
+		Labels  goenvconf.EnvMapString `json:"labels"`
+	}
+
+	type config struct {
+		Server serverConfig        `json:"server"`
+		Token  goenvconf.EnvString `json:"token"`
+		Debug  goenvconf.EnvBool   `json:"debug"`
+	}
+
+	schema, err := goenvconf.GenerateSchema(config{
+		Server: serverConfig{
+			Port:    goenvconf.NewEnvInt("PORT", 8080),
+			Origins: goenvconf.NewEnvIntSliceVariable("ORIGINS"),
+			Labels:  goenvconf.NewEnvMapStringVariable("LABELS"),
+		},
+		Token: goenvconf.NewEnvStringVariable("TOKEN"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	exitCode := run([]string{"list-vars", "-schema", "-format", "json", "-"}, bytes.NewReader(data), &stdout, &stderr)
+	assertEqual(t, 0, exitCode)
+	assertEqual(t, "", stderr.String())
+	assertEqual(t, `NAME     TYPE               REQUIRED  DEFAULT  FIELDS
+LABELS   map[string]string  yes                server.labels
+ORIGINS  []int              yes                server.origins
+PORT     int                no        8080     server.port
+TOKEN    string             yes                token
+`, stdout.String())
+}
@@ -0,0 +1,94 @@
+// Command goenvconf inspects config documents which contain goenvconf env references,
+// e.g. {"value": 8080, "env": "PORT"} or ${PORT:-8080}.
+//
+// Usage:
+//
+//	goenvconf <command> [flags] <file>...
+//
+// Commands:
+//
+//	render	Resolve every env reference of a JSON or YAML document and print the materialized document.
+//	lint	Check dotenv files and config documents for duplicate keys, malformed values, unknown variables
+//		and naming convention violations.
+//	list-vars	List environment variables which are referenced by a config document or a generated JSON schema,
+//		with their types, whether they are required, and their defaults.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// errUsage occurs when the command line is invalid. The usage is printed instead of the error.
+var errUsage = errors.New("invalid usage")
+
+type command struct {
+	name        string
+	description string
+	run         func(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error
+}
+
+var commands = []command{
+	{name: "render", description: "Resolve env references of a config document and print the materialized document.", run: runRender},
+	{name: "lint", description: "Check dotenv files and config documents for common mistakes.", run: runLint},
+	{name: "list-vars", description: "List environment variables which are referenced by a config document.", run: runListVars},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command of the arguments and returns the exit code.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stderr)
+
+		if len(args) == 0 {
+			return 2
+		}
+
+		return 0
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		err := cmd.run(args[1:], stdin, stdout, stderr)
+
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		default:
+			fmt.Fprintf(stderr, "goenvconf %s: %s\n", cmd.name, err)
+
+			return 1
+		}
+	}
+
+	fmt.Fprintf(stderr, "goenvconf: unknown command %q\n\n", args[0])
+	printUsage(stderr)
+
+	return 2
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: goenvconf <command> [flags] <file>...")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.description)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "goenvconf <command> -h" for the flags of a command.`)
+}
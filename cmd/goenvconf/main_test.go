@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Args     []string
+		ExitCode int
+		Stderr   string
+	}{
+		{Name: "no_args", ExitCode: 2, Stderr: "Usage: goenvconf <command>"},
+		{Name: "help", Args: []string{"help"}, ExitCode: 0, Stderr: "render"},
+		{Name: "unknown", Args: []string{"foo"}, ExitCode: 2, Stderr: `unknown command "foo"`},
+		{Name: "command_help", Args: []string{"render", "-h"}, ExitCode: 0, Stderr: "Usage: goenvconf render"},
+		{Name: "invalid_flag", Args: []string{"render", "-foo"}, ExitCode: 2, Stderr: "flag provided but not defined"},
+		{Name: "missing_file", Args: []string{"render", "missing.yaml"}, ExitCode: 1, Stderr: "goenvconf render: open missing.yaml"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			exitCode := run(tc.Args, strings.NewReader(""), &stdout, &stderr)
+			assertEqual(t, tc.ExitCode, exitCode)
+
+			if !strings.Contains(stderr.String(), tc.Stderr) {
+				t.Errorf("expected stderr to contain %q, got: %s", tc.Stderr, stderr.String())
+			}
+		})
+	}
+}
+
+func writeTestFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func assertEqual[T comparable](t *testing.T, expected T, value T) {
+	t.Helper()
+
+	if expected != value {
+		t.Errorf("expected: %v, got: %v", expected, value)
+	}
+}
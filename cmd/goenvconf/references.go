@@ -0,0 +1,209 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/hasura/goenvconf"
+	"go.yaml.in/yaml/v3"
+)
+
+// envReference is an env reference of a config document.
+type envReference struct {
+	path  string
+	line  int
+	field string
+	value goenvconf.EnvValue
+}
+
+// documentVisitor is called for env references and other mappings while walking a config document.
+type documentVisitor struct {
+	reference func(node *yaml.Node, field string)
+	// mapping is called for mappings which are not env references if not nil.
+	mapping func(node *yaml.Node, field string)
+}
+
+// walkDocument calls the visitor for every env reference of the document node. Env references are mappings
+// with value and/or env keys only, and variable reference strings, e.g. ${PORT:-8080}.
+func walkDocument(node *yaml.Node, field string, visitor documentVisitor) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			walkDocument(child, field, visitor)
+		}
+	case yaml.MappingNode:
+		if isEnvObjectNode(node) {
+			visitor.reference(node, field)
+
+			return
+		}
+
+		if visitor.mapping != nil {
+			visitor.mapping(node, field)
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walkDocument(node.Content[i+1], joinField(field, node.Content[i].Value), visitor)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			walkDocument(child, field+"["+strconv.Itoa(i)+"]", visitor)
+		}
+	case yaml.ScalarNode:
+		if node.Tag != "!!str" {
+			return
+		}
+
+		decoded, _ := goenvconf.DecodeHook(nil, reflect.TypeFor[goenvconf.EnvString](), node.Value)
+		if ev, ok := decoded.(goenvconf.EnvString); ok && ev.Variable != nil {
+			visitor.reference(node, field)
+		}
+	default:
+	}
+}
+
+// decodeReference decodes the env reference of the document node by the type of the literal value,
+// e.g. EnvIntSlice for [1, 2].
+func decodeReference(path string, node *yaml.Node, field string) (envReference, error) {
+	var data any
+
+	if err := node.Decode(&data); err != nil {
+		return envReference{}, err
+	}
+
+	var literal any
+
+	if object, ok := data.(map[string]any); ok {
+		literal = object["value"]
+	}
+
+	decoded, err := goenvconf.DecodeHook(nil, inferEnvType(literal), data)
+	if err != nil {
+		return envReference{}, err
+	}
+
+	ev, _ := decoded.(goenvconf.EnvValue)
+
+	return envReference{path: path, line: node.Line, field: field, value: ev}, nil
+}
+
+// isEnvObjectNode checks if the mapping only has value and env keys.
+func isEnvObjectNode(node *yaml.Node) bool {
+	if len(node.Content) == 0 {
+		return false
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		if key := node.Content[i].Value; key != "value" && key != "env" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// inferEnvType returns the Env type of the literal value. Scalars, slices and maps of the same scalar type use
+// the typed Env types, e.g. EnvMapInt for {"a": 1}. Other literal values use EnvAny, and strings or no value use EnvString.
+func inferEnvType(literal any) reflect.Type {
+	switch value := literal.(type) {
+	case nil, string:
+		return reflect.TypeFor[goenvconf.EnvString]()
+	case []any:
+		return pickEnvType(scalarKind(value...), sliceEnvTypes)
+	case map[string]any:
+		values := make([]any, 0, len(value))
+
+		for _, item := range value {
+			values = append(values, item)
+		}
+
+		return pickEnvType(scalarKind(values...), mapEnvTypes)
+	default:
+		return pickEnvType(scalarKind(literal), scalarEnvTypes)
+	}
+}
+
+// Env types of scalar kinds, slices and maps in the order of scalar kinds.
+var (
+	scalarEnvTypes = []reflect.Type{
+		reflect.TypeFor[goenvconf.EnvBool](),
+		reflect.TypeFor[goenvconf.EnvInt](),
+		reflect.TypeFor[goenvconf.EnvFloat](),
+		reflect.TypeFor[goenvconf.EnvString](),
+	}
+	sliceEnvTypes = []reflect.Type{
+		reflect.TypeFor[goenvconf.EnvBoolSlice](),
+		reflect.TypeFor[goenvconf.EnvIntSlice](),
+		reflect.TypeFor[goenvconf.EnvFloatSlice](),
+		reflect.TypeFor[goenvconf.EnvStringSlice](),
+	}
+	mapEnvTypes = []reflect.Type{
+		reflect.TypeFor[goenvconf.EnvMapBool](),
+		reflect.TypeFor[goenvconf.EnvMapInt](),
+		reflect.TypeFor[goenvconf.EnvMapFloat](),
+		reflect.TypeFor[goenvconf.EnvMapString](),
+	}
+)
+
+// Scalar kinds in the order of the types of pickEnvType.
+const (
+	kindBool = iota
+	kindInt
+	kindFloat
+	kindString
+	kindOther
+)
+
+// scalarKind returns the common scalar kind of the values. Integers and floats are mixed as floats,
+// and an empty list is a string list.
+func scalarKind(values ...any) int {
+	result := -1
+
+	for _, value := range values {
+		var kind int
+
+		switch value.(type) {
+		case bool:
+			kind = kindBool
+		case int, int64, uint64:
+			kind = kindInt
+		case float64:
+			kind = kindFloat
+		case string:
+			kind = kindString
+		default:
+			return kindOther
+		}
+
+		switch {
+		case result < 0 || result == kind:
+			result = kind
+		case (result == kindInt && kind == kindFloat) || (result == kindFloat && kind == kindInt):
+			result = kindFloat
+		default:
+			return kindOther
+		}
+	}
+
+	if result < 0 {
+		return kindString
+	}
+
+	return result
+}
+
+func pickEnvType(kind int, types []reflect.Type) reflect.Type {
+	if kind == kindOther {
+		return reflect.TypeFor[goenvconf.EnvAny]()
+	}
+
+	return types[kind]
+}
+
+func joinField(field string, key string) string {
+	if field == "" {
+		return key
+	}
+
+	return field + "." + key
+}
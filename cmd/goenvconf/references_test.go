@@ -0,0 +1,80 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+	"go.yaml.in/yaml/v3"
+)
+
+func TestWalkDocument(t *testing.T) {
+	var document yaml.Node
+
+	if err := yaml.Unmarshal([]byte(`server:
+  host: ${HOST:-localhost}
+  port: {value: 8080, env: PORT}
+  name: $$literal
+origins:
+  - {env: ORIGIN}
+  - static
+empty: {}
+`), &document); err != nil {
+		t.Fatal(err)
+	}
+
+	var references, mappings []string
+
+	walkDocument(&document, "", documentVisitor{
+		reference: func(node *yaml.Node, field string) {
+			reference, err := decodeReference("config.yaml", node, field)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			references = append(references, field+"="+reference.value.Variables()[0])
+		},
+		mapping: func(_ *yaml.Node, field string) {
+			mappings = append(mappings, field)
+		},
+	})
+
+	assertEqual(t, "server.host=HOST server.port=PORT origins[0]=ORIGIN", strings.Join(references, " "))
+	assertEqual(t, " server empty", strings.Join(mappings, " "))
+}
+
+func TestEnvTypeName(t *testing.T) {
+	testCases := map[reflect.Type]string{
+		reflect.TypeFor[goenvconf.EnvString]():   "string",
+		reflect.TypeFor[goenvconf.EnvFloat]():    "float",
+		reflect.TypeFor[goenvconf.EnvAny]():      "any",
+		reflect.TypeFor[goenvconf.EnvIntSlice](): "[]int",
+		reflect.TypeFor[goenvconf.EnvMapInt]():   "map[string]int",
+	}
+
+	for input, expected := range testCases {
+		assertEqual(t, expected, envTypeName(input))
+	}
+}
+
+func TestInferEnvType(t *testing.T) {
+	testCases := []struct {
+		Literal  any
+		Expected reflect.Type
+	}{
+		{Literal: nil, Expected: reflect.TypeFor[goenvconf.EnvString]()},
+		{Literal: true, Expected: reflect.TypeFor[goenvconf.EnvBool]()},
+		{Literal: 1, Expected: reflect.TypeFor[goenvconf.EnvInt]()},
+		{Literal: 0.5, Expected: reflect.TypeFor[goenvconf.EnvFloat]()},
+		{Literal: []any{1, 0.5}, Expected: reflect.TypeFor[goenvconf.EnvFloatSlice]()},
+		{Literal: []any{}, Expected: reflect.TypeFor[goenvconf.EnvStringSlice]()},
+		{Literal: []any{"a", 1}, Expected: reflect.TypeFor[goenvconf.EnvAny]()},
+		{Literal: map[string]any{"a": false}, Expected: reflect.TypeFor[goenvconf.EnvMapBool]()},
+		{Literal: map[string]any{"a": []any{}}, Expected: reflect.TypeFor[goenvconf.EnvAny]()},
+	}
+
+	for _, tc := range testCases {
+		assertEqual(t, tc.Expected, inferEnvType(tc.Literal))
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/hasura/goenvconf"
+)
+
+// runRender resolves every env reference of a config document and prints the materialized document,
+// so operators can see exactly what a service loads without running it.
+func runRender(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	var (
+		getter       getterFlags
+		inputFormat  string
+		outputFormat string
+	)
+
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: goenvconf render [flags] <file>")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Resolve env references of a JSON or YAML config document and print the materialized document.")
+		fmt.Fprintln(stderr, "Placeholders embedded in other strings are expanded, e.g. http://${HOST:-localhost}:8080.")
+		fmt.Fprintln(stderr, `Use "-" as the file to read the document from stdin.`)
+		fmt.Fprintln(stderr)
+		flags.PrintDefaults()
+	}
+
+	getter.register(flags)
+	flags.StringVar(&inputFormat, "format", "", "format of the document, json or yaml (default: detected by the file extension)")
+	flags.StringVar(&outputFormat, "output", "", "output format, json or yaml (default: the format of the document)")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+
+		return errUsage
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+
+		return errUsage
+	}
+
+	document, format, err := readDocument(flags.Arg(0), inputFormat, stdin)
+	if err != nil {
+		return err
+	}
+
+	getFunc, err := getter.getEnvFunc()
+	if err != nil {
+		return err
+	}
+
+	result, err := goenvconf.ResolveDocument(document, getFunc)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "" {
+		outputFormat = format
+	}
+
+	return writeDocument(stdout, result, outputFormat)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	configPath := writeTestFile(t, "config.yaml", `server:
+  host: ${HOST:-localhost}
+  port:
+    value: 8080
+    env: PORT
+  url: "http://${HOST:-localhost}:1"
+origins:
+  - env: ORIGIN
+name: app
+`)
+	envPath := writeTestFile(t, ".env", "PORT=9090\nORIGIN=example.com\n")
+
+	t.Run("yaml", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		exitCode := run([]string{"render", "-no-os-env", "-env-file", envPath, "-set", "ORIGIN=localhost", configPath},
+			nil, &stdout, &stderr)
+		assertEqual(t, 0, exitCode)
+		assertEqual(t, "", stderr.String())
+		assertEqual(t, `name: app
+origins:
+  - localhost
+server:
+  host: localhost
+  port: 9090
+  url: http://localhost:1
+`, stdout.String())
+	})
+
+	t.Run("json_output", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		exitCode := run([]string{"render", "-no-os-env", "-env-file", envPath, "-output", "json", configPath}, nil, &stdout, &stderr)
+		assertEqual(t, 0, exitCode)
+		assertEqual(t, `{
+  "name": "app",
+  "origins": [
+    "example.com"
+  ],
+  "server": {
+    "host": "localhost",
+    "port": 9090,
+    "url": "http://localhost:1"
+  }
+}
+`, stdout.String())
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		stdin := strings.NewReader(`{"id": {"value": 12345678901234567890, "env": "ID"}, "url": "${URL}"}`)
+
+		exitCode := run([]string{"render", "-no-os-env", "-format", "json", "-set", "URL=http://localhost", "-"}, stdin, &stdout, &stderr)
+		assertEqual(t, 0, exitCode)
+		assertEqual(t, "{\n  \"id\": 12345678901234567890,\n  \"url\": \"http://localhost\"\n}\n", stdout.String())
+	})
+
+	t.Run("resolution_error", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		exitCode := run([]string{"render", "-no-os-env", "-set", "PORT=x", configPath}, nil, &stdout, &stderr)
+		assertEqual(t, 1, exitCode)
+		assertEqual(t, "", stdout.String())
+
+		if !strings.Contains(stderr.String(), "server.port (PORT): ") {
+			t.Errorf("expected the path of the reference, got: %s", stderr.String())
+		}
+	})
+
+	t.Run("invalid_set", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		exitCode := run([]string{"render", "-set", "PORT", configPath}, nil, &stdout, &stderr)
+		assertEqual(t, 2, exitCode)
+	})
+}
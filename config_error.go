@@ -0,0 +1,74 @@
+package goenvconf
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ConfigError is the error of a config field.
+type ConfigError struct {
+	// Path is the field path in the config struct, e.g. Server.Port.
+	Path string
+	// Variable is the environment variable name of the field if any.
+	Variable string
+	// Err is the underlying error, usually a [ParseEnvError].
+	Err error
+}
+
+// Error returns the error message.
+func (ce ConfigError) Error() string {
+	message := ce.Err.Error()
+
+	if ce.Variable == "" || strings.HasPrefix(message, ce.Variable+":") {
+		return ce.Path + ": " + message
+	}
+
+	return ce.Path + " (" + ce.Variable + "): " + message
+}
+
+// Unwrap returns the underlying error.
+func (ce ConfigError) Unwrap() error {
+	return ce.Err
+}
+
+// ConfigErrors collects errors of many config fields. It is returned by bulk APIs such as [Binder].
+type ConfigErrors []ConfigError
+
+// Error renders a multi-line report of all errors.
+func (ce ConfigErrors) Error() string {
+	if len(ce) == 1 {
+		return ce[0].Error()
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(strconv.Itoa(len(ce)))
+	sb.WriteString(" config errors:")
+
+	for _, err := range ce {
+		sb.WriteString("\n  - ")
+		sb.WriteString(err.Error())
+	}
+
+	return sb.String()
+}
+
+// Unwrap returns all errors so errors.Is and errors.As can match any of them.
+func (ce ConfigErrors) Unwrap() []error {
+	results := make([]error, len(ce))
+
+	for i, err := range ce {
+		results[i] = err
+	}
+
+	return results
+}
+
+// toError returns nil if there is no error.
+func (ce ConfigErrors) toError() error {
+	if len(ce) == 0 {
+		return nil
+	}
+
+	return ce
+}
@@ -0,0 +1,377 @@
+package goenvconf
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The constraint methods return a copy of the instance with the constraint attached.
+// Constraints are evaluated on the resolved value by Get and GetCustom, and return a [ParseEnvError]
+// with the ValidationFailed code if violated. The hint of the error is the violating value,
+// which is omitted if the instance is secret.
+
+// NewEnvStringRequired creates an EnvString instance with a variable name, whose resolved value must not be empty.
+func NewEnvStringRequired(env string) EnvString {
+	return NewEnvStringVariable(env).Required()
+}
+
+// Required requires the resolved value to be non-empty.
+// An environment variable which is set to an empty string returns an error naming the variable.
+func (ev EnvString) Required() EnvString {
+	ev.options = withValidator(ev.options, func(value string) error {
+		if value == "" {
+			return NewValidationFailedError("the value must not be empty", "")
+		}
+
+		return nil
+	})
+
+	return ev
+}
+
+// WithPattern requires the resolved value to match the regular expression.
+func (ev EnvString) WithPattern(pattern *regexp.Regexp) EnvString {
+	ev.options = withValidator(ev.options, func(value string) error {
+		if !pattern.MatchString(value) {
+			return NewValidationFailedError("the value must match the pattern "+pattern.String(), value)
+		}
+
+		return nil
+	})
+
+	return ev
+}
+
+// WithOneOf requires the resolved value to be one of the allowed values.
+func (ev EnvString) WithOneOf(values ...string) EnvString {
+	if len(values) == 0 {
+		ev.options = withError(ev.options, NewParseEnvFailedError("WithOneOf requires at least one value", ""))
+	}
+
+	ev.options = withValidator(ev.options, func(value string) error {
+		if !slices.Contains(values, value) {
+			return NewValidationFailedError("the value must be one of "+strings.Join(values, ", "), value)
+		}
+
+		return nil
+	})
+
+	return ev
+}
+
+// NewEnvIntInRange creates an EnvInt instance whose resolved value must be in the range [minValue, maxValue].
+// Out-of-range values are rejected by Get and GetCustom.
+// Use NewEnvInt(env, value).WithClamp(minValue, maxValue) to clamp them instead.
+func NewEnvIntInRange(env string, value int64, minValue int64, maxValue int64) EnvInt {
+	result := NewEnvInt(env, value).WithMin(minValue).WithMax(maxValue)
+	if minValue > maxValue {
+		result.options = withError(result.options, newInvalidRangeError(formatIntText(minValue), formatIntText(maxValue)))
+	}
+
+	return result
+}
+
+// WithClamp returns a copy of the instance which clamps the resolved value to the range [minValue, maxValue].
+// The value is clamped before constraints and validators run.
+func (ev EnvInt) WithClamp(minValue int64, maxValue int64) EnvInt {
+	if minValue > maxValue {
+		ev.options = withError(ev.options, newInvalidRangeError(formatIntText(minValue), formatIntText(maxValue)))
+	}
+
+	ev.options = withTransform(ev.options, func(value int64) (int64, error) {
+		return max(minValue, min(value, maxValue)), nil
+	})
+
+	return ev
+}
+
+// WithMin requires the resolved value to be greater than or equal to the minimum value.
+func (ev EnvInt) WithMin(minValue int64) EnvInt {
+	ev.options = withValidator(ev.options, func(value int64) error {
+		if value < minValue {
+			return NewValidationFailedError(
+				"the value must be greater than or equal to "+strconv.FormatInt(minValue, 10),
+				strconv.FormatInt(value, 10),
+			)
+		}
+
+		return nil
+	})
+
+	return ev
+}
+
+// WithMax requires the resolved value to be less than or equal to the maximum value.
+func (ev EnvInt) WithMax(maxValue int64) EnvInt {
+	ev.options = withValidator(ev.options, func(value int64) error {
+		if value > maxValue {
+			return NewValidationFailedError(
+				"the value must be less than or equal to "+strconv.FormatInt(maxValue, 10),
+				strconv.FormatInt(value, 10),
+			)
+		}
+
+		return nil
+	})
+
+	return ev
+}
+
+// WithOneOf requires the resolved value to be one of the allowed values.
+func (ev EnvInt) WithOneOf(values ...int64) EnvInt {
+	if len(values) == 0 {
+		ev.options = withError(ev.options, NewParseEnvFailedError("WithOneOf requires at least one value", ""))
+	}
+
+	ev.options = withValidator(ev.options, func(value int64) error {
+		if !slices.Contains(values, value) {
+			return NewValidationFailedError(
+				"the value must be one of "+strings.ReplaceAll(formatSliceText(values, formatIntText), ",", ", "),
+				strconv.FormatInt(value, 10),
+			)
+		}
+
+		return nil
+	})
+
+	return ev
+}
+
+// WithMin requires the resolved value to be greater than or equal to the minimum value.
+func (ev EnvFloat) WithMin(minValue float64) EnvFloat {
+	ev.options = withValidator(ev.options, func(value float64) error {
+		if value < minValue || math.IsNaN(value) {
+			return NewValidationFailedError(
+				"the value must be greater than or equal to "+formatFloatText(minValue),
+				formatFloatText(value),
+			)
+		}
+
+		return nil
+	})
+
+	return ev
+}
+
+// WithMax requires the resolved value to be less than or equal to the maximum value.
+func (ev EnvFloat) WithMax(maxValue float64) EnvFloat {
+	ev.options = withValidator(ev.options, func(value float64) error {
+		if value > maxValue || math.IsNaN(value) {
+			return NewValidationFailedError(
+				"the value must be less than or equal to "+formatFloatText(maxValue),
+				formatFloatText(value),
+			)
+		}
+
+		return nil
+	})
+
+	return ev
+}
+
+// WithOneOf requires the resolved value to be one of the allowed values.
+func (ev EnvFloat) WithOneOf(values ...float64) EnvFloat {
+	if len(values) == 0 {
+		ev.options = withError(ev.options, NewParseEnvFailedError("WithOneOf requires at least one value", ""))
+	}
+
+	ev.options = withValidator(ev.options, func(value float64) error {
+		if !slices.Contains(values, value) {
+			return NewValidationFailedError(
+				"the value must be one of "+strings.ReplaceAll(formatSliceText(values, formatFloatText), ",", ", "),
+				formatFloatText(value),
+			)
+		}
+
+		return nil
+	})
+
+	return ev
+}
+
+// WithFiniteOnly rejects NaN and infinite values, which [strconv.ParseFloat] accepts.
+func (ev EnvFloat) WithFiniteOnly() EnvFloat {
+	ev.options = withValidator(ev.options, validateFiniteFloat)
+
+	return ev
+}
+
+// WithFiniteOnly rejects NaN and infinite items, which [strconv.ParseFloat] accepts.
+func (ev EnvFloatSlice) WithFiniteOnly() EnvFloatSlice {
+	ev.options = withValidator(ev.options, func(values []float64) error {
+		for i, value := range values {
+			if err := validateFiniteFloat(value); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+
+		return nil
+	})
+
+	return ev
+}
+
+// WithFiniteOnly rejects NaN and infinite values, which [strconv.ParseFloat] accepts.
+func (ev EnvMapFloat) WithFiniteOnly() EnvMapFloat {
+	ev.options = withValidator(ev.options, func(values map[string]float64) error {
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			if err := validateFiniteFloat(values[key]); err != nil {
+				return fmt.Errorf("[%s]: %w", key, err)
+			}
+		}
+
+		return nil
+	})
+
+	return ev
+}
+
+// validateMinItems requires the slice to have at least minItems items.
+func validateMinItems[T any](minItems int) func([]T) error {
+	return func(values []T) error {
+		if len(values) < minItems {
+			return NewValidationFailedError(
+				"the number of items must be greater than or equal to "+strconv.Itoa(minItems),
+				strconv.Itoa(len(values)),
+			)
+		}
+
+		return nil
+	}
+}
+
+// validateMaxItems requires the slice to have at most maxItems items.
+func validateMaxItems[T any](maxItems int) func([]T) error {
+	return func(values []T) error {
+		if len(values) > maxItems {
+			return NewValidationFailedError(
+				"the number of items must be less than or equal to "+strconv.Itoa(maxItems),
+				strconv.Itoa(len(values)),
+			)
+		}
+
+		return nil
+	}
+}
+
+// WithMinItems requires the resolved slice to have at least minItems items.
+func (ev EnvStringSlice) WithMinItems(minItems int) EnvStringSlice {
+	ev.options = withValidator(ev.options, validateMinItems[string](minItems))
+
+	return ev
+}
+
+// WithMaxItems requires the resolved slice to have at most maxItems items.
+func (ev EnvStringSlice) WithMaxItems(maxItems int) EnvStringSlice {
+	ev.options = withValidator(ev.options, validateMaxItems[string](maxItems))
+
+	return ev
+}
+
+// WithMinItems requires the resolved slice to have at least minItems items.
+func (ev EnvIntSlice) WithMinItems(minItems int) EnvIntSlice {
+	ev.options = withValidator(ev.options, validateMinItems[int64](minItems))
+
+	return ev
+}
+
+// WithMaxItems requires the resolved slice to have at most maxItems items.
+func (ev EnvIntSlice) WithMaxItems(maxItems int) EnvIntSlice {
+	ev.options = withValidator(ev.options, validateMaxItems[int64](maxItems))
+
+	return ev
+}
+
+// WithMinItems requires the resolved slice to have at least minItems items.
+func (ev EnvFloatSlice) WithMinItems(minItems int) EnvFloatSlice {
+	ev.options = withValidator(ev.options, validateMinItems[float64](minItems))
+
+	return ev
+}
+
+// WithMaxItems requires the resolved slice to have at most maxItems items.
+func (ev EnvFloatSlice) WithMaxItems(maxItems int) EnvFloatSlice {
+	ev.options = withValidator(ev.options, validateMaxItems[float64](maxItems))
+
+	return ev
+}
+
+// WithMinItems requires the resolved slice to have at least minItems items.
+func (ev EnvBoolSlice) WithMinItems(minItems int) EnvBoolSlice {
+	ev.options = withValidator(ev.options, validateMinItems[bool](minItems))
+
+	return ev
+}
+
+// WithMaxItems requires the resolved slice to have at most maxItems items.
+func (ev EnvBoolSlice) WithMaxItems(maxItems int) EnvBoolSlice {
+	ev.options = withValidator(ev.options, validateMaxItems[bool](maxItems))
+
+	return ev
+}
+
+// checkRequiredMap returns an error if the map value is required but not resolved.
+func (eo *envOptions) checkRequiredMap(variable *string, isNil bool) error {
+	if eo == nil || !eo.requireMap || !isNil {
+		return nil
+	}
+
+	if variable == nil || *variable == "" {
+		return ErrEnvironmentValueRequired
+	}
+
+	return getEnvVariableValueRequiredError(variable)
+}
+
+// RequireValue returns errors instead of a nil map like scalar and slice types: [ErrEnvironmentValueRequired]
+// if the instance is zero, or an EmptyVar error naming the variable if it is unset and there is no literal value.
+func (ev EnvMapString) RequireValue() EnvMapString {
+	ev.options = ev.options.clone()
+	ev.options.requireMap = true
+
+	return ev
+}
+
+// RequireValue returns errors instead of a nil map like scalar and slice types: [ErrEnvironmentValueRequired]
+// if the instance is zero, or an EmptyVar error naming the variable if it is unset and there is no literal value.
+func (ev EnvMapInt) RequireValue() EnvMapInt {
+	ev.options = ev.options.clone()
+	ev.options.requireMap = true
+
+	return ev
+}
+
+// RequireValue returns errors instead of a nil map like scalar and slice types: [ErrEnvironmentValueRequired]
+// if the instance is zero, or an EmptyVar error naming the variable if it is unset and there is no literal value.
+func (ev EnvMapFloat) RequireValue() EnvMapFloat {
+	ev.options = ev.options.clone()
+	ev.options.requireMap = true
+
+	return ev
+}
+
+// RequireValue returns errors instead of a nil map like scalar and slice types: [ErrEnvironmentValueRequired]
+// if the instance is zero, or an EmptyVar error naming the variable if it is unset and there is no literal value.
+func (ev EnvMapBool) RequireValue() EnvMapBool {
+	ev.options = ev.options.clone()
+	ev.options.requireMap = true
+
+	return ev
+}
+
+func validateFiniteFloat(value float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return NewValidationFailedError("the value must be a finite number", formatFloatText(value))
+	}
+
+	return nil
+}
+
+func newInvalidRangeError(minValue string, maxValue string) ParseEnvError {
+	return NewParseEnvFailedError("the minimum value must be less than or equal to the maximum value", minValue+" > "+maxValue)
+}
@@ -0,0 +1,278 @@
+package goenvconf
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"testing"
+)
+
+func TestConstraints(t *testing.T) {
+	t.Setenv("TEST_CONSTRAINT_PORT", "0")
+	t.Setenv("TEST_CONSTRAINT_MODE", "debug")
+
+	testCases := []struct {
+		Name     string
+		Get      func() (any, error)
+		Expected any
+		ErrorMsg string
+	}{
+		{
+			Name: "int_in_range",
+			Get: func() (any, error) {
+				return NewEnvIntValue(8080).WithMin(1).WithMax(65535).Get()
+			},
+			Expected: int64(8080),
+		},
+		{
+			Name: "int_min",
+			Get: func() (any, error) {
+				return NewEnvInt("TEST_CONSTRAINT_PORT", 8080).WithMin(1).WithMax(65535).Get()
+			},
+			ErrorMsg: "TEST_CONSTRAINT_PORT: ValidationFailed: the value must be greater than or equal to 1. Hint: 0",
+		},
+		{
+			Name: "int_max",
+			Get: func() (any, error) {
+				return NewEnvIntValue(70000).WithMin(1).WithMax(65535).GetCustom(GetOSEnv)
+			},
+			ErrorMsg: "ValidationFailed: the value must be less than or equal to 65535. Hint: 70000",
+		},
+		{
+			Name: "int_one_of",
+			Get: func() (any, error) {
+				return NewEnvIntValue(3).WithOneOf(1, 2).Get()
+			},
+			ErrorMsg: "the value must be one of 1, 2. Hint: 3",
+		},
+		{
+			Name: "float_in_range",
+			Get: func() (any, error) {
+				return NewEnvFloatValue(0.5).WithMin(0).WithMax(1).WithOneOf(0.5).Get()
+			},
+			Expected: 0.5,
+		},
+		{
+			Name: "float_min",
+			Get: func() (any, error) {
+				return NewEnvFloatValue(-0.5).WithMin(0).Get()
+			},
+			ErrorMsg: "the value must be greater than or equal to 0. Hint: -0.5",
+		},
+		{
+			Name: "float_max_nan",
+			Get: func() (any, error) {
+				return NewEnvFloatValue(math.NaN()).WithMax(1).Get()
+			},
+			ErrorMsg: "the value must be less than or equal to 1. Hint: NaN",
+		},
+		{
+			Name: "string_pattern",
+			Get: func() (any, error) {
+				return NewEnvStringValue("my-bucket").WithPattern(regexp.MustCompile(`^[a-z-]+$`)).Get()
+			},
+			Expected: "my-bucket",
+		},
+		{
+			Name: "string_pattern_failed",
+			Get: func() (any, error) {
+				return NewEnvStringValue("My_Bucket").WithPattern(regexp.MustCompile(`^[a-z-]+$`)).Get()
+			},
+			ErrorMsg: "the value must match the pattern ^[a-z-]+$. Hint: My_Bucket",
+		},
+		{
+			Name: "string_one_of",
+			Get: func() (any, error) {
+				return NewEnvStringVariable("TEST_CONSTRAINT_MODE").WithOneOf("info", "warn").GetCustom(GetOSEnv)
+			},
+			ErrorMsg: "TEST_CONSTRAINT_MODE: ValidationFailed: the value must be one of info, warn. Hint: debug",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := tc.Get()
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+
+				var parseErr ParseEnvError
+				assertDeepEqual(t, true, errors.As(err, &parseErr))
+				assertDeepEqual(t, ErrCodeValidationFailed, parseErr.Code)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestConstraints_secret(t *testing.T) {
+	t.Cleanup(ResetSecretVariables)
+	t.Setenv("TEST_CONSTRAINT_SECRET", "hunter2")
+	t.Setenv("TEST_CONSTRAINT_TOKEN", "hunter2")
+
+	_, err := NewEnvStringVariable("TEST_CONSTRAINT_SECRET").WithOneOf("a", "b").Secret().Get()
+	assertDeepEqual(t, "TEST_CONSTRAINT_SECRET: ValidationFailed: the value must be one of a, b", err.Error())
+
+	_, err = NewEnvStringVariable("TEST_CONSTRAINT_SECRET").Secret().WithPattern(regexp.MustCompile(`^[a-z]+$`)).Get()
+	assertDeepEqual(t, "TEST_CONSTRAINT_SECRET: ValidationFailed: the value must match the pattern ^[a-z]+$", err.Error())
+
+	assertNilError(t, RegisterSecretVariables("*_TOKEN"))
+
+	_, err = NewEnvStringVariable("TEST_CONSTRAINT_TOKEN").WithOneOf("a").Get()
+	assertDeepEqual(t, "TEST_CONSTRAINT_TOKEN: ValidationFailed: the value must be one of a", err.Error())
+
+	// Other validator errors of secret values are redacted.
+	_, err = NewEnvStringVariable("TEST_CONSTRAINT_SECRET").Secret().WithValidator(ValidatorFunc[string](func(value string) error {
+		return errors.New("invalid value " + value)
+	})).Get()
+	assertDeepEqual(t, "TEST_CONSTRAINT_SECRET: [REDACTED]", err.Error())
+
+	// Values which are not secret are kept in the hint.
+	_, err = NewEnvStringVariable("TEST_CONSTRAINT_SECRET").WithOneOf("a").Get()
+	assertErrorContains(t, err, "Hint: hunter2")
+}
+
+func TestConstraints_CopyOnWrite(t *testing.T) {
+	base := NewEnvIntValue(0).WithMax(10)
+	withMin := base.WithMin(1)
+
+	_, err := base.Get()
+	assertNilError(t, err)
+
+	_, err = withMin.Get()
+	assertErrorContains(t, err, "the value must be greater than or equal to 1")
+
+	result, err := withMin.GetOrDefault(5)
+	assertErrorContains(t, err, "the value must be greater than or equal to 1")
+	assertDeepEqual(t, int64(0), result)
+}
+
+func TestNewEnvIntInRange(t *testing.T) {
+	t.Setenv("TEST_RANGE_WORKERS", "1000")
+
+	_, err := NewEnvIntInRange("TEST_RANGE_WORKERS", 4, 1, 64).Get()
+	assertErrorContains(t, err, "TEST_RANGE_WORKERS: ValidationFailed: the value must be less than or equal to 64. Hint: 1000")
+
+	result, err := NewEnvIntInRange("TEST_RANGE_MISSING", 4, 1, 64).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(4), result)
+
+	result, err = NewEnvInt("TEST_RANGE_WORKERS", 4).WithClamp(1, 64).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(64), result)
+
+	result, err = NewEnvIntValue(-5).WithClamp(1, 64).GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(1), result)
+
+	result, err = NewEnvIntInRange("TEST_RANGE_WORKERS", 4, 1, 64).WithClamp(1, 64).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(64), result)
+}
+
+func TestEnvStringRequired(t *testing.T) {
+	t.Setenv("TEST_REQUIRED_EMPTY", "")
+	t.Setenv("TEST_REQUIRED_FOO", "foo")
+
+	_, err := NewEnvStringRequired("TEST_REQUIRED_EMPTY").Get()
+	assertErrorContains(t, err, "TEST_REQUIRED_EMPTY: ValidationFailed: the value must not be empty")
+
+	_, err = NewEnvStringRequired("TEST_REQUIRED_EMPTY").GetCustom(GetOSEnv)
+	assertErrorContains(t, err, "TEST_REQUIRED_EMPTY: ValidationFailed: the value must not be empty")
+
+	_, err = NewEnvStringValue("").Required().Get()
+	assertErrorContains(t, err, "ValidationFailed: the value must not be empty")
+
+	result, err := NewEnvStringRequired("TEST_REQUIRED_FOO").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "foo", result)
+
+	result, err = NewEnvString("TEST_REQUIRED_EMPTY", "fallback").Required().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "fallback", result)
+}
+
+func TestWithFiniteOnly(t *testing.T) {
+	t.Setenv("FINITE_FLOAT", "NaN")
+	t.Setenv("FINITE_FLOAT_SLICE", "1.5,-Inf")
+	t.Setenv("FINITE_FLOAT_MAP", "a=1;b=+Inf")
+
+	value, err := NewEnvFloatVariable("FINITE_FLOAT").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, true, math.IsNaN(value))
+
+	_, err = NewEnvFloatVariable("FINITE_FLOAT").WithFiniteOnly().Get()
+	assertDeepEqual(t, "FINITE_FLOAT: ValidationFailed: the value must be a finite number. Hint: NaN", err.Error())
+
+	_, err = NewEnvFloatSliceVariable("FINITE_FLOAT_SLICE").WithFiniteOnly().GetCustom(GetOSEnv)
+	assertDeepEqual(t, "FINITE_FLOAT_SLICE: [1]: ValidationFailed: the value must be a finite number. Hint: -Inf", err.Error())
+
+	_, err = NewEnvMapFloatVariable("FINITE_FLOAT_MAP").WithFiniteOnly().Get()
+	assertDeepEqual(t, "FINITE_FLOAT_MAP: [b]: ValidationFailed: the value must be a finite number. Hint: +Inf", err.Error())
+
+	values, err := NewEnvFloatSliceValue([]float64{1, 2}).WithFiniteOnly().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []float64{1, 2}, values)
+
+	mapValues, err := NewEnvMapFloatValue(map[string]float64{"a": 1}).WithFiniteOnly().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]float64{"a": 1}, mapValues)
+}
+
+func TestRequireValue(t *testing.T) {
+	getFunc := newBinderGetter(map[string]string{"LIMITS": "a=1"})
+
+	result, err := EnvMapInt{}.GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64(nil), result)
+
+	_, err = EnvMapInt{}.RequireValue().GetCustom(getFunc)
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentValueRequired))
+
+	_, err = NewEnvMapStringVariable("TEST_REQUIRE_VALUE_MISSING").RequireValue().Get()
+	assertErrorContains(t, err, "TEST_REQUIRE_VALUE_MISSING: EmptyVar")
+
+	_, err = NewEnvMapFloatVariable("MISSING").RequireValue().GetCustom(getFunc)
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+
+	limits, err := NewEnvMapIntVariable("LIMITS").RequireValue().GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"a": 1}, limits)
+
+	flags, err := NewEnvMapBool("MISSING", map[string]bool{}).RequireValue().GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]bool{}, flags)
+
+	var target struct {
+		Limits map[string]int64
+	}
+
+	err = NewBinder(getFunc).Bind(&target, struct {
+		Limits EnvMapInt
+	}{
+		Limits: NewEnvMapIntVariable("MISSING").RequireValue(),
+	})
+	assertErrorContains(t, err, "Limits: MISSING: EmptyVar")
+}
+
+func TestWithMinMaxItems(t *testing.T) {
+	getFunc := newBinderGetter(map[string]string{"PORTS": "1,2,3"})
+
+	_, err := NewEnvStringSlice("MISSING_BROKERS", []string{}).WithMinItems(1).GetCustom(getFunc)
+	assertErrorContains(t, err, "MISSING_BROKERS: ValidationFailed: the number of items must be greater than or equal to 1. Hint: 0")
+
+	_, err = NewEnvIntSliceVariable("PORTS").WithMaxItems(2).GetCustom(getFunc)
+	assertErrorContains(t, err, "PORTS: ValidationFailed: the number of items must be less than or equal to 2. Hint: 3")
+
+	ports, err := NewEnvIntSliceVariable("PORTS").WithMinItems(1).WithMaxItems(3).GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{1, 2, 3}, ports)
+
+	_, err = NewEnvFloatSliceValue([]float64{}).WithMinItems(1).Get()
+	assertErrorContains(t, err, "the number of items must be greater than or equal to 1")
+
+	flags, err := NewEnvBoolSliceValue([]bool{true}).WithMaxItems(1).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []bool{true}, flags)
+}
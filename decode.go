@@ -0,0 +1,537 @@
+package goenvconf
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+const (
+	envObjectValueKey    = "value"
+	envObjectVariableKey = "env"
+)
+
+var (
+	envStringType      = reflect.TypeFor[EnvString]()
+	envIntType         = reflect.TypeFor[EnvInt]()
+	envBoolType        = reflect.TypeFor[EnvBool]()
+	envFloatType       = reflect.TypeFor[EnvFloat]()
+	envAnyType         = reflect.TypeFor[EnvAny]()
+	envStringSliceType = reflect.TypeFor[EnvStringSlice]()
+	envIntSliceType    = reflect.TypeFor[EnvIntSlice]()
+	envFloatSliceType  = reflect.TypeFor[EnvFloatSlice]()
+	envBoolSliceType   = reflect.TypeFor[EnvBoolSlice]()
+	envMapStringType   = reflect.TypeFor[EnvMapString]()
+	envMapIntType      = reflect.TypeFor[EnvMapInt]()
+	envMapFloatType    = reflect.TypeFor[EnvMapFloat]()
+	envMapBoolType     = reflect.TypeFor[EnvMapBool]()
+)
+
+// DecodeHook is a decode hook that converts raw configuration data into Env types.
+// It is compatible with the DecodeHookFuncType of mapstructure, so it can be used with viper or mapstructure directly:
+//
+//	mapstructure.DecoderConfig{DecodeHook: goenvconf.DecodeHook}
+//
+// The following input forms are accepted for every Env type:
+//   - An object with value and/or env keys, e.g. {"value": 8080, "env": "PORT"}.
+//   - A variable reference string, e.g. "${PORT}", with an optional default literal value, e.g. "${PORT:-8080}".
+//     Other strings which start with $, e.g. "$ecret", are literal values.
+//   - A scalar literal value, e.g. 8080 or "8080". Slice and map types also accept
+//     the comma-separated and <key1>=<value1>;<key2>=<value2> string formats.
+//
+// Note that an empty map or a literal map containing only value and/or env keys is always treated as the object form.
+func DecodeHook(_ reflect.Type, to reflect.Type, data any) (any, error) { //nolint:cyclop
+	if data == nil || reflect.TypeOf(data) == to {
+		return data, nil
+	}
+
+	switch to {
+	case envStringType:
+		return decodeEnvString(data)
+	case envIntType:
+		return decodeEnvInt(data)
+	case envBoolType:
+		return decodeEnvBool(data)
+	case envFloatType:
+		return decodeEnvFloat(data)
+	case envAnyType:
+		return decodeEnvAny(data)
+	case envStringSliceType:
+		return decodeEnvStringSlice(data)
+	case envIntSliceType:
+		return decodeEnvIntSlice(data)
+	case envFloatSliceType:
+		return decodeEnvFloatSlice(data)
+	case envBoolSliceType:
+		return decodeEnvBoolSlice(data)
+	case envMapStringType:
+		return decodeEnvMapString(data)
+	case envMapIntType:
+		return decodeEnvMapInt(data)
+	case envMapFloatType:
+		return decodeEnvMapFloat(data)
+	case envMapBoolType:
+		return decodeEnvMapBool(data)
+	default:
+		return data, nil
+	}
+}
+
+// envRawInput holds the value and variable decoded from one of the accepted input forms.
+type envRawInput struct {
+	Value    any
+	Variable *string
+}
+
+// decodeEnvRawInput splits the raw data into the literal value and the variable reference.
+func decodeEnvRawInput(data any) (envRawInput, error) {
+	if str, ok := data.(string); ok {
+		if ref, ok := parseEnvReference(str); ok {
+			result := envRawInput{Variable: &ref.Name}
+			if ref.Default != nil {
+				result.Value = *ref.Default
+			}
+
+			return result, nil
+		}
+
+		return envRawInput{Value: str}, nil
+	}
+
+	object, ok := toEnvObject(data)
+	if !ok {
+		return envRawInput{Value: data}, nil
+	}
+
+	result := envRawInput{
+		Value: object[envObjectValueKey],
+	}
+
+	if rawVariable, ok := object[envObjectVariableKey]; ok && rawVariable != nil {
+		variable, ok := rawVariable.(string)
+		if !ok {
+			return result, NewParseEnvFailedError(
+				"invalid env field, expected a string",
+				fmt.Sprint(rawVariable),
+			)
+		}
+
+		result.Variable = &variable
+	}
+
+	return result, nil
+}
+
+// toEnvObject returns the data as a string map if it is an object with value and/or env keys only.
+// An empty object is also treated as the object form of a zero value.
+func toEnvObject(data any) (map[string]any, bool) {
+	var result map[string]any
+
+	switch obj := data.(type) {
+	case map[string]any:
+		result = obj
+	case map[any]any:
+		result = make(map[string]any, len(obj))
+
+		for key, value := range obj {
+			strKey, ok := key.(string)
+			if !ok {
+				return nil, false
+			}
+
+			result[strKey] = value
+		}
+	default:
+		return nil, false
+	}
+
+	for key := range result {
+		if key != envObjectValueKey && key != envObjectVariableKey {
+			return nil, false
+		}
+	}
+
+	return result, true
+}
+
+func decodeEnvString(data any) (EnvString, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvString{}, err
+	}
+
+	result := EnvString{Variable: raw.Variable}
+
+	if raw.Value != nil {
+		value, err := convertToString(raw.Value)
+		if err != nil {
+			return result, err
+		}
+
+		result.Value = &value
+	}
+
+	return result, nil
+}
+
+func decodeEnvInt(data any) (EnvInt, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvInt{}, err
+	}
+
+	result := EnvInt{Variable: raw.Variable}
+
+	if raw.Value != nil {
+		value, err := convertToInt64(raw.Value)
+		if err != nil {
+			return result, err
+		}
+
+		result.Value = &value
+	}
+
+	return result, nil
+}
+
+func decodeEnvBool(data any) (EnvBool, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvBool{}, err
+	}
+
+	result := EnvBool{Variable: raw.Variable}
+
+	if raw.Value != nil {
+		value, err := convertToBool(raw.Value)
+		if err != nil {
+			return result, err
+		}
+
+		result.Value = &value
+	}
+
+	return result, nil
+}
+
+func decodeEnvFloat(data any) (EnvFloat, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvFloat{}, err
+	}
+
+	result := EnvFloat{Variable: raw.Variable}
+
+	if raw.Value != nil {
+		value, err := convertToFloat64(raw.Value)
+		if err != nil {
+			return result, err
+		}
+
+		result.Value = &value
+	}
+
+	return result, nil
+}
+
+func decodeEnvAny(data any) (EnvAny, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvAny{}, err
+	}
+
+	return EnvAny{Value: raw.Value, Variable: raw.Variable}, nil
+}
+
+func decodeEnvStringSlice(data any) (EnvStringSlice, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvStringSlice{}, err
+	}
+
+	value, err := convertToSlice(raw.Value, convertToString, func(input string) ([]string, error) {
+		return parseStringSliceWithErrorPrefix(input, "")
+	})
+	if err != nil {
+		return EnvStringSlice{}, err
+	}
+
+	return EnvStringSlice{Variable: raw.Variable, Value: value}, nil
+}
+
+func decodeEnvIntSlice(data any) (EnvIntSlice, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvIntSlice{}, err
+	}
+
+	value, err := convertToSlice(raw.Value, convertToInt64, ParseIntSliceFromString[int64])
+	if err != nil {
+		return EnvIntSlice{}, err
+	}
+
+	return EnvIntSlice{Variable: raw.Variable, Value: value}, nil
+}
+
+func decodeEnvFloatSlice(data any) (EnvFloatSlice, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvFloatSlice{}, err
+	}
+
+	value, err := convertToSlice(raw.Value, convertToFloat64, ParseFloatSliceFromString[float64])
+	if err != nil {
+		return EnvFloatSlice{}, err
+	}
+
+	return EnvFloatSlice{Variable: raw.Variable, Value: value}, nil
+}
+
+func decodeEnvBoolSlice(data any) (EnvBoolSlice, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvBoolSlice{}, err
+	}
+
+	value, err := convertToSlice(raw.Value, convertToBool, ParseBoolSliceFromString)
+	if err != nil {
+		return EnvBoolSlice{}, err
+	}
+
+	return EnvBoolSlice{Variable: raw.Variable, Value: value}, nil
+}
+
+func decodeEnvMapString(data any) (EnvMapString, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvMapString{}, err
+	}
+
+	value, err := convertToMap(raw.Value, convertToString, ParseStringMapFromString)
+	if err != nil {
+		return EnvMapString{}, err
+	}
+
+	return EnvMapString{Variable: raw.Variable, Value: value}, nil
+}
+
+func decodeEnvMapInt(data any) (EnvMapInt, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvMapInt{}, err
+	}
+
+	value, err := convertToMap(raw.Value, convertToInt64, ParseIntegerMapFromString[int64])
+	if err != nil {
+		return EnvMapInt{}, err
+	}
+
+	return EnvMapInt{Variable: raw.Variable, Value: value}, nil
+}
+
+func decodeEnvMapFloat(data any) (EnvMapFloat, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvMapFloat{}, err
+	}
+
+	value, err := convertToMap(raw.Value, convertToFloat64, ParseFloatMapFromString[float64])
+	if err != nil {
+		return EnvMapFloat{}, err
+	}
+
+	return EnvMapFloat{Variable: raw.Variable, Value: value}, nil
+}
+
+func decodeEnvMapBool(data any) (EnvMapBool, error) {
+	raw, err := decodeEnvRawInput(data)
+	if err != nil {
+		return EnvMapBool{}, err
+	}
+
+	value, err := convertToMap(raw.Value, convertToBool, ParseBoolMapFromString)
+	if err != nil {
+		return EnvMapBool{}, err
+	}
+
+	return EnvMapBool{Variable: raw.Variable, Value: value}, nil
+}
+
+func convertToString(value any) (string, error) {
+	switch val := value.(type) {
+	case string:
+		return val, nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		return fmt.Sprint(val), nil
+	default:
+		return "", NewParseEnvFailedError("invalid string value", fmt.Sprintf("%T", value))
+	}
+}
+
+func convertToInt64(value any) (int64, error) { //nolint:cyclop
+	switch val := value.(type) {
+	case int:
+		return int64(val), nil
+	case int8:
+		return int64(val), nil
+	case int16:
+		return int64(val), nil
+	case int32:
+		return int64(val), nil
+	case int64:
+		return val, nil
+	case uint:
+		return convertUintToInt64(uint64(val))
+	case uint8:
+		return int64(val), nil
+	case uint16:
+		return int64(val), nil
+	case uint32:
+		return int64(val), nil
+	case uint64:
+		return convertUintToInt64(val)
+	case float32:
+		return convertFloatToInt64(float64(val))
+	case float64:
+		return convertFloatToInt64(val)
+	case json.Number:
+		return convertToInt64(val.String())
+	case string:
+		result, err := parseInt[int64](val)
+		if err != nil {
+			return 0, NewParseEnvFailedError("invalid integer value", val)
+		}
+
+		return result, nil
+	default:
+		return 0, NewParseEnvFailedError("invalid integer value", fmt.Sprintf("%T", value))
+	}
+}
+
+func convertUintToInt64(value uint64) (int64, error) {
+	if value > math.MaxInt64 {
+		return 0, NewParseEnvFailedError("integer value overflows int64", strconv.FormatUint(value, 10))
+	}
+
+	return int64(value), nil
+}
+
+func convertFloatToInt64(value float64) (int64, error) {
+	if value != math.Trunc(value) || value < math.MinInt64 || value >= math.MaxInt64 {
+		return 0, NewParseEnvFailedError("invalid integer value", fmt.Sprint(value))
+	}
+
+	return int64(value), nil
+}
+
+func convertToFloat64(value any) (float64, error) {
+	switch val := value.(type) {
+	case float64:
+		return val, nil
+	case float32:
+		return float64(val), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return reflect.ValueOf(val).Convert(reflect.TypeFor[float64]()).Float(), nil
+	case json.Number:
+		return convertToFloat64(val.String())
+	case string:
+		result, err := parseFloat[float64](val)
+		if err != nil {
+			return 0, NewParseEnvFailedError("invalid floating-point number value", val)
+		}
+
+		return result, nil
+	default:
+		return 0, NewParseEnvFailedError("invalid floating-point number value", fmt.Sprintf("%T", value))
+	}
+}
+
+func convertToBool(value any) (bool, error) {
+	switch val := value.(type) {
+	case bool:
+		return val, nil
+	case string:
+		result, err := strconv.ParseBool(val)
+		if err != nil {
+			return false, NewParseEnvFailedError("invalid boolean value", val)
+		}
+
+		return result, nil
+	default:
+		return false, NewParseEnvFailedError("invalid boolean value", fmt.Sprintf("%T", value))
+	}
+}
+
+// convertToSlice converts a raw slice or a comma-separated string to a typed slice.
+func convertToSlice[T any](
+	value any,
+	convert func(any) (T, error),
+	parse func(string) ([]T, error),
+) ([]T, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if str, ok := value.(string); ok {
+		return parse(str)
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() != reflect.Slice && reflectValue.Kind() != reflect.Array {
+		return nil, NewParseEnvFailedError("invalid slice value", fmt.Sprintf("%T", value))
+	}
+
+	results := make([]T, reflectValue.Len())
+
+	for i := range reflectValue.Len() {
+		item, err := convert(reflectValue.Index(i).Interface())
+		if err != nil {
+			return nil, NewParseEnvFailedError("invalid slice value", strconv.Itoa(i))
+		}
+
+		results[i] = item
+	}
+
+	return results, nil
+}
+
+// convertToMap converts a raw map or a <key1>=<value1>;<key2>=<value2> string to a typed map.
+func convertToMap[T any](
+	value any,
+	convert func(any) (T, error),
+	parse func(string) (map[string]T, error),
+) (map[string]T, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if str, ok := value.(string); ok {
+		return parse(str)
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() != reflect.Map {
+		return nil, NewParseEnvFailedError("invalid map value", fmt.Sprintf("%T", value))
+	}
+
+	results := make(map[string]T, reflectValue.Len())
+	iter := reflectValue.MapRange()
+
+	for iter.Next() {
+		key, ok := iter.Key().Interface().(string)
+		if !ok {
+			return nil, NewParseEnvFailedError("invalid map key, expected a string", fmt.Sprint(iter.Key().Interface()))
+		}
+
+		item, err := convert(iter.Value().Interface())
+		if err != nil {
+			return nil, NewParseEnvFailedError("invalid map value", key)
+		}
+
+		results[key] = item
+	}
+
+	return results, nil
+}
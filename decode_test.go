@@ -0,0 +1,149 @@
+package goenvconf
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+type decodeHookTestConfig struct {
+	Name      EnvString      `mapstructure:"name"`
+	Port      EnvInt         `mapstructure:"port"`
+	Debug     EnvBool        `mapstructure:"debug"`
+	Ratio     EnvFloat       `mapstructure:"ratio"`
+	Extra     EnvAny         `mapstructure:"extra"`
+	Hosts     EnvStringSlice `mapstructure:"hosts"`
+	Ports     EnvIntSlice    `mapstructure:"ports"`
+	Weights   EnvFloatSlice  `mapstructure:"weights"`
+	Flags     EnvBoolSlice   `mapstructure:"flags"`
+	Headers   EnvMapString   `mapstructure:"headers"`
+	Limits    EnvMapInt      `mapstructure:"limits"`
+	Scores    EnvMapFloat    `mapstructure:"scores"`
+	Features  EnvMapBool     `mapstructure:"features"`
+	Pointer   *EnvString     `mapstructure:"pointer"`
+	Untouched string         `mapstructure:"untouched"`
+}
+
+func TestDecodeHook(t *testing.T) {
+	input := map[string]any{
+		"name":  "${APP_NAME}",
+		"port":  8080,
+		"debug": "true",
+		"ratio": map[string]any{
+			"env":   "RATIO",
+			"value": 0.5,
+		},
+		"extra":     map[string]any{"foo": "bar"},
+		"hosts":     "a,b",
+		"ports":     []any{1, "2"},
+		"weights":   map[string]any{"value": []any{1.5}},
+		"flags":     "${FLAGS}",
+		"headers":   "foo=bar",
+		"limits":    map[string]any{"a": 1},
+		"scores":    map[string]any{"env": "SCORES"},
+		"features":  map[any]any{"value": map[any]any{"x": true}},
+		"pointer":   "$ecret",
+		"untouched": "${NOT_AN_ENV}",
+	}
+
+	var result decodeHookTestConfig
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: DecodeHook,
+		Result:     &result,
+	})
+	assertNilError(t, err)
+	assertNilError(t, decoder.Decode(input))
+
+	expected := decodeHookTestConfig{
+		Name:      NewEnvStringVariable("APP_NAME"),
+		Port:      NewEnvIntValue(8080),
+		Debug:     NewEnvBoolValue(true),
+		Ratio:     NewEnvFloat("RATIO", 0.5),
+		Extra:     NewEnvAnyValue(map[string]any{"foo": "bar"}),
+		Hosts:     NewEnvStringSliceValue([]string{"a", "b"}),
+		Ports:     NewEnvIntSliceValue([]int64{1, 2}),
+		Weights:   NewEnvFloatSliceValue([]float64{1.5}),
+		Flags:     NewEnvBoolSliceVariable("FLAGS"),
+		Headers:   NewEnvMapStringValue(map[string]string{"foo": "bar"}),
+		Limits:    NewEnvMapIntValue(map[string]int64{"a": 1}),
+		Scores:    NewEnvMapFloatVariable("SCORES"),
+		Features:  NewEnvMapBoolValue(map[string]bool{"x": true}),
+		Pointer:   toPtr(NewEnvStringValue("$ecret")),
+		Untouched: "${NOT_AN_ENV}",
+	}
+
+	assertDeepEqual(t, expected, result)
+}
+
+func TestDecodeHook_Errors(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		To       reflect.Type
+		Data     any
+		ErrorMsg string
+	}{
+		{
+			Name:     "invalid_int",
+			To:       envIntType,
+			Data:     "foo",
+			ErrorMsg: "invalid integer value. Hint: foo",
+		},
+		{
+			Name:     "fractional_int",
+			To:       envIntType,
+			Data:     1.5,
+			ErrorMsg: "invalid integer value. Hint: 1.5",
+		},
+		{
+			Name:     "invalid_bool",
+			To:       envBoolType,
+			Data:     map[string]any{"value": 1},
+			ErrorMsg: "invalid boolean value. Hint: int",
+		},
+		{
+			Name:     "invalid_env",
+			To:       envStringType,
+			Data:     map[string]any{"env": 1},
+			ErrorMsg: "invalid env field, expected a string. Hint: 1",
+		},
+		{
+			Name:     "invalid_slice_item",
+			To:       envIntSliceType,
+			Data:     []any{1, "a"},
+			ErrorMsg: "invalid slice value. Hint: 1",
+		},
+		{
+			Name:     "invalid_map_string",
+			To:       envMapIntType,
+			Data:     "a=b",
+			ErrorMsg: "invalid integer map syntax. Hint: a",
+		},
+		{
+			Name:     "invalid_float",
+			To:       envFloatType,
+			Data:     true,
+			ErrorMsg: "invalid floating-point number value. Hint: bool",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := DecodeHook(reflect.TypeOf(tc.Data), tc.To, tc.Data)
+			assertErrorContains(t, err, tc.ErrorMsg)
+		})
+	}
+}
+
+func TestDecodeHook_Passthrough(t *testing.T) {
+	ev := NewEnvString("FOO", "bar")
+
+	result, err := DecodeHook(envStringType, envStringType, ev)
+	assertNilError(t, err)
+	assertDeepEqual(t, ev, result)
+
+	result, err = DecodeHook(reflect.TypeFor[string](), reflect.TypeFor[string](), "${FOO}")
+	assertNilError(t, err)
+	assertDeepEqual(t, "${FOO}", result)
+}
@@ -0,0 +1,392 @@
+package goenvconf
+
+import (
+	"errors"
+	"reflect"
+)
+
+// The OrElse methods return a copy of the instance which resolves the alternative instance
+// if the value is unset, i.e. the variable is unset and there is no literal value.
+// Alternatives are tried in order, so layered defaults can be composed declaratively, e.g.
+//
+//	NewEnvStringVariable("APP_HOST").OrElse(NewEnvStringVariable("HOST")).WithDefault("localhost")
+//
+// Constraints and transforms of the instance also apply to the value of the alternative.
+// The WithDefault methods return a copy of the instance with the literal value.
+// The WithDefaultVariable methods return a copy of the instance whose default comes from another variable,
+// resolved through the same getter, which is common when renaming variables:
+//
+//	NewEnvStringVariable("BIND_ADDR").WithDefaultVariable("HOST").WithDefault("0.0.0.0")
+//
+// Default variables are looked up in order after candidate variables and deprecated aliases,
+// and the literal value is used only if none of them is set.
+
+// hasOrElse checks if the instance has alternatives.
+func (eo *envOptions) hasOrElse() bool {
+	return eo != nil && eo.orElse != nil
+}
+
+// withoutOrElse returns a copy of the options without alternatives.
+func (eo *envOptions) withoutOrElse() *envOptions {
+	result := eo.clone()
+	result.orElse = nil
+
+	return result
+}
+
+// withOrElse returns a copy of the options with the alternative appended.
+func withOrElse[T any](eo *envOptions, alternative EnvValue, get func(GetEnvFunc) (T, error)) *envOptions {
+	result := eo.clone()
+	variables := result.orElseVariables
+	result.orElseVariables = append(variables[:len(variables):len(variables)], alternative.Variables()...)
+
+	resolve := func(getFunc GetEnvFunc) (any, error) {
+		return get(getFunc)
+	}
+
+	previous := result.orElse
+	if previous == nil {
+		result.orElse = resolve
+
+		return result
+	}
+
+	result.orElse = func(getFunc GetEnvFunc) (any, error) {
+		value, err := previous(getFunc)
+		if !isUnsetResult(value, err) {
+			return value, err
+		}
+
+		return resolve(getFunc)
+	}
+
+	return result
+}
+
+// resolveOrElse resolves the value, or the alternatives if the value is unset.
+// The error of the instance is returned if all alternatives are unset.
+func resolveOrElse[T any](eo *envOptions, getFunc GetEnvFunc, get func(GetEnvFunc) (T, error)) (T, error) {
+	result, err := get(getFunc)
+	if !isUnsetResult(result, err) {
+		return result, err
+	}
+
+	value, alternativeErr := eo.orElse(getFunc)
+	if isUnsetResult(value, alternativeErr) {
+		return result, err
+	}
+
+	if alternativeErr != nil {
+		return result, alternativeErr
+	}
+
+	typedValue, _ := value.(T)
+
+	return typedValue, nil
+}
+
+// isUnsetResult checks if the resolution result is unset, i.e. an unset error, or a nil slice, map or value.
+func isUnsetResult(value any, err error) bool {
+	if err != nil {
+		return errors.Is(err, ErrEnvironmentVariableValueRequired) || errors.Is(err, ErrEnvironmentValueRequired)
+	}
+
+	if value == nil {
+		return true
+	}
+
+	reflectValue := reflect.ValueOf(value)
+
+	switch reflectValue.Kind() {
+	case reflect.Map, reflect.Slice:
+		return reflectValue.IsNil()
+	default:
+		return false
+	}
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvString) OrElse(alternative EnvString) EnvString {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvString) WithDefault(value string) EnvString {
+	ev.Value = &value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvString) WithDefaultVariable(name string) EnvString {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvInt) OrElse(alternative EnvInt) EnvInt {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvInt) WithDefault(value int64) EnvInt {
+	ev.Value = &value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvInt) WithDefaultVariable(name string) EnvInt {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvBool) OrElse(alternative EnvBool) EnvBool {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvBool) WithDefault(value bool) EnvBool {
+	ev.Value = &value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvBool) WithDefaultVariable(name string) EnvBool {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvFloat) OrElse(alternative EnvFloat) EnvFloat {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvFloat) WithDefault(value float64) EnvFloat {
+	ev.Value = &value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvFloat) WithDefaultVariable(name string) EnvFloat {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvAny) OrElse(alternative EnvAny) EnvAny {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvAny) WithDefault(value any) EnvAny {
+	ev.Value = value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvAny) WithDefaultVariable(name string) EnvAny {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvStringSlice) OrElse(alternative EnvStringSlice) EnvStringSlice {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvStringSlice) WithDefault(value []string) EnvStringSlice {
+	ev.Value = value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvStringSlice) WithDefaultVariable(name string) EnvStringSlice {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvIntSlice) OrElse(alternative EnvIntSlice) EnvIntSlice {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvIntSlice) WithDefault(value []int64) EnvIntSlice {
+	ev.Value = value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvIntSlice) WithDefaultVariable(name string) EnvIntSlice {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvFloatSlice) OrElse(alternative EnvFloatSlice) EnvFloatSlice {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvFloatSlice) WithDefault(value []float64) EnvFloatSlice {
+	ev.Value = value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvFloatSlice) WithDefaultVariable(name string) EnvFloatSlice {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvBoolSlice) OrElse(alternative EnvBoolSlice) EnvBoolSlice {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvBoolSlice) WithDefault(value []bool) EnvBoolSlice {
+	ev.Value = value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvBoolSlice) WithDefaultVariable(name string) EnvBoolSlice {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvMapString) OrElse(alternative EnvMapString) EnvMapString {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvMapString) WithDefault(value map[string]string) EnvMapString {
+	ev.Value = value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvMapString) WithDefaultVariable(name string) EnvMapString {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvMapInt) OrElse(alternative EnvMapInt) EnvMapInt {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvMapInt) WithDefault(value map[string]int64) EnvMapInt {
+	ev.Value = value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvMapInt) WithDefaultVariable(name string) EnvMapInt {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvMapFloat) OrElse(alternative EnvMapFloat) EnvMapFloat {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvMapFloat) WithDefault(value map[string]float64) EnvMapFloat {
+	ev.Value = value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvMapFloat) WithDefaultVariable(name string) EnvMapFloat {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
+
+// OrElse returns a copy of the instance which resolves the alternative instance if the value is unset.
+func (ev EnvMapBool) OrElse(alternative EnvMapBool) EnvMapBool {
+	ev.options = withOrElse(ev.options, alternative, alternative.GetCustom)
+
+	return ev
+}
+
+// WithDefault returns a copy of the instance with the literal value, which is used if the variable is unset.
+func (ev EnvMapBool) WithDefault(value map[string]bool) EnvMapBool {
+	ev.Value = value
+
+	return ev
+}
+
+// WithDefaultVariable returns a copy of the instance which resolves the default variable if the variable is unset.
+func (ev EnvMapBool) WithDefaultVariable(name string) EnvMapBool {
+	ev.options = ev.options.clone()
+	ev.options.defaultVariables = append(ev.options.defaultVariables[:len(ev.options.defaultVariables):len(ev.options.defaultVariables)], name)
+
+	return ev
+}
@@ -0,0 +1,141 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOrElse(t *testing.T) {
+	host := NewEnvStringVariable("APP_HOST").
+		OrElse(NewEnvStringVariable("HOST")).
+		OrElse(NewEnvStringValue("localhost"))
+
+	testCases := []struct {
+		Name     string
+		Env      map[string]string
+		Expected string
+	}{
+		{Name: "primary", Env: map[string]string{"APP_HOST": "app.local", "HOST": "host.local"}, Expected: "app.local"},
+		{Name: "alternative", Env: map[string]string{"HOST": "host.local"}, Expected: "host.local"},
+		{Name: "default", Env: map[string]string{}, Expected: "localhost"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := host.GetCustom(newBinderGetter(tc.Env))
+			assertNilError(t, err)
+			assertDeepEqual(t, tc.Expected, result)
+		})
+	}
+
+	t.Setenv("OR_ELSE_PORT", "8080")
+
+	port, err := NewEnvIntVariable("OR_ELSE_MISSING").OrElse(NewEnvIntVariable("OR_ELSE_PORT")).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(8080), port)
+
+	assertDeepEqual(t, []string{"APP_HOST", "HOST"}, host.Variables())
+}
+
+func TestOrElse_Types(t *testing.T) {
+	getFunc := newBinderGetter(map[string]string{"ORIGINS": "a,b", "LIMITS": "a=1", "EXTRA": `{"a":1}`})
+
+	origins, err := NewEnvStringSliceVariable("APP_ORIGINS").OrElse(NewEnvStringSliceVariable("ORIGINS")).GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a", "b"}, origins)
+
+	limits, err := NewEnvMapIntVariable("APP_LIMITS").OrElse(NewEnvMapIntVariable("LIMITS")).GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"a": 1}, limits)
+
+	extra, err := EnvAny{}.OrElse(NewEnvAnyVariable("EXTRA")).GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]any{"a": float64(1)}, extra)
+
+	ratio, err := NewEnvFloatValue(0.5).OrElse(NewEnvFloatValue(1)).GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, 0.5, ratio)
+}
+
+func TestOrElse_Errors(t *testing.T) {
+	getFunc := newBinderGetter(map[string]string{"PORT": "abc", "MAX_PORT": "8080"})
+
+	_, err := NewEnvIntVariable("APP_PORT").OrElse(NewEnvIntVariable("PORT")).GetCustom(getFunc)
+	assertErrorContains(t, err, `parsing "abc": invalid syntax`)
+
+	_, err = NewEnvIntVariable("APP_PORT").OrElse(NewEnvIntVariable("MISSING_PORT")).GetCustom(getFunc)
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+	assertErrorContains(t, err, "APP_PORT")
+
+	_, err = NewEnvIntVariable("APP_PORT").OrElse(NewEnvIntVariable("MAX_PORT")).WithMax(80).GetCustom(getFunc)
+	assertErrorContains(t, err, "the value must be less than or equal to 80")
+}
+
+func TestWithDefault(t *testing.T) {
+	source := NewEnvStringVariable("WITH_DEFAULT_HOST")
+	host := source.WithDefault("localhost")
+
+	assertDeepEqual(t, NewEnvStringVariable("WITH_DEFAULT_HOST"), source)
+	assertDeepEqual(t, NewEnvString("WITH_DEFAULT_HOST", "localhost"), host)
+	assertDeepEqual(t, NewEnvIntSlice("PORTS", []int64{80}), NewEnvIntSliceVariable("PORTS").WithDefault([]int64{80}))
+	assertDeepEqual(
+		t,
+		NewEnvMapBool("FLAGS", map[string]bool{"a": true}),
+		NewEnvMapBoolVariable("FLAGS").WithDefault(map[string]bool{"a": true}),
+	)
+
+	result, err := host.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "localhost", result)
+}
+
+func TestWithDefaultVariable(t *testing.T) {
+	bindAddr := NewEnvStringVariable("BIND_ADDR").
+		WithCandidateVariables("LISTEN_ADDR").
+		WithDefaultVariable("HOST").
+		WithDefault("0.0.0.0")
+
+	testCases := []struct {
+		Name     string
+		Env      map[string]string
+		Expected string
+	}{
+		{Name: "primary", Env: map[string]string{"BIND_ADDR": "127.0.0.1", "HOST": "host.local"}, Expected: "127.0.0.1"},
+		{Name: "candidate", Env: map[string]string{"LISTEN_ADDR": "10.0.0.1", "HOST": "host.local"}, Expected: "10.0.0.1"},
+		{Name: "default_variable", Env: map[string]string{"BIND_ADDR": "", "HOST": "host.local"}, Expected: "host.local"},
+		{Name: "literal", Env: map[string]string{}, Expected: "0.0.0.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := bindAddr.GetCustom(newBinderGetter(tc.Env))
+			assertNilError(t, err)
+			assertDeepEqual(t, tc.Expected, result)
+		})
+	}
+
+	assertDeepEqual(t, []string{"BIND_ADDR", "LISTEN_ADDR", "HOST"}, bindAddr.Variables())
+
+	isSet, err := bindAddr.IsSetCustom(newBinderGetter(map[string]string{"HOST": "host.local"}))
+	assertNilError(t, err)
+	assertDeepEqual(t, true, isSet)
+
+	t.Setenv("DEFAULT_VARIABLE_PORT", "8080")
+
+	port, err := NewEnvIntVariable("DEFAULT_VARIABLE_MISSING").WithDefaultVariable("DEFAULT_VARIABLE_PORT").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(8080), port)
+
+	_, err = NewEnvIntVariable("APP_PORT").WithDefaultVariable("PORT").GetCustom(newBinderGetter(map[string]string{"PORT": "abc"}))
+	assertErrorContains(t, err, "invalid syntax")
+
+	_, err = NewEnvIntVariable("APP_PORT").WithDefaultVariable("PORT").GetCustom(newBinderGetter(map[string]string{}))
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+
+	limits, err := NewEnvMapIntVariable("APP_LIMITS").WithDefaultVariable("LIMITS").GetCustom(newBinderGetter(map[string]string{"LIMITS": "a=1"}))
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"a": 1}, limits)
+
+	assertErrorContains(t, NewEnvStringVariable("APP_HOST").WithDefaultVariable("1HOST").Validate(), "invalid default variable name")
+	assertErrorContains(t, NewEnvStringValue("localhost").WithDefaultVariable("HOST").Validate(), "default variables require a variable name")
+}
@@ -0,0 +1,142 @@
+package goenvconf
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ResolveDocument returns a copy of a decoded config document, e.g. the result of json.Unmarshal or yaml.Unmarshal into any,
+// where every env reference is replaced with the resolved value, so the document shows exactly what a service loads.
+//
+// Env references are objects with value and/or env keys and variable reference strings, e.g. ${PORT:-8080},
+// as accepted by [DecodeHook]. Variable values are kept as strings if the literal value is a string or absent,
+// and decoded as JSON like [EnvAny] otherwise, e.g. the value of PORT is a number in {"value": 8080, "env": "PORT"}.
+// Unset references are replaced with nil. Placeholders which are embedded in other strings are expanded
+// like literal values of [EnvString] by [ExpandString], e.g. http://${HOST:-localhost}:8080,
+// and other values are copied as is.
+// All errors are returned together as [ConfigErrors] with the paths of the references, e.g. servers[0].port.
+// The getter defaults to [GetOSEnv] if nil.
+func ResolveDocument(document any, getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	var errs ConfigErrors
+
+	result := resolveDocumentValue(document, "", getFunc, &errs)
+
+	return result, errs.toError()
+}
+
+func resolveDocumentValue(value any, path string, getFunc GetEnvFunc, errs *ConfigErrors) any {
+	if isDocumentReference(value) {
+		result, err := resolveDocumentReference(value, getFunc)
+		if err != nil {
+			raw, _ := decodeEnvRawInput(value)
+
+			var variable string
+			if raw.Variable != nil {
+				variable = *raw.Variable
+			}
+
+			*errs = append(*errs, ConfigError{Path: path, Variable: variable, Err: err})
+		}
+
+		return result
+	}
+
+	switch typedValue := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(typedValue))
+
+		for key, item := range typedValue {
+			result[key] = resolveDocumentValue(item, joinDocumentPath(path, key), getFunc, errs)
+		}
+
+		return result
+	case map[any]any:
+		result := make(map[any]any, len(typedValue))
+
+		for key, item := range typedValue {
+			result[key] = resolveDocumentValue(item, joinDocumentPath(path, fmt.Sprint(key)), getFunc, errs)
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(typedValue))
+
+		for i, item := range typedValue {
+			result[i] = resolveDocumentValue(item, path+"["+strconv.Itoa(i)+"]", getFunc, errs)
+		}
+
+		return result
+	case string:
+		result, err := ExpandString(typedValue, getFunc)
+		if err != nil {
+			*errs = append(*errs, ConfigError{Path: path, Err: err})
+
+			return typedValue
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+// isDocumentReference checks if the document value is an env reference. Unlike [DecodeHook],
+// empty objects are not references because the type of the field is unknown.
+func isDocumentReference(value any) bool {
+	if str, ok := value.(string); ok {
+		_, isReference := parseEnvReference(str)
+
+		return isReference
+	}
+
+	object, ok := toEnvObject(value)
+
+	return ok && len(object) > 0
+}
+
+// resolveDocumentReference resolves the env reference as EnvString if the literal value is a string or absent,
+// or as EnvAny otherwise. It returns nil if the reference is unset.
+func resolveDocumentReference(value any, getFunc GetEnvFunc) (any, error) {
+	raw, err := decodeEnvRawInput(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, isString := raw.Value.(string); raw.Value != nil && !isString {
+		return resolveDocumentResult(EnvAny{Value: raw.Value, Variable: raw.Variable}.GetCustom(getFunc))
+	}
+
+	ev, err := decodeEnvString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ev.GetCustom(getFunc)
+	if err == nil && ev.Value == nil && result == "" {
+		return nil, nil
+	}
+
+	return resolveDocumentResult(result, err)
+}
+
+// resolveDocumentResult returns nil without error if the reference is unset.
+func resolveDocumentResult(result any, err error) (any, error) {
+	if errors.Is(err, ErrEnvironmentValueRequired) || errors.Is(err, ErrEnvironmentVariableValueRequired) {
+		return nil, nil
+	}
+
+	return result, err
+}
+
+func joinDocumentPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,49 @@
+package goenvconf
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestResolveDocument(t *testing.T) {
+	var document any
+
+	assertNilError(t, json.Unmarshal([]byte(`{
+		"server": {
+			"host": "${HOST:-localhost}",
+			"port": {"value": 8080, "env": "PORT"},
+			"url": "http://${HOST:-localhost}:8080",
+			"api": "http://${API_HOST}/v1",
+			"password": "pa$$word"
+		},
+		"origins": [{"env": "ORIGIN"}, "$MISSING", "static"],
+		"limits": {},
+		"features": {"value": {"beta": true}},
+		"ratio": {"value": 0.5, "env": "RATIO"}
+	}`), &document))
+
+	getter := newBinderGetter(map[string]string{"PORT": "9090", "ORIGIN": "example.com", "RATIO": "x"})
+
+	result, err := ResolveDocument(document, getter)
+	assertErrorContains(t, err, "ratio (RATIO): ")
+	assertErrorContains(t, err, "server.api: ")
+	assertDeepEqual(t, map[string]any{
+		"server": map[string]any{
+			"host":     "localhost",
+			"port":     float64(9090),
+			"url":      "http://localhost:8080",
+			"api":      "http://${API_HOST}/v1",
+			"password": "pa$word",
+		},
+		"origins":  []any{"example.com", "$MISSING", "static"},
+		"limits":   map[string]any{},
+		"features": map[string]any{"beta": true},
+		"ratio":    nil,
+	}, result)
+
+	t.Run("yaml_keys", func(t *testing.T) {
+		result, err := ResolveDocument(map[any]any{1: "${PORT}"}, getter)
+		assertNilError(t, err)
+		assertDeepEqual(t, map[any]any{1: "9090"}, result)
+	})
+}
@@ -0,0 +1,173 @@
+package goenvconf
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// NewReaderGetEnvFunc reads a key=value stream in the dotenv grammar from the reader, e.g. stdin,
+// an embedded file or an HTTP response body, and returns a getter of the parsed variables.
+// The getter returns [ErrEnvironmentVariableValueRequired] if the variable does not exist in the stream.
+// See [ParseDotEnv] for the grammar.
+func NewReaderGetEnvFunc(reader io.Reader) (GetEnvFunc, error) {
+	values, err := ParseDotEnv(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMapGetEnvFunc(values), nil
+}
+
+// ParseDotEnv parses a key=value stream in the dotenv grammar:
+//   - Empty lines and lines starting with # are ignored. Lines may start with the export keyword.
+//   - Unquoted values are trimmed, and comments starting with " #" are removed.
+//   - Single-quoted values are literal.
+//   - Double-quoted values may span multiple lines and support \n, \r, \t, \", \\ and \$ escape sequences.
+//
+// Variables are not expanded. If a key is duplicated, the last value wins.
+func ParseDotEnv(reader io.Reader) (map[string]string, error) {
+	entries, err := ParseDotEnvEntries(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		result[entry.Key] = entry.Value
+	}
+
+	return result, nil
+}
+
+// DotEnvEntry is a variable of a dotenv stream.
+type DotEnvEntry struct {
+	Key   string
+	Value string
+	// Line is the line number where the variable is defined, starting from 1.
+	Line int
+}
+
+// ParseDotEnvEntries parses a key=value stream in the dotenv grammar like [ParseDotEnv],
+// and returns all entries in the stream order, including duplicated keys, e.g. for linting.
+func ParseDotEnvEntries(reader io.Reader) ([]DotEnvEntry, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	input := strings.ReplaceAll(string(data), "\r\n", "\n")
+	result := make([]DotEnvEntry, 0, strings.Count(input, "\n")+1)
+	lineNumber := 0
+
+	for input != "" {
+		var line string
+
+		line, input, _ = strings.Cut(input, "\n")
+		lineNumber++
+
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "export "); ok {
+			line = strings.TrimSpace(rest)
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+
+		if !ok || !isValidVariableName(key) {
+			return nil, newDotEnvSyntaxError(lineNumber, "expected <KEY>=<value>", line)
+		}
+
+		value = strings.TrimSpace(value)
+
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			if index := strings.Index(value, " #"); index >= 0 {
+				value = strings.TrimSpace(value[:index])
+			}
+
+			result = append(result, DotEnvEntry{Key: key, Value: value, Line: lineNumber})
+
+			continue
+		}
+
+		startLine := lineNumber
+
+		quoted, rest, lines, ok := readDotEnvQuotedValue(value + "\n" + input)
+		if !ok {
+			return nil, newDotEnvSyntaxError(startLine, "unterminated quoted value", key)
+		}
+
+		lineNumber += lines
+
+		trailing, remaining, _ := strings.Cut(rest, "\n")
+		if trailing = strings.TrimSpace(trailing); trailing != "" && trailing[0] != '#' {
+			return nil, newDotEnvSyntaxError(lineNumber, "unexpected characters after the quoted value", trailing)
+		}
+
+		result = append(result, DotEnvEntry{Key: key, Value: quoted, Line: startLine})
+		input = remaining
+	}
+
+	return result, nil
+}
+
+// readDotEnvQuotedValue reads the quoted value at the start of the input, and returns the unquoted value,
+// the rest of the input after the closing quote and the number of line breaks inside the value.
+func readDotEnvQuotedValue(input string) (string, string, int, bool) {
+	quote := input[0]
+
+	if quote == '\'' {
+		end := strings.IndexByte(input[1:], '\'')
+		if end < 0 {
+			return "", "", 0, false
+		}
+
+		value := input[1 : end+1]
+
+		return value, input[end+2:], strings.Count(value, "\n"), true
+	}
+
+	var sb strings.Builder
+
+	for i := 1; i < len(input); i++ {
+		switch char := input[i]; char {
+		case '"':
+			value := sb.String()
+
+			return value, input[i+1:], strings.Count(input[1:i], "\n"), true
+		case '\\':
+			if i+1 >= len(input) {
+				return "", "", 0, false
+			}
+
+			i++
+
+			switch input[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '"', '\\', '$':
+				sb.WriteByte(input[i])
+			default:
+				sb.WriteByte('\\')
+				sb.WriteByte(input[i])
+			}
+		default:
+			sb.WriteByte(char)
+		}
+	}
+
+	return "", "", 0, false
+}
+
+func newDotEnvSyntaxError(lineNumber int, detail string, hint string) ParseEnvError {
+	return NewParseEnvFailedError("invalid dotenv syntax at line "+strconv.Itoa(lineNumber)+", "+detail, hint)
+}
@@ -0,0 +1,93 @@
+package goenvconf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	input := `# database settings
+export DATABASE_URL=postgres://localhost:5432/db
+PORT = 8080 # the server port
+EMPTY=
+SINGLE='literal $HOME \n # not a comment'
+DOUBLE="line1\nline2 \"quoted\" \$HOME"
+MULTI="first
+second"
+AFTER=after # comment
+URL=http://example.com/#anchor
+PORT=9090
+`
+
+	result, err := ParseDotEnv(strings.NewReader(strings.ReplaceAll(input, "\n", "\r\n")))
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]string{
+		"DATABASE_URL": "postgres://localhost:5432/db",
+		"PORT":         "9090",
+		"EMPTY":        "",
+		"SINGLE":       `literal $HOME \n # not a comment`,
+		"DOUBLE":       "line1\nline2 \"quoted\" $HOME",
+		"MULTI":        "first\nsecond",
+		"AFTER":        "after",
+		"URL":          "http://example.com/#anchor",
+	}, result)
+}
+
+func TestParseDotEnvEntries(t *testing.T) {
+	input := `PORT=8080
+MULTI="first
+second"
+
+# comment
+PORT=9090
+`
+
+	result, err := ParseDotEnvEntries(strings.NewReader(input))
+	assertNilError(t, err)
+	assertDeepEqual(t, []DotEnvEntry{
+		{Key: "PORT", Value: "8080", Line: 1},
+		{Key: "MULTI", Value: "first\nsecond", Line: 2},
+		{Key: "PORT", Value: "9090", Line: 6},
+	}, result)
+}
+
+func TestParseDotEnv_Errors(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    string
+		ErrorMsg string
+	}{
+		{Name: "no_equal", Input: "FOO", ErrorMsg: "invalid dotenv syntax at line 1, expected <KEY>=<value>. Hint: FOO"},
+		{Name: "invalid_key", Input: "\n1FOO=bar", ErrorMsg: "invalid dotenv syntax at line 2, expected <KEY>=<value>. Hint: 1FOO=bar"},
+		{Name: "unterminated", Input: "A=1\nFOO=\"bar\nBAZ=1", ErrorMsg: "invalid dotenv syntax at line 2, unterminated quoted value. Hint: FOO"},
+		{Name: "unterminated_single", Input: "FOO='bar", ErrorMsg: "unterminated quoted value. Hint: FOO"},
+		{Name: "trailing", Input: "FOO=\"a\nb\" c", ErrorMsg: "invalid dotenv syntax at line 2, unexpected characters after the quoted value. Hint: c"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ParseDotEnv(strings.NewReader(tc.Input))
+			assertErrorContains(t, err, tc.ErrorMsg)
+		})
+	}
+}
+
+func TestNewReaderGetEnvFunc(t *testing.T) {
+	getFunc, err := NewReaderGetEnvFunc(strings.NewReader("PORT=8080\nHOSTS=a,b"))
+	assertNilError(t, err)
+
+	port, err := NewEnvIntVariable("PORT").GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(8080), port)
+
+	hosts, err := NewEnvStringSliceVariable("HOSTS").GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a", "b"}, hosts)
+
+	_, err = getFunc("MISSING")
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+
+	_, err = NewReaderGetEnvFunc(strings.NewReader("FOO"))
+	assertErrorContains(t, err, "invalid dotenv syntax")
+}
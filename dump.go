@@ -0,0 +1,265 @@
+package goenvconf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The DumpResolved functions print the resolved Env fields of a config struct, e.g. for a --print-config flag.
+// Fields are nested as in the config struct and annotated with the source of the value, the variable and the getter.
+// Values and errors of secret fields are redacted in the dump and the returned errors,
+// including those of variables registered by [RegisterSecretVariables].
+// Nil pointer fields and fields which are not Env instances are skipped.
+
+const (
+	dumpSourceKey = "source"
+	dumpGetterKey = "getter"
+	dumpErrorKey  = "error"
+)
+
+// DumpResolved writes the resolved Env fields of the config as indented JSON. The getter defaults to [GetOSEnv] if nil.
+// Fields which fail to resolve are written with the error, and all errors are returned together as [ConfigErrors]
+// after the dump is written.
+func DumpResolved(config any, getFunc GetEnvFunc, w io.Writer) error {
+	return NewBinder(getFunc).DumpResolved(config, w)
+}
+
+// DumpResolvedYAML writes the resolved Env fields of the config as YAML like [DumpResolved].
+func DumpResolvedYAML(config any, getFunc GetEnvFunc, w io.Writer) error {
+	return NewBinder(getFunc).DumpResolvedYAML(config, w)
+}
+
+// DumpResolved writes the resolved Env fields of the config as indented JSON like the [DumpResolved] function,
+// with the getters of the binder.
+func (b Binder) DumpResolved(config any, w io.Writer) error {
+	root, errs, err := b.dumpResolved(config)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	return errs.toError()
+}
+
+// DumpResolvedYAML writes the resolved Env fields of the config as YAML like the [DumpResolvedYAML] function,
+// with the getters of the binder.
+func (b Binder) DumpResolvedYAML(config any, w io.Writer) error {
+	root, errs, err := b.dumpResolved(config)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	if err := writeDumpYAML(&buf, root, 0); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	return errs.toError()
+}
+
+// dumpResolved resolves the Env fields of the config into a dump object.
+func (b Binder) dumpResolved(config any) (dumpObject, ConfigErrors, error) {
+	value := reflect.Indirect(reflect.ValueOf(config))
+	if value.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("%w, got %T", ErrInvalidBindTarget, config)
+	}
+
+	getFunc, err := b.prefetchGetFunc(value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b.getFunc = getFunc
+	root := &dumpObject{}
+	cache := newParseCache()
+
+	var errs ConfigErrors
+
+	for _, field := range collectEnvFields(value) {
+		ev, _ := field.value.Interface().(EnvValue)
+		_, resolved := b.traceEnvValue(ev, cache)
+
+		variable := resolved.Variable
+		if variable == "" {
+			variable = envVariableName(field.value)
+		}
+
+		isSecret := isSecretField(field.value, variable)
+		entry := dumpObject{}
+
+		if resolved.Value != nil {
+			if isSecret {
+				entry.set(envObjectValueKey, redactedValue)
+			} else {
+				entry.set(envObjectValueKey, resolved.Value)
+			}
+		}
+
+		entry.set(dumpSourceKey, string(resolved.Source))
+
+		if variable != "" {
+			entry.set(envObjectVariableKey, variable)
+		}
+
+		if resolved.Getter != "" {
+			entry.set(dumpGetterKey, resolved.Getter)
+		}
+
+		if resolved.Err != nil {
+			fieldErr := resolved.Err
+			if isSecret {
+				fieldErr = redactError(fieldErr)
+			}
+
+			errs = append(errs, ConfigError{Path: field.path, Variable: envVariableName(field.value), Err: fieldErr})
+			entry.set(dumpErrorKey, fieldErr.Error())
+		}
+
+		parent := root
+		segments := strings.Split(field.path, ".")
+
+		for _, segment := range segments[:len(segments)-1] {
+			parent = parent.child(segment)
+		}
+
+		parent.set(segments[len(segments)-1], &entry)
+	}
+
+	return *root, errs, nil
+}
+
+// dumpObject is an object of the dump whose members keep the field order of the config struct.
+type dumpObject []dumpMember
+
+type dumpMember struct {
+	key   string
+	value any
+}
+
+func (do *dumpObject) set(key string, value any) {
+	*do = append(*do, dumpMember{key: key, value: value})
+}
+
+// child returns the nested object of the key, which is created if not exists.
+func (do *dumpObject) child(key string) *dumpObject {
+	for _, member := range *do {
+		if member.key == key {
+			if child, ok := member.value.(*dumpObject); ok {
+				return child
+			}
+		}
+	}
+
+	child := &dumpObject{}
+	do.set(key, child)
+
+	return child
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (do dumpObject) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString("{")
+
+	for i, member := range do {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(member.key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(member.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// writeDumpYAML writes the object as a YAML block mapping. Values which are not strings are written
+// in the JSON flow style, which is valid YAML.
+func writeDumpYAML(buf *bytes.Buffer, object dumpObject, depth int) error {
+	for _, member := range object {
+		buf.WriteString(strings.Repeat("  ", depth))
+		buf.WriteString(yamlString(member.key))
+		buf.WriteByte(':')
+
+		switch value := member.value.(type) {
+		case *dumpObject:
+			buf.WriteByte('\n')
+
+			if err := writeDumpYAML(buf, *value, depth+1); err != nil {
+				return err
+			}
+
+			continue
+		case string:
+			buf.WriteByte(' ')
+			buf.WriteString(yamlString(value))
+		default:
+			data, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+
+			buf.WriteByte(' ')
+			buf.Write(data)
+		}
+
+		buf.WriteByte('\n')
+	}
+
+	return nil
+}
+
+// yamlString returns the string as a plain YAML scalar if it cannot be mistaken for another type, or quoted otherwise.
+func yamlString(value string) string {
+	if value == "" || !isPlainYAMLStart(value[0]) {
+		return strconv.Quote(value)
+	}
+
+	for i := 1; i < len(value); i++ {
+		if !isPlainYAMLStart(value[i]) && (value[i] < '0' || value[i] > '9') &&
+			value[i] != '.' && value[i] != '-' && value[i] != '/' {
+			return strconv.Quote(value)
+		}
+	}
+
+	switch strings.ToLower(value) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null":
+		return strconv.Quote(value)
+	default:
+		return value
+	}
+}
+
+func isPlainYAMLStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,167 @@
+package goenvconf
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+type dumpServerConfig struct {
+	Host    EnvString
+	Port    EnvInt
+	Origins EnvStringSlice
+}
+
+type dumpConfig struct {
+	Server   dumpServerConfig
+	Password EnvString
+	APIKey   *EnvString
+	Ratio    EnvFloat
+	Limits   EnvMapInt
+	Missing  *EnvString
+	Timeout  int
+}
+
+func TestDumpResolved(t *testing.T) {
+	t.Cleanup(ResetSecretVariables)
+
+	assertNilError(t, RegisterSecretVariables("*_KEY"))
+
+	config := dumpConfig{
+		Server: dumpServerConfig{
+			Host:    NewEnvString("HOST", "localhost"),
+			Port:    NewEnvIntVariable("PORT"),
+			Origins: NewEnvStringSliceVariable("ORIGINS"),
+		},
+		Password: NewEnvStringVariable("PASSWORD").Secret(),
+		APIKey:   toPtr(NewEnvStringVariable("API_KEY")),
+		Ratio:    NewEnvFloatVariable("RATIO"),
+		Limits:   NewEnvMapIntValue(map[string]int64{"a": 1}),
+	}
+	getter := newBinderGetter(map[string]string{
+		"PORT":     "8080",
+		"ORIGINS":  "a,b",
+		"PASSWORD": "secret",
+		"API_KEY":  "key",
+		"RATIO":    "x",
+	})
+
+	var buf bytes.Buffer
+
+	err := DumpResolved(config, getter, &buf)
+	assertErrorContains(t, err, "Ratio (RATIO): strconv.ParseFloat")
+	assertDeepEqual(t, `{
+  "Server": {
+    "Host": {
+      "value": "localhost",
+      "source": "value",
+      "env": "HOST"
+    },
+    "Port": {
+      "value": 8080,
+      "source": "env",
+      "env": "PORT"
+    },
+    "Origins": {
+      "value": [
+        "a",
+        "b"
+      ],
+      "source": "env",
+      "env": "ORIGINS"
+    }
+  },
+  "Password": {
+    "value": "[REDACTED]",
+    "source": "env",
+    "env": "PASSWORD"
+  },
+  "APIKey": {
+    "value": "[REDACTED]",
+    "source": "env",
+    "env": "API_KEY"
+  },
+  "Ratio": {
+    "source": "error",
+    "env": "RATIO",
+    "error": "strconv.ParseFloat: parsing \"x\": invalid syntax"
+  },
+  "Limits": {
+    "value": {
+      "a": 1
+    },
+    "source": "value"
+  }
+}
+`, buf.String())
+
+	t.Run("yaml", func(t *testing.T) {
+		var yamlBuf bytes.Buffer
+
+		err := DumpResolvedYAML(config, getter, &yamlBuf)
+		assertErrorContains(t, err, "Ratio (RATIO): strconv.ParseFloat")
+
+		var fromYAML, fromJSON map[string]any
+
+		assertNilError(t, yaml.Unmarshal(yamlBuf.Bytes(), &fromYAML))
+		assertNilError(t, json.Unmarshal(buf.Bytes(), &fromJSON))
+		assertDeepEqual(t, normalizeDumpValue(fromJSON), normalizeDumpValue(fromYAML))
+	})
+
+	t.Run("secret_error", func(t *testing.T) {
+		type secretConfig struct {
+			Port EnvInt
+		}
+
+		var secretBuf bytes.Buffer
+
+		err := DumpResolved(secretConfig{Port: NewEnvIntVariable("DB_PORT_SECRET").Secret()},
+			newBinderGetter(map[string]string{"DB_PORT_SECRET": "hunter2"}), &secretBuf)
+		assertErrorContains(t, err, "Port (DB_PORT_SECRET): [REDACTED]")
+
+		if strings.Contains(err.Error(), "hunter2") || strings.Contains(secretBuf.String(), "hunter2") {
+			t.Fatalf("expected the secret value to be redacted, got %q and %q", err.Error(), secretBuf.String())
+		}
+	})
+
+	t.Run("invalid_config", func(t *testing.T) {
+		assertErrorContains(t, DumpResolved("foo", getter, &bytes.Buffer{}), ErrInvalidBindTarget.Error())
+	})
+}
+
+func TestYAMLString(t *testing.T) {
+	for input, expected := range map[string]string{
+		"":            `""`,
+		"localhost":   "localhost",
+		"db.internal": "db.internal",
+		"true":        `"true"`,
+		"No":          `"No"`,
+		"8080":        `"8080"`,
+		"[REDACTED]":  `"[REDACTED]"`,
+		"a: b":        `"a: b"`,
+		"line\nbreak": `"line\nbreak"`,
+	} {
+		assertDeepEqual(t, expected, yamlString(input))
+	}
+}
+
+// normalizeDumpValue converts numbers to float64 so JSON and YAML documents can be compared.
+func normalizeDumpValue(value any) any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for key, item := range typedValue {
+			typedValue[key] = normalizeDumpValue(item)
+		}
+	case []any:
+		for i, item := range typedValue {
+			typedValue[i] = normalizeDumpValue(item)
+		}
+	case int:
+		return float64(typedValue)
+	}
+
+	return value
+}
@@ -0,0 +1,242 @@
+// Package envcli provides [urfave/cli] v2 flags for goenvconf types.
+//
+// [urfave/cli]: https://github.com/urfave/cli
+package envcli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hasura/goenvconf"
+	"github.com/urfave/cli/v2"
+)
+
+// EnvPointer is the constraint of pointers to Env types which implement [flag.Value].
+// FlagString returns the flag argument form, which is printed as the flag value.
+type EnvPointer[T any] interface {
+	*T
+	flag.Value
+	FlagString() string
+}
+
+// EnvStringFlag is the flag of [goenvconf.EnvString].
+type EnvStringFlag = Flag[goenvconf.EnvString, *goenvconf.EnvString]
+
+// EnvIntFlag is the flag of [goenvconf.EnvInt].
+type EnvIntFlag = Flag[goenvconf.EnvInt, *goenvconf.EnvInt]
+
+// EnvBoolFlag is the flag of [goenvconf.EnvBool].
+type EnvBoolFlag = Flag[goenvconf.EnvBool, *goenvconf.EnvBool]
+
+// EnvFloatFlag is the flag of [goenvconf.EnvFloat].
+type EnvFloatFlag = Flag[goenvconf.EnvFloat, *goenvconf.EnvFloat]
+
+// EnvAnyFlag is the flag of [goenvconf.EnvAny].
+type EnvAnyFlag = Flag[goenvconf.EnvAny, *goenvconf.EnvAny]
+
+// EnvStringSliceFlag is the flag of [goenvconf.EnvStringSlice].
+type EnvStringSliceFlag = Flag[goenvconf.EnvStringSlice, *goenvconf.EnvStringSlice]
+
+// EnvIntSliceFlag is the flag of [goenvconf.EnvIntSlice].
+type EnvIntSliceFlag = Flag[goenvconf.EnvIntSlice, *goenvconf.EnvIntSlice]
+
+// EnvFloatSliceFlag is the flag of [goenvconf.EnvFloatSlice].
+type EnvFloatSliceFlag = Flag[goenvconf.EnvFloatSlice, *goenvconf.EnvFloatSlice]
+
+// EnvBoolSliceFlag is the flag of [goenvconf.EnvBoolSlice].
+type EnvBoolSliceFlag = Flag[goenvconf.EnvBoolSlice, *goenvconf.EnvBoolSlice]
+
+// EnvMapStringFlag is the flag of [goenvconf.EnvMapString].
+type EnvMapStringFlag = Flag[goenvconf.EnvMapString, *goenvconf.EnvMapString]
+
+// EnvMapIntFlag is the flag of [goenvconf.EnvMapInt].
+type EnvMapIntFlag = Flag[goenvconf.EnvMapInt, *goenvconf.EnvMapInt]
+
+// EnvMapFloatFlag is the flag of [goenvconf.EnvMapFloat].
+type EnvMapFloatFlag = Flag[goenvconf.EnvMapFloat, *goenvconf.EnvMapFloat]
+
+// EnvMapBoolFlag is the flag of [goenvconf.EnvMapBool].
+type EnvMapBoolFlag = Flag[goenvconf.EnvMapBool, *goenvconf.EnvMapBool]
+
+// Flag implements the [cli.Flag] interface for an Env type.
+//
+// The command-line argument is parsed by the Set method of the Env type: a literal value overrides
+// the value and clears the variable name of the Env instance, and an env:<VAR_NAME> argument sets the variable name.
+// The variable name of the Env instance is resolved by goenvconf when the value is read,
+// while EnvVars are looked up by the framework when the flag is applied and set as literal values.
+type Flag[T any, P EnvPointer[T]] struct {
+	Name        string
+	Category    string
+	DefaultText string
+	Usage       string
+	Required    bool
+	Hidden      bool
+	Aliases     []string
+	EnvVars     []string
+	// Value is the default Env instance of the flag.
+	Value T
+	// Destination receives the Env instance if not nil. Otherwise, the flag stores the instance in Value.
+	Destination *T
+
+	hasBeenSet   bool
+	defaultValue *string
+}
+
+var (
+	_ cli.RequiredFlag      = (*EnvStringFlag)(nil)
+	_ cli.DocGenerationFlag = (*EnvStringFlag)(nil)
+	_ cli.CategorizableFlag = (*EnvStringFlag)(nil)
+)
+
+// String returns a readable representation of this value (for usage defaults).
+func (f *Flag[T, P]) String() string {
+	return cli.FlagStringer(f)
+}
+
+// Names returns the names of the flag.
+func (f *Flag[T, P]) Names() []string {
+	return cli.FlagNames(f.Name, f.Aliases)
+}
+
+// IsSet returns whether or not the flag has been set through env or the command line.
+func (f *Flag[T, P]) IsSet() bool {
+	return f.hasBeenSet
+}
+
+// IsRequired returns whether or not the flag is required.
+func (f *Flag[T, P]) IsRequired() bool {
+	return f.Required
+}
+
+// IsVisible returns true if the flag is not hidden, otherwise false.
+func (f *Flag[T, P]) IsVisible() bool {
+	return !f.Hidden
+}
+
+// GetCategory returns the category for the flag.
+func (f *Flag[T, P]) GetCategory() string {
+	return f.Category
+}
+
+// TakesValue returns true if the flag takes a value. Boolean flags can be set without a value.
+func (f *Flag[T, P]) TakesValue() bool {
+	return !isBoolFlag(P(f.target()))
+}
+
+// GetUsage returns the usage string for the flag.
+func (f *Flag[T, P]) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flag value as string representation.
+func (f *Flag[T, P]) GetValue() string {
+	return P(f.target()).FlagString()
+}
+
+// GetDefaultText returns the default text for this flag.
+func (f *Flag[T, P]) GetDefaultText() string {
+	if f.DefaultText != "" {
+		return f.DefaultText
+	}
+
+	if f.defaultValue != nil {
+		return *f.defaultValue
+	}
+
+	return P(&f.Value).FlagString()
+}
+
+// GetEnvVars returns the env vars for this flag.
+func (f *Flag[T, P]) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+// Apply registers the flag to the flag set.
+func (f *Flag[T, P]) Apply(set *flag.FlagSet) error {
+	defaultValue := P(&f.Value).FlagString()
+	f.defaultValue = &defaultValue
+
+	target := f.target()
+	if f.Destination != nil {
+		*f.Destination = f.Value
+	}
+
+	for _, name := range f.EnvVars {
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			continue
+		}
+
+		if err := P(target).Set(value); err != nil {
+			return fmt.Errorf("could not parse %q from environment variable %q as value for flag %s: %w", value, name, f.Name, err)
+		}
+
+		f.hasBeenSet = true
+
+		break
+	}
+
+	for _, name := range f.Names() {
+		set.Var(&flagValue[T, P]{target: target, hasBeenSet: &f.hasBeenSet}, name, f.Usage)
+	}
+
+	return nil
+}
+
+// Get returns the Env instance of the flag in the given context.
+func (f *Flag[T, P]) Get(ctx *cli.Context) T {
+	value, _ := ctx.Value(f.Name).(T)
+
+	return value
+}
+
+func (f *Flag[T, P]) target() *T {
+	if f.Destination != nil {
+		return f.Destination
+	}
+
+	return &f.Value
+}
+
+// flagValue wraps an Env instance to implement the [flag.Getter] interface.
+type flagValue[T any, P EnvPointer[T]] struct {
+	target     *T
+	hasBeenSet *bool
+}
+
+// Set implements the flag.Value interface.
+func (fv *flagValue[T, P]) Set(value string) error {
+	if err := P(fv.target).Set(value); err != nil {
+		return err
+	}
+
+	*fv.hasBeenSet = true
+
+	return nil
+}
+
+// String implements the flag.Value interface.
+func (fv *flagValue[T, P]) String() string {
+	if fv.target == nil {
+		return ""
+	}
+
+	return P(fv.target).FlagString()
+}
+
+// Get implements the flag.Getter interface.
+func (fv *flagValue[T, P]) Get() any {
+	return *fv.target
+}
+
+// IsBoolFlag allows boolean flags to be set without an explicit value.
+func (fv *flagValue[T, P]) IsBoolFlag() bool {
+	return isBoolFlag(P(fv.target))
+}
+
+func isBoolFlag(value flag.Value) bool {
+	boolFlag, ok := value.(interface{ IsBoolFlag() bool })
+
+	return ok && boolFlag.IsBoolFlag()
+}
@@ -0,0 +1,137 @@
+package envcli
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+	"github.com/urfave/cli/v2"
+)
+
+func newTestApp(action cli.ActionFunc, flags ...cli.Flag) *cli.App {
+	return &cli.App{
+		Name:      "test",
+		Flags:     flags,
+		Action:    action,
+		Writer:    io.Discard,
+		ErrWriter: io.Discard,
+	}
+}
+
+func TestFlag(t *testing.T) {
+	t.Setenv("TEST_ENVCLI_HOST", "example.com")
+
+	var port goenvconf.EnvInt
+
+	hostFlag := &EnvStringFlag{
+		Name:    "host",
+		EnvVars: []string{"TEST_ENVCLI_HOST"},
+		Value:   goenvconf.NewEnvStringValue("localhost"),
+	}
+	portFlag := &EnvIntFlag{
+		Name:        "port",
+		Aliases:     []string{"p"},
+		Value:       goenvconf.NewEnvIntVariable("PORT"),
+		Destination: &port,
+	}
+	debugFlag := &EnvBoolFlag{Name: "debug"}
+	headersFlag := &EnvMapStringFlag{Name: "headers"}
+
+	var (
+		host    goenvconf.EnvString
+		debug   goenvconf.EnvBool
+		headers goenvconf.EnvMapString
+	)
+
+	app := newTestApp(func(ctx *cli.Context) error {
+		host = hostFlag.Get(ctx)
+		debug = debugFlag.Get(ctx)
+		headers = headersFlag.Get(ctx)
+
+		assertDeepEqual(t, true, ctx.IsSet("port"))
+		assertDeepEqual(t, false, ctx.IsSet("headers"))
+
+		return nil
+	}, hostFlag, portFlag, debugFlag, headersFlag)
+
+	assertNilError(t, app.Run([]string{"test", "-p", "9090", "--debug"}))
+	assertDeepEqual(t, goenvconf.NewEnvStringValue("example.com"), host)
+	assertDeepEqual(t, goenvconf.NewEnvIntValue(9090), port)
+	assertDeepEqual(t, goenvconf.NewEnvBoolValue(true), debug)
+	assertDeepEqual(t, goenvconf.EnvMapString{}, headers)
+	assertDeepEqual(t, true, hostFlag.IsSet())
+	assertDeepEqual(t, "localhost", hostFlag.GetDefaultText())
+	assertDeepEqual(t, "env:PORT", portFlag.GetDefaultText())
+}
+
+func TestFlag_EnvArgument(t *testing.T) {
+	var hosts goenvconf.EnvStringSlice
+
+	app := newTestApp(func(*cli.Context) error {
+		return nil
+	}, &EnvStringSliceFlag{
+		Name:        "hosts",
+		Value:       goenvconf.NewEnvStringSliceValue([]string{"a"}),
+		Destination: &hosts,
+	})
+
+	assertNilError(t, app.Run([]string{"test", "--hosts", "env:HOSTS"}))
+	assertDeepEqual(t, goenvconf.NewEnvStringSlice("HOSTS", []string{"a"}), hosts)
+}
+
+func TestFlag_Errors(t *testing.T) {
+	t.Setenv("TEST_ENVCLI_PORT", "foo")
+
+	app := newTestApp(nil, &EnvIntFlag{Name: "port"})
+	assertErrorContains(t, app.Run([]string{"test", "--port", "foo"}), "invalid integer value")
+
+	app = newTestApp(nil, &EnvIntFlag{Name: "port", EnvVars: []string{"TEST_ENVCLI_PORT"}})
+	assertErrorContains(t, app.Run([]string{"test"}), `could not parse "foo" from environment variable "TEST_ENVCLI_PORT"`)
+}
+
+func TestFlag_String(t *testing.T) {
+	portFlag := &EnvIntFlag{
+		Name:    "port",
+		Usage:   "the server port",
+		EnvVars: []string{"PORT"},
+		Value:   goenvconf.NewEnvIntValue(8080),
+	}
+	assertDeepEqual(t, "--port value\tthe server port (default: 8080) [$PORT]", portFlag.String())
+
+	debugFlag := &EnvBoolFlag{Name: "debug", Usage: "debug mode"}
+	assertDeepEqual(t, "--debug\tdebug mode", debugFlag.String())
+}
+
+func assertNilError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Errorf("expected nil error, got: %s", err)
+		t.FailNow()
+	}
+}
+
+func assertErrorContains(t *testing.T, err error, msg string) {
+	t.Helper()
+
+	if err == nil {
+		t.Errorf("expected error with content: `%s`, got: nil", msg)
+		t.FailNow()
+	}
+
+	if !strings.Contains(err.Error(), msg) {
+		t.Errorf("expected error with content: %s, got: %s", msg, err)
+		t.FailNow()
+	}
+}
+
+func assertDeepEqual(t *testing.T, expected, reality any) {
+	t.Helper()
+
+	if !reflect.DeepEqual(expected, reality) {
+		t.Errorf("%v != %v", expected, reality)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,63 @@
+// Package envcmp provides [go-cmp] options to compare goenvconf types in tests.
+//
+// [go-cmp]: https://github.com/google/go-cmp
+package envcmp
+
+import (
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hasura/goenvconf"
+)
+
+// Comparers returns comparers of all Env types, which compare the literal value and the variable name
+// with the Equal methods, so options of Env instances, e.g. validators, are ignored.
+func Comparers() cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(goenvconf.EnvString.Equal),
+		cmp.Comparer(goenvconf.EnvInt.Equal),
+		cmp.Comparer(goenvconf.EnvBool.Equal),
+		cmp.Comparer(goenvconf.EnvFloat.Equal),
+		cmp.Comparer(goenvconf.EnvAny.Equal),
+		cmp.Comparer(goenvconf.EnvStringSlice.Equal),
+		cmp.Comparer(goenvconf.EnvIntSlice.Equal),
+		cmp.Comparer(goenvconf.EnvFloatSlice.Equal),
+		cmp.Comparer(goenvconf.EnvBoolSlice.Equal),
+		cmp.Comparer(goenvconf.EnvMapString.Equal),
+		cmp.Comparer(goenvconf.EnvMapInt.Equal),
+		cmp.Comparer(goenvconf.EnvMapFloat.Equal),
+		cmp.Comparer(goenvconf.EnvMapBool.Equal),
+	}
+}
+
+// Resolved is the result of an Env value which is resolved by the [Resolve] transformer.
+type Resolved struct {
+	Value any
+	Error string
+}
+
+// Resolve returns a transformer which resolves Env values with the getter before comparing,
+// so Env instances are equal if they resolve to the same value or error message,
+// e.g. a literal 8080 and a variable which is set to 8080. The getter defaults to [goenvconf.GetOSEnv] if nil.
+// Nil pointers of Env types are compared as they are.
+func Resolve(getFunc goenvconf.GetEnvFunc) cmp.Option {
+	return cmp.FilterValues(
+		func(x, y goenvconf.EnvValue) bool {
+			return !isNilPointer(x) && !isNilPointer(y)
+		},
+		cmp.Transformer("goenvconf.Resolve", func(ev goenvconf.EnvValue) Resolved {
+			value, err := ev.Resolve(getFunc)
+			if err != nil {
+				return Resolved{Error: err.Error()}
+			}
+
+			return Resolved{Value: value}
+		}),
+	)
+}
+
+func isNilPointer(value any) bool {
+	reflectValue := reflect.ValueOf(value)
+
+	return reflectValue.Kind() == reflect.Pointer && reflectValue.IsNil()
+}
@@ -0,0 +1,95 @@
+package envcmp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hasura/goenvconf"
+)
+
+type serverConfig struct {
+	Host    goenvconf.EnvString
+	Port    *goenvconf.EnvInt
+	Origins goenvconf.EnvStringSlice
+	Limits  goenvconf.EnvMapInt
+	Extra   goenvconf.EnvAny
+}
+
+func newGetter(values map[string]string) goenvconf.GetEnvFunc {
+	return func(name string) (string, error) {
+		if value, ok := values[name]; ok {
+			return value, nil
+		}
+
+		return "", goenvconf.ErrEnvironmentVariableValueRequired
+	}
+}
+
+func TestComparers(t *testing.T) {
+	port := goenvconf.NewEnvInt("PORT", 8080)
+	expected := serverConfig{
+		Host:    goenvconf.NewEnvStringVariable("HOST").Required(),
+		Port:    &port,
+		Origins: goenvconf.NewEnvStringSliceValue([]string{"a"}),
+		Limits:  goenvconf.NewEnvMapIntValue(map[string]int64{"a": 1}),
+		Extra:   goenvconf.NewEnvAnyValue(map[string]any{"a": 1}),
+	}
+
+	port2 := goenvconf.NewEnvInt("PORT", 8080)
+	actual := serverConfig{
+		Host:    goenvconf.NewEnvStringVariable("HOST"),
+		Port:    &port2,
+		Origins: goenvconf.NewEnvStringSliceValue([]string{"a"}),
+		Limits:  goenvconf.NewEnvMapIntValue(map[string]int64{"a": 1}),
+		Extra:   goenvconf.NewEnvAnyValue(map[string]any{"a": 1}),
+	}
+
+	if diff := cmp.Diff(expected, actual, Comparers()); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+
+	actual.Origins = goenvconf.NewEnvStringSliceValue([]string{"b"})
+
+	diff := cmp.Diff(expected, actual, Comparers())
+	if !strings.Contains(diff, "Origins") {
+		t.Errorf("expected a diff of Origins, got:\n%s", diff)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	getFunc := newGetter(map[string]string{"HOST": "localhost", "PORT": "8080", "ORIGINS": "a,b"})
+
+	port := goenvconf.NewEnvIntVariable("PORT")
+	expected := serverConfig{
+		Host:    goenvconf.NewEnvStringValue("localhost"),
+		Port:    &port,
+		Origins: goenvconf.NewEnvStringSliceValue([]string{"a", "b"}),
+	}
+
+	port2 := goenvconf.NewEnvIntValue(8080)
+	actual := serverConfig{
+		Host:    goenvconf.NewEnvStringVariable("HOST"),
+		Port:    &port2,
+		Origins: goenvconf.NewEnvStringSliceVariable("ORIGINS"),
+	}
+
+	if diff := cmp.Diff(expected, actual, Resolve(getFunc)); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+
+	actual.Port = nil
+
+	diff := cmp.Diff(expected, actual, Resolve(getFunc))
+	if !strings.Contains(diff, "Port") {
+		t.Errorf("expected a diff of Port, got:\n%s", diff)
+	}
+
+	actual.Port = &port2
+	actual.Host = goenvconf.NewEnvStringVariable("MISSING_HOST")
+
+	diff = cmp.Diff(expected, actual, Resolve(getFunc))
+	if !strings.Contains(diff, "MISSING_HOST: EmptyVar") {
+		t.Errorf("expected a diff of the resolution error, got:\n%s", diff)
+	}
+}
@@ -0,0 +1,505 @@
+// Package envconfig is a compatibility layer with [kelseyhightower/envconfig].
+// It populates structs annotated with envconfig tags using goenvconf's getters and error types,
+// so existing services can migrate incrementally by replacing the import path only.
+//
+// The following struct tags are supported:
+//   - envconfig: the alternative variable name of the field.
+//   - default: the default value if the variable is not set.
+//   - required: returns an error if the variable is not set and there is no default value.
+//   - split_words: splits the camel-cased field name into upper-cased words joined with underscores.
+//   - ignored: ignores the field.
+//
+// Fields of goenvconf Env types are not resolved immediately. Instead, their variable names are set to the
+// computed keys, or to the unprefixed alternative names if the variables are only set under those names,
+// and their literal values are set to the default values, so they can be resolved later.
+//
+// [kelseyhightower/envconfig]: https://github.com/kelseyhightower/envconfig
+package envconfig
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hasura/goenvconf"
+)
+
+// ErrInvalidSpecification indicates that a specification is of the wrong type.
+var ErrInvalidSpecification = errors.New("specification must be a struct pointer")
+
+var (
+	gatherRegexp  = regexp.MustCompile("([^A-Z]+|[A-Z]+[^A-Z]+|[A-Z]+)")
+	acronymRegexp = regexp.MustCompile("([A-Z]+)([A-Z][^A-Z]+)")
+	durationType  = reflect.TypeFor[time.Duration]()
+)
+
+// envValueType is the interface of Env types, which are processed as single variables.
+var envValueType = reflect.TypeFor[goenvconf.EnvValue]()
+
+// Decoder has the same semantics as Setter, but takes higher precedence.
+// It is provided for historical compatibility.
+type Decoder interface {
+	Decode(value string) error
+}
+
+// Setter is implemented by types can self-deserialize values.
+// Any type that implements flag.Value also implements Setter.
+type Setter interface {
+	Set(value string) error
+}
+
+// varInfo maintains information about the configuration variable.
+type varInfo struct {
+	Name  string
+	Alt   string
+	Key   string
+	Field reflect.Value
+	Tags  reflect.StructTag
+}
+
+// Process populates the specified struct based on environment variables.
+func Process(prefix string, spec any) error {
+	return ProcessCustom(prefix, spec, goenvconf.GetOSEnv)
+}
+
+// MustProcess is the same as [Process] but panics if an error occurs.
+func MustProcess(prefix string, spec any) {
+	if err := Process(prefix, spec); err != nil {
+		panic(err)
+	}
+}
+
+// ProcessCustom populates the specified struct based on variables of a custom function.
+// The function must return [goenvconf.ErrEnvironmentVariableValueRequired] if the variable is not set.
+func ProcessCustom(prefix string, spec any, getFunc goenvconf.GetEnvFunc) error {
+	infos, err := gatherInfo(prefix, spec)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		name := info.Key
+
+		value, ok, err := lookupEnv(getFunc, name)
+		if err != nil {
+			return err
+		}
+
+		if !ok && info.Alt != "" {
+			value, ok, err = lookupEnv(getFunc, info.Alt)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				name = info.Alt
+			}
+		}
+
+		def, hasDefault := info.Tags.Lookup("default")
+		required := isTrue(info.Tags.Get("required"))
+
+		if isEnvType(indirectType(info.Field.Type())) {
+			if !ok && !hasDefault && required {
+				return requiredError(info.Key)
+			}
+
+			if err := processEnvField(info.Field, name, def, hasDefault); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !ok {
+			if !hasDefault {
+				if required {
+					return requiredError(info.Key)
+				}
+
+				continue
+			}
+
+			value = def
+		}
+
+		if err := processField(value, info.Field); err != nil {
+			return goenvconf.NewParseEnvFailedError(
+				fmt.Sprintf("failed to parse %s into %s", info.Key, info.Field.Type()),
+				err.Error(),
+			)
+		}
+	}
+
+	return nil
+}
+
+func lookupEnv(getFunc goenvconf.GetEnvFunc, key string) (string, bool, error) {
+	value, err := getFunc(key)
+	if err == nil {
+		return value, true, nil
+	}
+
+	if errors.Is(err, goenvconf.ErrEnvironmentVariableValueRequired) {
+		return "", false, nil
+	}
+
+	return "", false, err
+}
+
+func requiredError(key string) error {
+	return fmt.Errorf("%s: %w", key, goenvconf.ErrEnvironmentVariableValueRequired)
+}
+
+// gatherInfo gathers information about the specified struct.
+func gatherInfo(prefix string, spec any) ([]varInfo, error) {
+	specValue := reflect.ValueOf(spec)
+	if specValue.Kind() != reflect.Pointer || specValue.IsNil() {
+		return nil, ErrInvalidSpecification
+	}
+
+	specValue = specValue.Elem()
+	if specValue.Kind() != reflect.Struct {
+		return nil, ErrInvalidSpecification
+	}
+
+	specType := specValue.Type()
+	infos := make([]varInfo, 0, specValue.NumField())
+
+	for i := range specValue.NumField() {
+		field := specValue.Field(i)
+		fieldType := specType.Field(i)
+
+		if !field.CanSet() || isTrue(fieldType.Tag.Get("ignored")) {
+			continue
+		}
+
+		for field.Kind() == reflect.Pointer && !isEnvType(indirectType(field.Type())) {
+			if field.IsNil() {
+				if field.Type().Elem().Kind() != reflect.Struct {
+					// nil pointer to a non-struct: leave it alone
+					break
+				}
+
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+
+			field = field.Elem()
+		}
+
+		info := varInfo{
+			Name:  fieldType.Name,
+			Field: field,
+			Tags:  fieldType.Tag,
+			Alt:   strings.ToUpper(fieldType.Tag.Get("envconfig")),
+			Key:   fieldType.Name,
+		}
+
+		if isTrue(fieldType.Tag.Get("split_words")) {
+			info.Key = splitWords(fieldType.Name)
+		}
+
+		if info.Alt != "" {
+			info.Key = info.Alt
+		}
+
+		if prefix != "" {
+			info.Key = prefix + "_" + info.Key
+		}
+
+		info.Key = strings.ToUpper(info.Key)
+
+		if field.Kind() == reflect.Struct && !isEnvType(field.Type()) && !isDecodable(field) {
+			innerPrefix := prefix
+			if !fieldType.Anonymous {
+				innerPrefix = info.Key
+			}
+
+			embeddedInfos, err := gatherInfo(innerPrefix, field.Addr().Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			infos = append(infos, embeddedInfos...)
+
+			continue
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+func splitWords(name string) string {
+	words := gatherRegexp.FindAllStringSubmatch(name, -1)
+	if len(words) == 0 {
+		return name
+	}
+
+	results := make([]string, 0, len(words))
+
+	for _, word := range words {
+		if m := acronymRegexp.FindStringSubmatch(word[0]); len(m) == 3 {
+			results = append(results, m[1], m[2])
+		} else {
+			results = append(results, word[0])
+		}
+	}
+
+	return strings.Join(results, "_")
+}
+
+// processEnvField sets the variable name and the default literal value of an Env field.
+// The name is the unprefixed alternative name if the variable is only found under that name.
+func processEnvField(field reflect.Value, name string, def string, hasDefault bool) error {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+
+		field = field.Elem()
+	}
+
+	variable := field.FieldByName("Variable")
+	if !variable.IsNil() && variable.Elem().String() != "" {
+		return nil
+	}
+
+	input := map[string]any{
+		"env": name,
+	}
+
+	if hasDefault {
+		input["value"] = def
+	}
+
+	result, err := goenvconf.DecodeHook(reflect.TypeOf(input), field.Type(), input)
+	if err != nil {
+		return err
+	}
+
+	field.Set(reflect.ValueOf(result))
+
+	return nil
+}
+
+func processField(value string, field reflect.Value) error { //nolint:cyclop,funlen
+	typ := field.Type()
+
+	if typ.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(typ.Elem()))
+		}
+
+		return processField(value, field.Elem())
+	}
+
+	if decoder := asDecoder(field); decoder != nil {
+		return decoder.Decode(value)
+	}
+
+	if setter := asSetter(field); setter != nil {
+		return setter.Set(value)
+	}
+
+	if unmarshaler := asTextUnmarshaler(field); unmarshaler != nil {
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+
+	if unmarshaler := asBinaryUnmarshaler(field); unmarshaler != nil {
+		return unmarshaler.UnmarshalBinary([]byte(value))
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var (
+			val int64
+			err error
+		)
+
+		if typ == durationType {
+			var duration time.Duration
+
+			duration, err = time.ParseDuration(value)
+			val = int64(duration)
+		} else {
+			val, err = strconv.ParseInt(value, 0, typ.Bits())
+		}
+
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(val)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		val, err := strconv.ParseUint(value, 0, typ.Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(val)
+	case reflect.Bool:
+		val, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(val)
+	case reflect.Float32, reflect.Float64:
+		val, err := strconv.ParseFloat(value, typ.Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(val)
+	case reflect.Slice:
+		return processSlice(value, field)
+	case reflect.Map:
+		return processMap(value, field)
+	default:
+		return goenvconf.NewParseEnvFailedError("unsupported field type", typ.String())
+	}
+
+	return nil
+}
+
+// processSlice parses a slice with the envconfig syntax: <value1>,<value2>.
+// Unlike goenvconf slices, values are always split on commas, e.g. ["a","b"] is parsed as the items ["a" and "b"].
+func processSlice(value string, field reflect.Value) error {
+	typ := field.Type()
+
+	if typ.Elem().Kind() == reflect.Uint8 {
+		field.Set(reflect.ValueOf([]byte(value)).Convert(typ))
+
+		return nil
+	}
+
+	var rawValues []string
+
+	if strings.TrimSpace(value) != "" {
+		rawValues = strings.Split(value, ",")
+	}
+
+	results := reflect.MakeSlice(typ, len(rawValues), len(rawValues))
+
+	for i, rawValue := range rawValues {
+		if err := processField(rawValue, results.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	field.Set(results)
+
+	return nil
+}
+
+// processMap parses a map with the envconfig syntax: <key1>:<value1>,<key2>:<value2>.
+// Pairs are always split on commas, and JSON objects are not detected.
+func processMap(value string, field reflect.Value) error {
+	typ := field.Type()
+	results := reflect.MakeMap(typ)
+
+	if strings.TrimSpace(value) != "" {
+		for index, pair := range strings.Split(value, ",") {
+			rawKey, rawValue, ok := strings.Cut(pair, ":")
+			if !ok {
+				return goenvconf.NewParseEnvFailedError(
+					"invalid map item, expected: <key1>:<value1>,<key2>:<value2>",
+					strconv.Itoa(index),
+				)
+			}
+
+			key := reflect.New(typ.Key()).Elem()
+			if err := processField(rawKey, key); err != nil {
+				return err
+			}
+
+			item := reflect.New(typ.Elem()).Elem()
+			if err := processField(rawValue, item); err != nil {
+				return err
+			}
+
+			results.SetMapIndex(key, item)
+		}
+	}
+
+	field.Set(results)
+
+	return nil
+}
+
+func interfaceFrom[T any](field reflect.Value) (T, bool) {
+	var zero T
+
+	if field.CanInterface() {
+		if result, ok := field.Interface().(T); ok {
+			return result, true
+		}
+	}
+
+	if field.CanAddr() && field.Addr().CanInterface() {
+		if result, ok := field.Addr().Interface().(T); ok {
+			return result, true
+		}
+	}
+
+	return zero, false
+}
+
+func asDecoder(field reflect.Value) Decoder { //nolint:ireturn
+	result, _ := interfaceFrom[Decoder](field)
+
+	return result
+}
+
+func asSetter(field reflect.Value) Setter { //nolint:ireturn
+	result, _ := interfaceFrom[Setter](field)
+
+	return result
+}
+
+func asTextUnmarshaler(field reflect.Value) encoding.TextUnmarshaler { //nolint:ireturn
+	result, _ := interfaceFrom[encoding.TextUnmarshaler](field)
+
+	return result
+}
+
+func asBinaryUnmarshaler(field reflect.Value) encoding.BinaryUnmarshaler { //nolint:ireturn
+	result, _ := interfaceFrom[encoding.BinaryUnmarshaler](field)
+
+	return result
+}
+
+func isDecodable(field reflect.Value) bool {
+	return asDecoder(field) != nil ||
+		asSetter(field) != nil ||
+		asTextUnmarshaler(field) != nil ||
+		asBinaryUnmarshaler(field) != nil
+}
+
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
+
+// isEnvType checks if the type implements [goenvconf.EnvValue].
+func isEnvType(valueType reflect.Type) bool {
+	return valueType.Implements(envValueType)
+}
+
+func isTrue(s string) bool {
+	b, _ := strconv.ParseBool(s)
+
+	return b
+}
@@ -0,0 +1,277 @@
+package envconfig
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hasura/goenvconf"
+	"github.com/hasura/goenvconf/envconftest"
+)
+
+type Embedded struct {
+	Enabled bool
+}
+
+type database struct {
+	Host string `default:"localhost"`
+	Port int    `default:"5432"`
+}
+
+type level int
+
+func (l *level) Decode(value string) error {
+	switch value {
+	case "debug":
+		*l = 1
+	case "info":
+		*l = 2
+	default:
+		return errors.New("invalid level")
+	}
+
+	return nil
+}
+
+type specification struct {
+	Embedded
+
+	Debug        bool
+	Port         int
+	Rate         float32
+	User         string
+	TTL          time.Duration
+	MaxRetries   uint8  `split_words:"true"`
+	APIKey       string `envconfig:"API_KEY"     required:"true"`
+	Origins      []string
+	Ports        []int
+	Labels       map[string]int
+	Level        level
+	Endpoint     *url.URL
+	Timeout      *int
+	Database     database
+	Pointer      *database
+	Ignored      string `ignored:"true"`
+	Name         goenvconf.EnvString
+	Workers      *goenvconf.EnvInt `default:"4"`
+	Preconfigure goenvconf.EnvBool
+	unexported   string
+}
+
+func TestProcess(t *testing.T) {
+	t.Setenv("APP_DEBUG", "true")
+	t.Setenv("APP_PORT", "8080")
+	t.Setenv("APP_RATE", "0.5")
+	t.Setenv("APP_USER", "admin")
+	t.Setenv("APP_TTL", "5s")
+	t.Setenv("APP_MAX_RETRIES", "3")
+	t.Setenv("API_KEY", "secret")
+	t.Setenv("APP_ORIGINS", "a,b")
+	t.Setenv("APP_PORTS", "1,2")
+	t.Setenv("APP_LABELS", "a:1,b:2")
+	t.Setenv("APP_LEVEL", "info")
+	t.Setenv("APP_ENDPOINT", "https://hasura.io")
+	t.Setenv("APP_TIMEOUT", "10")
+	t.Setenv("APP_ENABLED", "true")
+	t.Setenv("APP_DATABASE_PORT", "6543")
+	t.Setenv("APP_POINTER_HOST", "remote")
+	t.Setenv("APP_IGNORED", "ignored")
+
+	spec := specification{
+		Preconfigure: goenvconf.NewEnvBoolVariable("CUSTOM_VAR"),
+	}
+
+	assertNilError(t, Process("app", &spec))
+
+	endpoint, _ := url.Parse("https://hasura.io")
+
+	assertDeepEqual(t, specification{
+		Embedded:     Embedded{Enabled: true},
+		Debug:        true,
+		Port:         8080,
+		Rate:         0.5,
+		User:         "admin",
+		TTL:          5 * time.Second,
+		MaxRetries:   3,
+		APIKey:       "secret",
+		Origins:      []string{"a", "b"},
+		Ports:        []int{1, 2},
+		Labels:       map[string]int{"a": 1, "b": 2},
+		Level:        2,
+		Endpoint:     endpoint,
+		Timeout:      toPtr(10),
+		Database:     database{Host: "localhost", Port: 6543},
+		Pointer:      &database{Host: "remote", Port: 5432},
+		Name:         goenvconf.NewEnvStringVariable("APP_NAME"),
+		Workers:      toPtr(goenvconf.NewEnvInt("APP_WORKERS", 4)),
+		Preconfigure: goenvconf.NewEnvBoolVariable("CUSTOM_VAR"),
+	}, spec)
+}
+
+func TestProcessCustom_commaSplitting(t *testing.T) {
+	var spec struct {
+		Origins []string
+		Empty   []string
+		Labels  map[string]string
+	}
+
+	// Values are split on commas like kelseyhightower/envconfig, without detecting JSON arrays.
+	assertNilError(t, ProcessCustom("", &spec, envconftest.NewGetEnvFunc(map[string]string{
+		"ORIGINS": `["a,b", ""]`,
+		"EMPTY":   " ",
+		"LABELS":  `{"a":"1"}`,
+	})))
+	assertDeepEqual(t, []string{`["a`, `b"`, ` ""]`}, spec.Origins)
+	assertDeepEqual(t, []string{}, spec.Empty)
+	assertDeepEqual(t, map[string]string{`{"a"`: `"1"}`}, spec.Labels)
+}
+
+func TestProcessCustom_Errors(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Spec     any
+		Env      map[string]string
+		ErrorMsg string
+	}{
+		{
+			Name:     "invalid_spec",
+			Spec:     specification{},
+			ErrorMsg: ErrInvalidSpecification.Error(),
+		},
+		{
+			Name: "required",
+			Spec: &struct {
+				Token string `required:"true"`
+			}{},
+			ErrorMsg: "TOKEN: EmptyVar",
+		},
+		{
+			Name: "required_env_type",
+			Spec: &struct {
+				Token goenvconf.EnvString `required:"true"`
+			}{},
+			ErrorMsg: "TOKEN: EmptyVar",
+		},
+		{
+			Name: "parse_failed",
+			Spec: &struct {
+				Port int
+			}{},
+			Env:      map[string]string{"PORT": "abc"},
+			ErrorMsg: "ParseEnvFailed: failed to parse PORT into int",
+		},
+		{
+			Name: "invalid_map",
+			Spec: &struct {
+				Labels map[string]string
+			}{},
+			Env:      map[string]string{"LABELS": "a:1,b"},
+			ErrorMsg: "invalid map item",
+		},
+		{
+			Name: "invalid_default",
+			Spec: &struct {
+				Port goenvconf.EnvInt `default:"abc"`
+			}{},
+			ErrorMsg: "invalid integer value",
+		},
+		{
+			Name: "decoder_error",
+			Spec: &struct {
+				Level level
+			}{},
+			Env:      map[string]string{"LEVEL": "trace"},
+			ErrorMsg: "invalid level",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := ProcessCustom("", tc.Spec, envconftest.NewGetEnvFunc(tc.Env))
+			assertErrorContains(t, err, tc.ErrorMsg)
+		})
+	}
+
+	t.Run("getter_error", func(t *testing.T) {
+		err := ProcessCustom("", &struct{ Port int }{}, func(string) (string, error) {
+			return "", errors.New("mock error")
+		})
+		assertErrorContains(t, err, "mock error")
+	})
+
+	t.Run("must_process", func(t *testing.T) {
+		defer func() {
+			assertDeepEqual(t, ErrInvalidSpecification, recover())
+		}()
+
+		MustProcess("", nil)
+	})
+}
+
+func TestSplitWords(t *testing.T) {
+	for input, expected := range map[string]string{
+		"MaxRetries":     "Max_Retries",
+		"APIKey":         "API_Key",
+		"HTTPServerPort": "HTTP_Server_Port",
+		"ID":             "ID",
+		"lower":          "lower",
+	} {
+		assertDeepEqual(t, expected, splitWords(input))
+	}
+}
+
+func assertNilError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Errorf("expected nil error, got: %s", err)
+		t.FailNow()
+	}
+}
+
+func assertErrorContains(t *testing.T, err error, msg string) {
+	t.Helper()
+
+	if err == nil {
+		t.Errorf("expected error with content: `%s`, got: nil", msg)
+		t.FailNow()
+	}
+
+	if !strings.Contains(err.Error(), msg) {
+		t.Errorf("expected error with content: %s, got: %s", msg, err)
+		t.FailNow()
+	}
+}
+
+func assertDeepEqual(t *testing.T, expected, reality any) {
+	t.Helper()
+
+	if !reflect.DeepEqual(expected, reality) {
+		t.Errorf("%v != %v", expected, reality)
+		t.FailNow()
+	}
+}
+
+func toPtr[T any](input T) *T {
+	return &input
+}
+
+func TestProcessCustom_EnvAltName(t *testing.T) {
+	var spec struct {
+		Token goenvconf.EnvString `envconfig:"TOKEN" required:"true"`
+		Port  goenvconf.EnvInt    `envconfig:"PORT"`
+	}
+
+	getFunc := envconftest.NewGetEnvFunc(map[string]string{"TOKEN": "secret", "APP_PORT": "8080"})
+
+	assertNilError(t, ProcessCustom("app", &spec, getFunc))
+	assertDeepEqual(t, goenvconf.NewEnvStringVariable("TOKEN"), spec.Token)
+	assertDeepEqual(t, goenvconf.NewEnvIntVariable("APP_PORT"), spec.Port)
+
+	token, err := spec.Token.GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, "secret", token)
+}
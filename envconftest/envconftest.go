@@ -0,0 +1,159 @@
+// Package envconftest provides helpers to test code which resolves goenvconf types,
+// e.g. fake environments, getter stubs and assertions of [goenvconf.ParseEnvError] codes.
+package envconftest
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+)
+
+// NewGetEnvFunc creates a getter which reads a copy of the values.
+// Missing variables return [goenvconf.ErrEnvironmentVariableValueRequired].
+func NewGetEnvFunc(values map[string]string) goenvconf.GetEnvFunc {
+	return NewEnv(values).GetEnv
+}
+
+// NewErrorGetEnvFunc creates a getter which always returns the error, e.g. to simulate a provider outage.
+func NewErrorGetEnvFunc(err error) goenvconf.GetEnvFunc {
+	return func(string) (string, error) {
+		return "", err
+	}
+}
+
+// Env is a fake environment store which is safe for concurrent use. It records the names of all lookups.
+type Env struct {
+	mu      sync.RWMutex
+	values  map[string]string
+	lookups []string
+}
+
+// NewEnv creates a fake environment store with a copy of the values.
+func NewEnv(values map[string]string) *Env {
+	result := &Env{
+		values: make(map[string]string, len(values)),
+	}
+
+	maps.Copy(result.values, values)
+
+	return result
+}
+
+// Set sets the variable for the duration of the test. The previous state is restored when the test finishes.
+func (e *Env) Set(tb testing.TB, key string, value string) {
+	tb.Helper()
+
+	e.scope(tb, key)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.values[key] = value
+}
+
+// Unset unsets the variable for the duration of the test. The previous state is restored when the test finishes.
+func (e *Env) Unset(tb testing.TB, key string) {
+	tb.Helper()
+
+	e.scope(tb, key)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.values, key)
+}
+
+// GetEnv implements [goenvconf.GetEnvFunc]. Missing variables return [goenvconf.ErrEnvironmentVariableValueRequired].
+func (e *Env) GetEnv(name string) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.lookups = append(e.lookups, name)
+
+	value, ok := e.values[name]
+	if !ok {
+		return "", goenvconf.ErrEnvironmentVariableValueRequired
+	}
+
+	return value, nil
+}
+
+// EnvNames returns the sorted names of the variables. It implements [goenvconf.EnvLister] for prefix scans.
+func (e *Env) EnvNames() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(e.values))
+}
+
+// Lookups returns the names of all lookups in order.
+func (e *Env) Lookups() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return append([]string(nil), e.lookups...)
+}
+
+// scope registers a cleanup function which restores the current state of the variable.
+func (e *Env) scope(tb testing.TB, key string) {
+	e.mu.RLock()
+	previous, existed := e.values[key]
+	e.mu.RUnlock()
+
+	tb.Cleanup(func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if existed {
+			e.values[key] = previous
+		} else {
+			delete(e.values, key)
+		}
+	})
+}
+
+// Stub replaces the getter variable with a fake environment of the values for the duration of the test,
+// e.g. a package-level getter of the code under test. The previous getter is restored when the test finishes.
+func Stub(tb testing.TB, target *goenvconf.GetEnvFunc, values map[string]string) *Env {
+	tb.Helper()
+
+	previous := *target
+	env := NewEnv(values)
+	*target = env.GetEnv
+
+	tb.Cleanup(func() {
+		*target = previous
+	})
+
+	return env
+}
+
+// ErrorCode returns the code of the [goenvconf.ParseEnvError] in the error chain, e.g. ParseEnvFailed.
+func ErrorCode(err error) (string, bool) {
+	var parseErr goenvconf.ParseEnvError
+	if !errors.As(err, &parseErr) {
+		return "", false
+	}
+
+	return parseErr.Code, true
+}
+
+// AssertErrorCode fails the test if the error chain does not contain a [goenvconf.ParseEnvError] with the code.
+func AssertErrorCode(tb testing.TB, err error, code string) {
+	tb.Helper()
+
+	result, ok := ErrorCode(err)
+	if !ok {
+		tb.Errorf("expected an error with code %s, got: %v", code, err)
+
+		return
+	}
+
+	if result != code {
+		tb.Errorf("expected the error code %s, got %s: %v", code, result, err)
+	}
+}
@@ -0,0 +1,113 @@
+package envconftest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+)
+
+var getEnvFunc goenvconf.GetEnvFunc = goenvconf.GetOSEnv
+
+type recordingTB struct {
+	testing.TB
+
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestNewGetEnvFunc(t *testing.T) {
+	values := map[string]string{"PORT": "8080"}
+	getFunc := NewGetEnvFunc(values)
+	values["PORT"] = "9090"
+
+	result, err := goenvconf.NewEnvIntVariable("PORT").GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(8080), result)
+
+	_, err = getFunc("HOST")
+	assertDeepEqual(t, true, errors.Is(err, goenvconf.ErrEnvironmentVariableValueRequired))
+
+	_, err = NewErrorGetEnvFunc(errors.New("outage"))("HOST")
+	assertDeepEqual(t, "outage", err.Error())
+}
+
+func TestEnv(t *testing.T) {
+	env := NewEnv(map[string]string{"HOST": "localhost"})
+
+	t.Run("scoped", func(t *testing.T) {
+		env.Set(t, "HOST", "example.com")
+		env.Set(t, "PORT", "8080")
+		env.Unset(t, "HOST")
+
+		_, err := env.GetEnv("HOST")
+		assertDeepEqual(t, true, errors.Is(err, goenvconf.ErrEnvironmentVariableValueRequired))
+
+		port, err := env.GetEnv("PORT")
+		assertNilError(t, err)
+		assertDeepEqual(t, "8080", port)
+		assertDeepEqual(t, []string{"PORT"}, env.EnvNames())
+	})
+
+	host, err := env.GetEnv("HOST")
+	assertNilError(t, err)
+	assertDeepEqual(t, "localhost", host)
+
+	_, err = env.GetEnv("PORT")
+	assertDeepEqual(t, true, errors.Is(err, goenvconf.ErrEnvironmentVariableValueRequired))
+	assertDeepEqual(t, []string{"HOST", "PORT", "HOST", "PORT"}, env.Lookups())
+}
+
+func TestStub(t *testing.T) {
+	t.Run("stubbed", func(t *testing.T) {
+		env := Stub(t, &getEnvFunc, map[string]string{"HOST": "localhost"})
+
+		result, err := goenvconf.NewEnvStringVariable("HOST").GetCustom(getEnvFunc)
+		assertNilError(t, err)
+		assertDeepEqual(t, "localhost", result)
+		assertDeepEqual(t, []string{"HOST"}, env.Lookups())
+	})
+
+	assertDeepEqual(t, reflect.ValueOf(goenvconf.GetOSEnv).Pointer(), reflect.ValueOf(getEnvFunc).Pointer())
+}
+
+func TestAssertErrorCode(t *testing.T) {
+	_, err := goenvconf.NewEnvStringVariable("HOST").Required().GetCustom(NewGetEnvFunc(map[string]string{"HOST": ""}))
+	AssertErrorCode(t, err, goenvconf.ErrCodeValidationFailed)
+
+	_, err = goenvconf.NewEnvIntVariable("PORT").GetCustom(NewGetEnvFunc(nil))
+	AssertErrorCode(t, err, "EmptyVar")
+
+	code, ok := ErrorCode(errors.New("foo"))
+	assertDeepEqual(t, false, ok)
+	assertDeepEqual(t, "", code)
+
+	tb := &recordingTB{TB: t}
+	AssertErrorCode(tb, errors.New("foo"), "EmptyVar")
+	AssertErrorCode(tb, goenvconf.NewValidationFailedError("too small", ""), "EmptyVar")
+	assertDeepEqual(t, []string{
+		"expected an error with code EmptyVar, got: foo",
+		"expected the error code EmptyVar, got ValidationFailed: ValidationFailed: too small",
+	}, tb.errors)
+}
+
+func assertNilError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+}
+
+func assertDeepEqual(t *testing.T, expected, reality any) {
+	t.Helper()
+
+	if !reflect.DeepEqual(expected, reality) {
+		t.Fatalf("%v != %v", expected, reality)
+	}
+}
@@ -0,0 +1,91 @@
+package envconftest
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.yaml.in/yaml/v3"
+)
+
+var updateGolden = flag.Bool("envconftest.update", false, "update golden files of envconftest assertions")
+
+// AssertJSONGolden marshals the config to indented JSON and compares it with the golden file, then unmarshals
+// the golden content back into a new value of the config type and verifies that it equals the config.
+// Env fields are compared with their Equal methods.
+// Run tests with the -envconftest.update flag to write the golden file instead.
+func AssertJSONGolden(tb testing.TB, path string, config any) {
+	tb.Helper()
+
+	assertGolden(tb, path, config, func(value any) ([]byte, error) {
+		result, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return append(result, '\n'), nil
+	}, json.Unmarshal)
+}
+
+// AssertYAMLGolden marshals the config to YAML and compares it with the golden file, then unmarshals
+// the golden content back into a new value of the config type and verifies that it equals the config.
+// Env fields are compared with their Equal methods.
+// Run tests with the -envconftest.update flag to write the golden file instead.
+func AssertYAMLGolden(tb testing.TB, path string, config any) {
+	tb.Helper()
+
+	assertGolden(tb, path, config, yaml.Marshal, yaml.Unmarshal)
+}
+
+func assertGolden(
+	tb testing.TB,
+	path string,
+	config any,
+	marshal func(any) ([]byte, error),
+	unmarshal func([]byte, any) error,
+) {
+	tb.Helper()
+
+	encoded, err := marshal(config)
+	if err != nil {
+		tb.Fatalf("failed to marshal the config: %s", err)
+	}
+
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			tb.Fatalf("failed to create the golden directory: %s", err)
+		}
+
+		if err := os.WriteFile(path, encoded, 0o600); err != nil {
+			tb.Fatalf("failed to write the golden file: %s", err)
+		}
+	}
+
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		tb.Fatalf("failed to read the golden file, run tests with -envconftest.update to create it: %s", err)
+	}
+
+	if diff := cmp.Diff(string(golden), string(encoded)); diff != "" {
+		tb.Errorf("the encoded config does not match the golden file %s (-golden +encoded):\n%s", path, diff)
+	}
+
+	expected := reflect.Indirect(reflect.ValueOf(config))
+	decoded := reflect.New(expected.Type())
+
+	if err := unmarshal(golden, decoded.Interface()); err != nil {
+		tb.Fatalf("failed to unmarshal the golden file: %s", err)
+	}
+
+	// Env types are compared by their Equal methods, so options of Env instances are ignored.
+	diff := cmp.Diff(expected.Interface(), decoded.Elem().Interface(), cmp.Exporter(func(reflect.Type) bool {
+		return true
+	}))
+	if diff != "" {
+		tb.Errorf("the decoded config does not equal the config (-config +decoded):\n%s", diff)
+	}
+}
@@ -0,0 +1,70 @@
+package envconftest
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hasura/goenvconf"
+)
+
+type goldenServerConfig struct {
+	Host    goenvconf.EnvString      `json:"host"    yaml:"host"`
+	Port    goenvconf.EnvInt         `json:"port"    yaml:"port"`
+	Origins goenvconf.EnvStringSlice `json:"origins" yaml:"origins"`
+}
+
+type goldenConfig struct {
+	Server  goldenServerConfig     `json:"server"          yaml:"server"`
+	Debug   *goenvconf.EnvBool     `json:"debug,omitempty" yaml:"debug,omitempty"`
+	Limits  goenvconf.EnvMapInt    `json:"limits"          yaml:"limits"`
+	Extra   goenvconf.EnvAny       `json:"extra"           yaml:"extra"`
+	Timeout time.Duration          `json:"timeout"         yaml:"timeout"`
+	Labels  goenvconf.EnvMapString `json:"labels"          yaml:"labels"`
+}
+
+func newGoldenConfig() goldenConfig {
+	debug := goenvconf.NewEnvBool("DEBUG", false)
+
+	return goldenConfig{
+		Server: goldenServerConfig{
+			Host:    goenvconf.NewEnvString("SERVER_HOST", "localhost").Required(),
+			Port:    goenvconf.NewEnvIntVariable("SERVER_PORT").WithCompactEncoding(),
+			Origins: goenvconf.NewEnvStringSliceValue([]string{"a", "b"}),
+		},
+		Debug:   &debug,
+		Limits:  goenvconf.NewEnvMapIntValue(map[string]int64{"a": 1}),
+		Extra:   goenvconf.NewEnvAnyValue(map[string]any{"foo": "bar"}),
+		Timeout: time.Second,
+		Labels:  goenvconf.NewEnvMapStringVariable("LABELS"),
+	}
+}
+
+func TestAssertJSONGolden(t *testing.T) {
+	AssertJSONGolden(t, filepath.Join("testdata", "config.golden.json"), newGoldenConfig())
+	AssertJSONGolden(t, filepath.Join("testdata", "config.golden.json"), toPtr(newGoldenConfig()))
+}
+
+func TestAssertYAMLGolden(t *testing.T) {
+	AssertYAMLGolden(t, filepath.Join("testdata", "config.golden.yaml"), newGoldenConfig())
+}
+
+func TestAssertGolden_Mismatch(t *testing.T) {
+	if *updateGolden {
+		t.Skip("the golden file is updated")
+	}
+
+	config := newGoldenConfig()
+	config.Server.Port = goenvconf.NewEnvIntVariable("PORT")
+
+	tb := &recordingTB{TB: t}
+	AssertJSONGolden(tb, filepath.Join("testdata", "config.golden.json"), config)
+	assertDeepEqual(t, 2, len(tb.errors))
+	assertDeepEqual(t, true, strings.Contains(tb.errors[0], "does not match the golden file"))
+	assertDeepEqual(t, true, strings.Contains(tb.errors[1], "does not equal the config"))
+}
+
+func toPtr[T any](value T) *T {
+	return &value
+}
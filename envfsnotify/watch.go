@@ -0,0 +1,69 @@
+// Package envfsnotify reloads goenvconf dotenv files on [fsnotify] file system events.
+//
+// [fsnotify]: https://github.com/fsnotify/fsnotify
+package envfsnotify
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/hasura/goenvconf"
+)
+
+// WatchDotEnvFile creates a [goenvconf.WatchSource] which reloads the dotenv file when it is written, created or renamed,
+// and reports the variables which changed. The parent directory is watched, so files which are replaced atomically
+// by editors and secret mounts are still reloaded. If the file fails to reload, e.g. while it is being written,
+// it is reloaded again at the next event.
+func WatchDotEnvFile(file *goenvconf.DotEnvFile) goenvconf.WatchSource {
+	return goenvconf.WatchSourceFunc(func(ctx context.Context, changes chan<- []string) error {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return err
+		}
+
+		defer watcher.Close()
+
+		path := filepath.Clean(file.Path())
+
+		if err := watcher.Add(filepath.Dir(path)); err != nil {
+			return err
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return nil
+				}
+
+				if filepath.Clean(event.Name) != path || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					continue
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return nil
+				}
+
+				// Events are dropped if the buffer overflows, so the file is reloaded in case it changed.
+				if !errors.Is(err, fsnotify.ErrEventOverflow) {
+					return err
+				}
+			}
+
+			changed, err := file.Reload()
+			if err != nil || len(changed) == 0 {
+				continue
+			}
+
+			select {
+			case changes <- changed:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package envfsnotify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hasura/goenvconf"
+)
+
+func TestWatchDotEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	writeFile(t, path, "DB_USER=admin\nDB_PASSWORD=secret\n")
+
+	file, err := goenvconf.NewDotEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan []string)
+	result := make(chan error, 1)
+
+	go func() {
+		result <- WatchDotEnvFile(file).Watch(ctx, changes)
+	}()
+
+	// Wait until the directory is watched. Other files in the directory are ignored.
+	time.Sleep(50 * time.Millisecond)
+	writeFile(t, filepath.Join(dir, "other.env"), "DB_USER=other\n")
+
+	// Replace the file atomically like editors and secret mounts.
+	tempPath := filepath.Join(dir, ".env.tmp")
+	writeFile(t, tempPath, "DB_USER=admin\nDB_PASSWORD=rotated\n")
+
+	if err := os.Rename(tempPath, path); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case names := <-changes:
+		if !reflect.DeepEqual([]string{"DB_PASSWORD"}, names) {
+			t.Fatalf("expected [DB_PASSWORD], got %v", names)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for changes")
+	}
+
+	value, err := file.GetEnv("DB_PASSWORD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "rotated" {
+		t.Fatalf("expected rotated, got %s", value)
+	}
+
+	cancel()
+
+	if err := <-result; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatchDotEnvFile_Watcher(t *testing.T) {
+	type config struct {
+		Password goenvconf.EnvString
+	}
+
+	path := filepath.Join(t.TempDir(), "app.env")
+	writeFile(t, path, "DB_PASSWORD=secret\n")
+
+	file, err := goenvconf.NewDotEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := goenvconf.NewWatcher(config{Password: goenvconf.NewEnvStringVariable("DB_PASSWORD")}, file.GetEnv,
+		goenvconf.WatcherOptions{Debounce: 10 * time.Millisecond}, WatchDotEnvFile(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = watcher.Run(ctx)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	writeFile(t, path, "DB_PASSWORD=rotated\n")
+
+	select {
+	case event := <-watcher.Events():
+		if event.Err != nil {
+			t.Fatal(event.Err)
+		}
+
+		if len(event.Changes) != 1 || event.Changes[0].New.Value != "rotated" {
+			t.Fatalf("unexpected changes: %+v", event.Changes)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a watch event")
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
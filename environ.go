@@ -0,0 +1,83 @@
+package goenvconf
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+// NewEnvironGetEnvFunc creates a getter of the environment entries in the key=value form, e.g. os.Environ().
+// The entries are indexed into a map once, so resolving large config structs costs one map lookup per field.
+// Entries without '=' are ignored. If a key is duplicated, the last value wins.
+// The getter returns [ErrEnvironmentVariableValueRequired] if the variable does not exist.
+func NewEnvironGetEnvFunc(environ []string) GetEnvFunc {
+	return newMapGetEnvFunc(parseEnviron(environ))
+}
+
+// parseEnviron indexes the environment entries in the key=value form into a map.
+func parseEnviron(environ []string) map[string]string {
+	values := make(map[string]string, len(environ))
+
+	for _, entry := range environ {
+		if entry == "" {
+			continue
+		}
+
+		// Windows has hidden entries such as =C:=C:\path whose keys start with '='.
+		index := strings.IndexByte(entry[1:], '=') + 1
+		if index == 0 {
+			continue
+		}
+
+		values[entry[:index]] = entry[index+1:]
+	}
+
+	return values
+}
+
+// SnapshotOSEnv snapshots the process environment once and returns a getter of the snapshot.
+// Later changes of the process environment are not visible to the getter.
+// Prefix scans through the getter list the names of the process environment and read the values of the snapshot,
+// so variables which are unset after the snapshot are not scanned. Use [BindOSEnv] to scan the snapshot exactly.
+func SnapshotOSEnv() GetEnvFunc {
+	return newSnapshotGetEnvFunc(parseEnviron(os.Environ()))
+}
+
+// BindOSEnv binds the source config into the target struct with a snapshot of the process environment,
+// instead of looking up the process environment once per field. See [Binder.Bind] for the binding rules.
+// Prefix-scanned maps list the variable names of the snapshot.
+func BindOSEnv(target any, source any) error {
+	values := parseEnviron(os.Environ())
+
+	return NewBinder(newSnapshotGetEnvFunc(values)).
+		WithEnvLister(EnvListerFunc(func() []string {
+			return slices.Collect(maps.Keys(values))
+		})).
+		Bind(target, source)
+}
+
+// newSnapshotGetEnvFunc creates a getter of a snapshot of the process environment.
+// Unlike other map getters, prefix scans recognize it as a getter of the process environment.
+func newSnapshotGetEnvFunc(values map[string]string) GetEnvFunc {
+	return func(name string) (string, error) {
+		return lookupMapValue(values, name)
+	}
+}
+
+// newMapGetEnvFunc creates a getter of the map which returns [ErrEnvironmentVariableValueRequired]
+// if the variable does not exist.
+func newMapGetEnvFunc(values map[string]string) GetEnvFunc {
+	return func(name string) (string, error) {
+		return lookupMapValue(values, name)
+	}
+}
+
+func lookupMapValue(values map[string]string, name string) (string, error) {
+	value, ok := values[name]
+	if !ok {
+		return "", ErrEnvironmentVariableValueRequired
+	}
+
+	return value, nil
+}
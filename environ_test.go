@@ -0,0 +1,113 @@
+package goenvconf
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestNewEnvironGetEnvFunc(t *testing.T) {
+	getFunc := NewEnvironGetEnvFunc([]string{
+		"HOST=localhost",
+		"TOKEN=abc==",
+		"EMPTY=",
+		"INVALID",
+		"",
+		"=C:=C:\\app",
+		"HOST=example.com",
+	})
+
+	testCases := []struct {
+		Name     string
+		Expected string
+	}{
+		{Name: "HOST", Expected: "example.com"},
+		{Name: "TOKEN", Expected: "abc=="},
+		{Name: "EMPTY", Expected: ""},
+		{Name: "=C:", Expected: "C:\\app"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := getFunc(tc.Name)
+			assertNilError(t, err)
+			assertDeepEqual(t, tc.Expected, result)
+		})
+	}
+
+	_, err := getFunc("INVALID")
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+}
+
+func TestSnapshotOSEnv(t *testing.T) {
+	t.Setenv("SNAPSHOT_HOST", "localhost")
+
+	getFunc := SnapshotOSEnv()
+
+	t.Setenv("SNAPSHOT_HOST", "example.com")
+
+	result, err := NewEnvStringVariable("SNAPSHOT_HOST").GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, "localhost", result)
+}
+
+func TestBindOSEnv(t *testing.T) {
+	t.Setenv("SERVER_PORT", "9090")
+	t.Setenv("ORIGINS", "a,b")
+
+	config := binderConfig{
+		Server: binderServerConfig{
+			Host:    NewEnvString("SERVER_HOST", "localhost"),
+			Port:    NewEnvIntVariable("SERVER_PORT"),
+			Origins: NewEnvStringSliceVariable("ORIGINS"),
+		},
+	}
+
+	var target binderTarget
+
+	assertNilError(t, BindOSEnv(&target, config))
+	assertDeepEqual(t, binderServerTarget{Host: "localhost", Port: 9090, Origins: []string{"a", "b"}}, target.Server)
+}
+
+type benchmarkEnvironConfig struct {
+	Fields [200]EnvString
+}
+
+func newBenchmarkEnvironConfig(b *testing.B) *benchmarkEnvironConfig {
+	b.Helper()
+
+	var config benchmarkEnvironConfig
+
+	for i := range config.Fields {
+		name := "BENCHMARK_ENVIRON_" + strconv.Itoa(i)
+		b.Setenv(name, strconv.Itoa(i))
+		config.Fields[i] = NewEnvStringVariable(name)
+	}
+
+	return &config
+}
+
+func BenchmarkResolve_GetOSEnv(b *testing.B) {
+	config := newBenchmarkEnvironConfig(b)
+
+	for b.Loop() {
+		for _, field := range config.Fields {
+			if _, err := field.GetCustom(GetOSEnv); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkResolve_SnapshotOSEnv(b *testing.B) {
+	config := newBenchmarkEnvironConfig(b)
+	getFunc := SnapshotOSEnv()
+
+	for b.Loop() {
+		for _, field := range config.Fields {
+			if _, err := field.GetCustom(getFunc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@
 package goenvconf
 
 import (
+	"context"
 	"errors"
 	"os"
 	"strconv"
@@ -10,10 +11,16 @@ import (
 // GetEnvFunc abstracts a custom function to get the value of an environment variable.
 type GetEnvFunc func(string) (string, error)
 
+// GetEnvFuncContext abstracts a custom function to get the value of an environment variable with a context,
+// e.g. a request to a remote secret store which can be cancelled.
+type GetEnvFuncContext func(ctx context.Context, name string) (string, error)
+
 // EnvString represents either a literal string or an environment reference.
 type EnvString struct {
-	Value    *string `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    *string `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvString creates an EnvString instance.
@@ -58,6 +65,24 @@ func (ev EnvString) Equal(target EnvString) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvString) Get() (string, error) {
+	result, err := ev.get()
+	if err != nil {
+		return "", err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvString) get() (string, error) {
+	if ev.options.usesGetFunc() {
+		// An empty variable falls back to the literal value like the plain lookup below.
+		if ev.Value != nil && !ev.options.isStrict() {
+			return ev.getCustom(getNonEmptyOSEnv)
+		}
+
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.IsZero() {
 		return "", ErrEnvironmentValueRequired
 	}
@@ -74,7 +99,7 @@ func (ev EnvString) Get() (string, error) {
 	}
 
 	if ev.Value != nil {
-		return *ev.Value, nil
+		return ev.expandLiteral(*ev.Value, GetOSEnv)
 	}
 
 	if envExisted {
@@ -84,6 +109,16 @@ func (ev EnvString) Get() (string, error) {
 	return "", getEnvVariableValueRequiredError(ev.Variable)
 }
 
+// getNonEmptyOSEnv looks up the variable in the process environment, and treats an empty value as unset.
+func getNonEmptyOSEnv(name string) (string, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return "", ErrEnvironmentVariableValueRequired
+	}
+
+	return value, nil
+}
+
 // GetOrDefault returns the default value if the environment value is empty.
 func (ev EnvString) GetOrDefault(defaultValue string) (string, error) {
 	result, err := ev.Get()
@@ -100,8 +135,57 @@ func (ev EnvString) GetOrDefault(defaultValue string) (string, error) {
 	return result, nil
 }
 
+// GetNonEmpty gets the value like Get, but returns an error naming the variable if the resolved value is empty,
+// e.g. the variable is explicitly set to an empty string.
+func (ev EnvString) GetNonEmpty() (string, error) {
+	return ev.Required().Get()
+}
+
+// GetNonEmptyCustom gets the value like GetCustom, but returns an error naming the variable if the resolved value is empty.
+func (ev EnvString) GetNonEmptyCustom(getFunc GetEnvFunc) (string, error) {
+	return ev.Required().GetCustom(getFunc)
+}
+
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty.
+func (ev EnvString) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue string) (string, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return "", err
+	} else if result == "" {
+		result = defaultValue
+	}
+
+	return result, nil
+}
+
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvString) GetCustom(getFunc GetEnvFunc) (string, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return "", err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvString) getCustom(getFunc GetEnvFunc) (string, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.IsZero() {
 		return "", ErrEnvironmentValueRequired
 	}
@@ -118,7 +202,7 @@ func (ev EnvString) GetCustom(getFunc GetEnvFunc) (string, error) {
 	}
 
 	if ev.Value != nil {
-		return *ev.Value, nil
+		return ev.expandLiteral(*ev.Value, getFunc)
 	}
 
 	return "", getEnvVariableValueRequiredError(ev.Variable)
@@ -126,8 +210,10 @@ func (ev EnvString) GetCustom(getFunc GetEnvFunc) (string, error) {
 
 // EnvInt represents either a literal integer or an environment reference.
 type EnvInt struct {
-	Value    *int64  `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    *int64  `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvInt creates an EnvInt instance.
@@ -172,6 +258,19 @@ func (ev EnvInt) Equal(target EnvInt) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvInt) Get() (int64, error) {
+	result, err := ev.get()
+	if err != nil {
+		return 0, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvInt) get() (int64, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.IsZero() {
 		return 0, ErrEnvironmentValueRequired
 	}
@@ -179,7 +278,7 @@ func (ev EnvInt) Get() (int64, error) {
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue := os.Getenv(*ev.Variable)
 		if rawValue != "" {
-			return strconv.ParseInt(rawValue, 10, 64)
+			return ev.options.parseInt(rawValue)
 		}
 	}
 
@@ -204,8 +303,44 @@ func (ev EnvInt) GetOrDefault(defaultValue int64) (int64, error) {
 	return result, nil
 }
 
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty.
+func (ev EnvInt) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue int64) (int64, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return 0, err
+	}
+
+	return result, nil
+}
+
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvInt) GetCustom(getFunc GetEnvFunc) (int64, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return 0, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvInt) getCustom(getFunc GetEnvFunc) (int64, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.IsZero() {
 		return 0, ErrEnvironmentValueRequired
 	}
@@ -217,7 +352,7 @@ func (ev EnvInt) GetCustom(getFunc GetEnvFunc) (int64, error) {
 		}
 
 		if rawValue != "" {
-			return strconv.ParseInt(rawValue, 10, 64)
+			return ev.options.parseInt(rawValue)
 		}
 	}
 
@@ -230,8 +365,10 @@ func (ev EnvInt) GetCustom(getFunc GetEnvFunc) (int64, error) {
 
 // EnvBool represents either a literal boolean or an environment reference.
 type EnvBool struct {
-	Value    *bool   `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    *bool   `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvBool creates an EnvBool instance.
@@ -276,6 +413,19 @@ func (ev EnvBool) Equal(target EnvBool) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvBool) Get() (bool, error) {
+	result, err := ev.get()
+	if err != nil {
+		return false, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvBool) get() (bool, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.IsZero() {
 		return false, ErrEnvironmentValueRequired
 	}
@@ -283,7 +433,7 @@ func (ev EnvBool) Get() (bool, error) {
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue := os.Getenv(*ev.Variable)
 		if rawValue != "" {
-			return strconv.ParseBool(rawValue)
+			return ev.options.parseBool(rawValue)
 		}
 	}
 
@@ -308,8 +458,44 @@ func (ev EnvBool) GetOrDefault(defaultValue bool) (bool, error) {
 	return result, nil
 }
 
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty.
+func (ev EnvBool) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue bool) (bool, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return false, err
+	}
+
+	return result, nil
+}
+
 // GetCustom gets literal value or from system environment with custom function.
 func (ev EnvBool) GetCustom(getFunc GetEnvFunc) (bool, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return false, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvBool) getCustom(getFunc GetEnvFunc) (bool, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.IsZero() {
 		return false, ErrEnvironmentValueRequired
 	}
@@ -321,7 +507,7 @@ func (ev EnvBool) GetCustom(getFunc GetEnvFunc) (bool, error) {
 		}
 
 		if rawValue != "" {
-			return strconv.ParseBool(rawValue)
+			return ev.options.parseBool(rawValue)
 		}
 	}
 
@@ -334,8 +520,10 @@ func (ev EnvBool) GetCustom(getFunc GetEnvFunc) (bool, error) {
 
 // EnvFloat represents either a literal floating point number or an environment reference.
 type EnvFloat struct {
-	Value    *float64 `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string  `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    *float64 `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string  `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvFloat creates an EnvFloat instance.
@@ -380,6 +568,19 @@ func (ev EnvFloat) Equal(target EnvFloat) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvFloat) Get() (float64, error) {
+	result, err := ev.get()
+	if err != nil {
+		return 0, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvFloat) get() (float64, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.IsZero() {
 		return 0, ErrEnvironmentValueRequired
 	}
@@ -412,8 +613,44 @@ func (ev EnvFloat) GetOrDefault(defaultValue float64) (float64, error) {
 	return result, nil
 }
 
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty.
+func (ev EnvFloat) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue float64) (float64, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return 0, err
+	}
+
+	return result, nil
+}
+
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvFloat) GetCustom(getFunc GetEnvFunc) (float64, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return 0, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvFloat) getCustom(getFunc GetEnvFunc) (float64, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.IsZero() {
 		return 0, ErrEnvironmentValueRequired
 	}
@@ -363,6 +363,66 @@ func TestEnvString_GetCustom(t *testing.T) {
 	}
 }
 
+func TestEnvString_GetNonEmpty(t *testing.T) {
+	t.Setenv("TEST_NON_EMPTY_URL", "")
+	t.Setenv("TEST_NON_EMPTY_HOST", "localhost")
+
+	_, err := NewEnvStringVariable("TEST_NON_EMPTY_URL").GetNonEmpty()
+	assertErrorContains(t, err, "TEST_NON_EMPTY_URL: ValidationFailed: the value must not be empty")
+
+	result, err := NewEnvStringVariable("TEST_NON_EMPTY_HOST").GetNonEmpty()
+	assertNilError(t, err)
+	assertDeepEqual(t, "localhost", result)
+
+	result, err = NewEnvString("TEST_NON_EMPTY_URL", "http://localhost").GetNonEmpty()
+	assertNilError(t, err)
+	assertDeepEqual(t, "http://localhost", result)
+
+	_, err = NewEnvStringValue("").GetNonEmpty()
+	assertErrorContains(t, err, "ValidationFailed: the value must not be empty")
+
+	_, err = NewEnvStringVariable("URL").GetNonEmptyCustom(newBinderGetter(map[string]string{"URL": ""}))
+	assertErrorContains(t, err, "URL: ValidationFailed: the value must not be empty")
+
+	_, err = NewEnvStringVariable("URL").GetNonEmptyCustom(newBinderGetter(map[string]string{}))
+	assertErrorContains(t, err, "URL: EmptyVar")
+}
+
+func TestEnvString_GetEmptyWithOptions(t *testing.T) {
+	t.Setenv("TEST_EMPTY_STRING", "")
+
+	testCases := []struct {
+		Name  string
+		Input EnvString
+	}{
+		{Name: "plain", Input: NewEnvString("TEST_EMPTY_STRING", "def")},
+		{Name: "candidates", Input: NewEnvString("TEST_EMPTY_STRING", "def").WithCandidateVariables("TEST_EMPTY_STRING_MISSING")},
+		{Name: "base64", Input: NewEnvString("TEST_EMPTY_STRING", "def").WithBase64()},
+		{Name: "json_pointer", Input: NewEnvString("TEST_EMPTY_STRING", "def").WithJSONPointer("/host")},
+		{Name: "or_else", Input: NewEnvString("TEST_EMPTY_STRING", "def").OrElse(NewEnvStringValue("alternative"))},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := tc.Input.Get()
+			assertNilError(t, err)
+			assertDeepEqual(t, "def", result)
+		})
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		result, err := NewEnvString("TEST_EMPTY_STRING", "def").Strict().Get()
+		assertNilError(t, err)
+		assertDeepEqual(t, "", result)
+	})
+
+	t.Run("no_literal", func(t *testing.T) {
+		result, err := NewEnvStringVariable("TEST_EMPTY_STRING").WithBase64().Get()
+		assertNilError(t, err)
+		assertDeepEqual(t, "", result)
+	})
+}
+
 func TestEnvInt_GetCustom(t *testing.T) {
 	testCases := []struct {
 		Name     string
@@ -891,3 +951,76 @@ func TestEnvFloat_Equal(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCustomOrDefault(t *testing.T) {
+	getFunc := newBinderGetter(map[string]string{
+		"PORT":    "8080",
+		"EMPTY":   "",
+		"BAD":     "x",
+		"TAGS":    "a,b",
+		"MAP":     "a=1",
+		"BAD_MAP": "a=x",
+	})
+
+	str, err := NewEnvStringVariable("EMPTY").GetCustomOrDefault(getFunc, "foo")
+	assertNilError(t, err)
+	assertDeepEqual(t, "foo", str)
+
+	port, err := NewEnvIntVariable("PORT").GetCustomOrDefault(getFunc, 80)
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(8080), port)
+
+	port, err = NewEnvIntVariable("MISSING").GetCustomOrDefault(getFunc, 80)
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(80), port)
+
+	_, err = NewEnvIntVariable("BAD").GetCustomOrDefault(getFunc, 80)
+	assertErrorContains(t, err, "invalid syntax")
+
+	flag, err := NewEnvBoolVariable("MISSING").GetCustomOrDefault(getFunc, true)
+	assertNilError(t, err)
+	assertDeepEqual(t, true, flag)
+
+	ratio, err := NewEnvFloatVariable("MISSING").GetCustomOrDefault(getFunc, 0.5)
+	assertNilError(t, err)
+	assertDeepEqual(t, 0.5, ratio)
+
+	tags, err := NewEnvStringSliceVariable("TAGS").GetCustomOrDefault(getFunc, []string{"c"})
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a", "b"}, tags)
+
+	ints, err := NewEnvIntSliceVariable("MISSING").GetCustomOrDefault(getFunc, []int64{1})
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{1}, ints)
+
+	floats, err := NewEnvFloatSliceVariable("MISSING").GetCustomOrDefault(getFunc, []float64{1})
+	assertNilError(t, err)
+	assertDeepEqual(t, []float64{1}, floats)
+
+	bools, err := NewEnvBoolSliceVariable("MISSING").GetCustomOrDefault(getFunc, []bool{true})
+	assertNilError(t, err)
+	assertDeepEqual(t, []bool{true}, bools)
+
+	strMap, err := NewEnvMapStringVariable("MISSING").GetCustomOrDefault(getFunc, map[string]string{"b": "2"})
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]string{"b": "2"}, strMap)
+
+	intMap, err := NewEnvMapIntVariable("MAP").GetCustomOrDefault(getFunc, map[string]int64{"b": 2})
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"a": 1}, intMap)
+
+	intMap, err = NewEnvMapIntVariable("MISSING").Strict().GetCustomOrDefault(getFunc, map[string]int64{"b": 2})
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"b": 2}, intMap)
+
+	floatMap, err := NewEnvMapFloat("MISSING", map[string]float64{"a": 1}).GetCustomOrDefault(getFunc, nil)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]float64{"a": 1}, floatMap)
+
+	_, err = NewEnvMapBoolVariable("BAD_MAP").GetCustomOrDefault(getFunc, nil)
+	assertErrorContains(t, err, "invalid boolean map syntax")
+
+	anyValue, err := NewEnvAnyVariable("MISSING").GetCustomOrDefault(getFunc, "fallback")
+	assertNilError(t, err)
+	assertDeepEqual(t, "fallback", anyValue)
+}
@@ -0,0 +1,130 @@
+package envkoanf
+
+import (
+	"errors"
+
+	"github.com/hasura/goenvconf"
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	envObjectValueKey    = "value"
+	envObjectVariableKey = "env"
+)
+
+// EnvParser implements the koanf.Parser interface that wraps another parser, e.g. JSON or YAML,
+// and resolves objects with the {value, env} shape in the parsed document.
+type EnvParser struct {
+	parser  koanf.Parser
+	getFunc goenvconf.GetEnvFunc
+}
+
+// Parser returns a parser that wraps the inner format parser.
+// Env references are resolved by the getFunc, or the OS environment if getFunc is nil.
+func Parser(parser koanf.Parser, getFunc goenvconf.GetEnvFunc) *EnvParser {
+	if getFunc == nil {
+		getFunc = goenvconf.GetOSEnv
+	}
+
+	return &EnvParser{
+		parser:  parser,
+		getFunc: getFunc,
+	}
+}
+
+// Unmarshal parses the bytes with the inner parser and resolves every {value, env} object.
+// The object is replaced by the raw value of the environment variable if it is not empty, otherwise by the literal value.
+// The key is removed if both are empty.
+func (ep *EnvParser) Unmarshal(b []byte) (map[string]any, error) {
+	result, err := ep.parser.Unmarshal(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ep.resolveMap(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Marshal marshals the map with the inner parser.
+func (ep *EnvParser) Marshal(m map[string]any) ([]byte, error) {
+	return ep.parser.Marshal(m)
+}
+
+func (ep *EnvParser) resolveMap(m map[string]any) error {
+	for key, value := range m {
+		result, ok, err := ep.resolve(value)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			m[key] = result
+		} else {
+			delete(m, key)
+		}
+	}
+
+	return nil
+}
+
+func (ep *EnvParser) resolve(value any) (any, bool, error) {
+	switch val := value.(type) {
+	case map[string]any:
+		if isEnvObject(val) {
+			return ep.resolveEnvObject(val)
+		}
+
+		return val, true, ep.resolveMap(val)
+	case []any:
+		for i, item := range val {
+			result, _, err := ep.resolve(item)
+			if err != nil {
+				return nil, false, err
+			}
+
+			val[i] = result
+		}
+
+		return val, true, nil
+	default:
+		return value, true, nil
+	}
+}
+
+func (ep *EnvParser) resolveEnvObject(object map[string]any) (any, bool, error) {
+	if variable, ok := object[envObjectVariableKey].(string); ok && variable != "" {
+		value, err := ep.getFunc(variable)
+		if err != nil && !errors.Is(err, goenvconf.ErrEnvironmentVariableValueRequired) {
+			return nil, false, err
+		}
+
+		if value != "" {
+			return value, true, nil
+		}
+	}
+
+	value, ok := object[envObjectValueKey]
+	if !ok || value == nil {
+		return nil, false, nil
+	}
+
+	return value, true, nil
+}
+
+// isEnvObject checks if the object only has value and/or env keys.
+func isEnvObject(object map[string]any) bool {
+	if len(object) == 0 {
+		return false
+	}
+
+	for key := range object {
+		if key != envObjectValueKey && key != envObjectVariableKey {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,81 @@
+package envkoanf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+	"github.com/hasura/goenvconf/envconftest"
+	"github.com/knadh/koanf/parsers/json"
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/rawbytes"
+	"github.com/knadh/koanf/v2"
+)
+
+func TestParser(t *testing.T) {
+	getFunc := envconftest.NewGetEnvFunc(map[string]string{
+		"DATABASE_URL": "postgres://db",
+		"EMPTY":        "",
+	})
+
+	t.Run("json", func(t *testing.T) {
+		k := koanf.New(".")
+		assertNilError(t, k.Load(rawbytes.Provider([]byte(`{
+			"database": {
+				"url": { "env": "DATABASE_URL", "value": "postgres://localhost" },
+				"pool_size": { "env": "POOL_SIZE", "value": 10 },
+				"replicas": [{ "url": { "env": "EMPTY", "value": "postgres://replica" } }]
+			},
+			"debug": { "env": "DEBUG" },
+			"labels": { "value": "foo" }
+		}`)), Parser(json.Parser(), getFunc)))
+
+		assertDeepEqual(t, map[string]any{
+			"database.url":       "postgres://db",
+			"database.pool_size": float64(10),
+			"database.replicas": []any{
+				map[string]any{"url": "postgres://replica"},
+			},
+			"labels": "foo",
+		}, k.All())
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		parser := Parser(yaml.Parser(), getFunc)
+
+		result, err := parser.Unmarshal([]byte(`
+database:
+  url:
+    env: DATABASE_URL
+  port:
+    value: 5432
+`))
+		assertNilError(t, err)
+		assertDeepEqual(t, map[string]any{
+			"database": map[string]any{
+				"url":  "postgres://db",
+				"port": 5432,
+			},
+		}, result)
+
+		bytes, err := parser.Marshal(result)
+		assertNilError(t, err)
+		assertDeepEqual(t, "database:\n    port: 5432\n    url: postgres://db\n", string(bytes))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Parser(json.Parser(), nil).Unmarshal([]byte(`{`))
+		assertErrorContains(t, err, "unexpected end of JSON input")
+
+		_, err = Parser(json.Parser(), func(string) (string, error) {
+			return "", errors.New("mock error")
+		}).Unmarshal([]byte(`{"foo": [{"env": "FOO"}]}`))
+		assertErrorContains(t, err, "mock error")
+
+		result, err := Parser(json.Parser(), func(string) (string, error) {
+			return "", goenvconf.ErrEnvironmentVariableValueRequired
+		}).Unmarshal([]byte(`{"foo": {"env": "FOO", "value": true}}`))
+		assertNilError(t, err)
+		assertDeepEqual(t, map[string]any{"foo": true}, result)
+	})
+}
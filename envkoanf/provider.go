@@ -0,0 +1,151 @@
+// Package envkoanf integrates goenvconf types with [koanf].
+//
+// [koanf]: https://github.com/knadh/koanf
+package envkoanf
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/hasura/goenvconf"
+)
+
+// ErrReadBytesNotSupported occurs when calling the ReadBytes method of the provider.
+var ErrReadBytesNotSupported = errors.New("envkoanf provider does not support this method")
+
+// EnvProvider implements the koanf.Provider interface that reads a config struct
+// and exposes resolved values of Env fields.
+type EnvProvider struct {
+	config  any
+	tag     string
+	getFunc goenvconf.GetEnvFunc
+}
+
+// Provider returns a provider that takes a config struct and a struct tag to read key names.
+// Env fields are resolved by the getFunc, or the OS environment if getFunc is nil.
+// Env fields that resolve to neither a variable value nor a literal value are omitted,
+// so they don't override values from other providers.
+func Provider(config any, tag string, getFunc goenvconf.GetEnvFunc) *EnvProvider {
+	if getFunc == nil {
+		getFunc = goenvconf.GetOSEnv
+	}
+
+	return &EnvProvider{
+		config:  config,
+		tag:     tag,
+		getFunc: getFunc,
+	}
+}
+
+// ReadBytes is not supported by the provider.
+func (ep *EnvProvider) ReadBytes() ([]byte, error) {
+	return nil, ErrReadBytesNotSupported
+}
+
+// Read returns the config struct as a nested map with resolved values of Env fields.
+func (ep *EnvProvider) Read() (map[string]any, error) {
+	value := reflect.ValueOf(ep.config)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return map[string]any{}, nil
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return map[string]any{}, nil
+	}
+
+	return ep.readStruct(value)
+}
+
+func (ep *EnvProvider) readStruct(structValue reflect.Value) (map[string]any, error) {
+	result := map[string]any{}
+	structType := structValue.Type()
+
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := ep.fieldKeyName(field)
+		if name == "-" {
+			continue
+		}
+
+		fieldValue := structValue.Field(i)
+		for fieldValue.Kind() == reflect.Pointer && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+
+		if fieldValue.Kind() == reflect.Pointer {
+			continue
+		}
+
+		value, ok, err := ep.readValue(fieldValue)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			result[name] = value
+		}
+	}
+
+	return result, nil
+}
+
+func (ep *EnvProvider) readValue(value reflect.Value) (any, bool, error) {
+	result, isEnv, err := resolveEnvValue(value.Interface(), ep.getFunc)
+	if isEnv {
+		if err != nil {
+			if errors.Is(err, goenvconf.ErrEnvironmentValueRequired) ||
+				errors.Is(err, goenvconf.ErrEnvironmentVariableValueRequired) {
+				return nil, false, nil
+			}
+
+			return nil, false, err
+		}
+
+		return result, result != nil, nil
+	}
+
+	if value.Kind() == reflect.Struct {
+		nested, err := ep.readStruct(value)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return nested, true, nil
+	}
+
+	return value.Interface(), true, nil
+}
+
+func (ep *EnvProvider) fieldKeyName(field reflect.StructField) string {
+	tagName, _, _ := strings.Cut(field.Tag.Get(ep.tag), ",")
+	if tagName != "" {
+		return tagName
+	}
+
+	return field.Name
+}
+
+// resolveEnvValue resolves the value if it implements [goenvconf.EnvValue].
+// The second result is false if the value is not an Env type. Nil maps are returned as nil.
+func resolveEnvValue(value any, getFunc goenvconf.GetEnvFunc) (any, bool, error) {
+	ev, ok := value.(goenvconf.EnvValue)
+	if !ok {
+		return nil, false, nil
+	}
+
+	result, err := ev.Resolve(getFunc)
+	if reflectValue := reflect.ValueOf(result); reflectValue.Kind() == reflect.Map && reflectValue.IsNil() {
+		result = nil
+	}
+
+	return result, true, err
+}
@@ -0,0 +1,128 @@
+package envkoanf
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+	"github.com/hasura/goenvconf/envconftest"
+	"github.com/knadh/koanf/v2"
+)
+
+type databaseConfig struct {
+	URL      goenvconf.EnvString `koanf:"url"`
+	PoolSize goenvconf.EnvInt    `koanf:"pool_size"`
+}
+
+type testConfig struct {
+	Name     string                    `koanf:"name"`
+	Database databaseConfig            `koanf:"database"`
+	Origins  *goenvconf.EnvStringSlice `koanf:"origins"`
+	Labels   goenvconf.EnvMapString    `koanf:"labels"`
+	Debug    goenvconf.EnvBool         `koanf:"debug"`
+	Missing  *goenvconf.EnvString      `koanf:"missing"`
+	Ignored  goenvconf.EnvString       `koanf:"-"`
+}
+
+func TestProvider(t *testing.T) {
+	config := testConfig{
+		Name: "app",
+		Database: databaseConfig{
+			URL:      goenvconf.NewEnvStringVariable("DATABASE_URL"),
+			PoolSize: goenvconf.NewEnvInt("DATABASE_POOL_SIZE", 10),
+		},
+		Origins: toPtr(goenvconf.NewEnvStringSliceVariable("ORIGINS")),
+		Labels:  goenvconf.NewEnvMapStringVariable("LABELS"),
+		Debug:   goenvconf.NewEnvBoolVariable("DEBUG"),
+		Ignored: goenvconf.NewEnvStringValue("ignored"),
+	}
+
+	getFunc := envconftest.NewGetEnvFunc(map[string]string{
+		"DATABASE_URL": "postgres://localhost",
+		"ORIGINS":      "a,b",
+	})
+
+	k := koanf.New(".")
+	assertNilError(t, k.Load(Provider(&config, "koanf", getFunc), nil))
+	assertDeepEqual(t, map[string]any{
+		"name":               "app",
+		"database.url":       "postgres://localhost",
+		"database.pool_size": int64(10),
+		"origins":            []string{"a", "b"},
+	}, k.All())
+
+	var result struct {
+		Name     string `koanf:"name"`
+		Database struct {
+			URL      string `koanf:"url"`
+			PoolSize int    `koanf:"pool_size"`
+		} `koanf:"database"`
+	}
+
+	assertNilError(t, k.Unmarshal("", &result))
+	assertDeepEqual(t, "postgres://localhost", result.Database.URL)
+	assertDeepEqual(t, 10, result.Database.PoolSize)
+
+	t.Run("parse_error", func(t *testing.T) {
+		_, err := Provider(config, "koanf", envconftest.NewGetEnvFunc(map[string]string{
+			"DATABASE_POOL_SIZE": "ten",
+		})).Read()
+		assertErrorContains(t, err, "invalid syntax")
+	})
+
+	t.Run("getter_error", func(t *testing.T) {
+		_, err := Provider(config, "koanf", func(string) (string, error) {
+			return "", errors.New("mock error")
+		}).Read()
+		assertErrorContains(t, err, "mock error")
+	})
+
+	t.Run("read_bytes", func(t *testing.T) {
+		_, err := Provider(config, "koanf", nil).ReadBytes()
+		assertDeepEqual(t, ErrReadBytesNotSupported, err)
+	})
+
+	t.Run("non_struct", func(t *testing.T) {
+		result, err := Provider((*testConfig)(nil), "koanf", nil).Read()
+		assertNilError(t, err)
+		assertDeepEqual(t, map[string]any{}, result)
+	})
+}
+
+func assertNilError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Errorf("expected nil error, got: %s", err)
+		t.FailNow()
+	}
+}
+
+func assertErrorContains(t *testing.T, err error, msg string) {
+	t.Helper()
+
+	if err == nil {
+		t.Errorf("expected error with content: `%s`, got: nil", msg)
+		t.FailNow()
+	}
+
+	if !strings.Contains(err.Error(), msg) {
+		t.Errorf("expected error with content: %s, got: %s", msg, err)
+		t.FailNow()
+	}
+}
+
+func assertDeepEqual(t *testing.T, expected, reality any) {
+	t.Helper()
+
+	if !reflect.DeepEqual(expected, reality) {
+		t.Errorf("%v != %v", expected, reality)
+		t.FailNow()
+	}
+}
+
+func toPtr[T any](input T) *T {
+	return &input
+}
@@ -0,0 +1,20 @@
+module github.com/hasura/goenvconf/envpflag
+
+go 1.24
+
+require (
+	github.com/hasura/goenvconf v0.0.0-00010101000000-000000000000
+	github.com/spf13/pflag v1.0.10
+)
+
+require (
+	github.com/bahlo/generic-list-go v0.2.0 // indirect
+	github.com/buger/jsonparser v1.1.1 // indirect
+	github.com/invopop/jsonschema v0.13.0 // indirect
+	github.com/mailru/easyjson v0.7.7 // indirect
+	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
+	go.mongodb.org/mongo-driver/v2 v2.5.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/hasura/goenvconf => ../
@@ -0,0 +1,185 @@
+// Package envpflag registers [pflag] flags for goenvconf types, which can also be used with [cobra] commands.
+//
+// [pflag]: https://github.com/spf13/pflag
+// [cobra]: https://github.com/spf13/cobra
+package envpflag
+
+import (
+	"errors"
+	"flag"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/hasura/goenvconf"
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagTag  = "flag"
+	usageTag = "usage"
+)
+
+// ErrInvalidConfig occurs when the config is not a non-nil pointer to a struct.
+var ErrInvalidConfig = errors.New("config must be a non-nil pointer to a struct")
+
+// envValueType is the interface of Env types, which are registered as flags.
+var envValueType = reflect.TypeFor[goenvconf.EnvValue]()
+
+// envValue wraps the flag.Value implementation of an Env field to implement [pflag.Value].
+type envValue struct {
+	flag.Value
+
+	typeName string
+}
+
+// String returns the flag argument form of the Env instance, which is printed as the default value.
+func (ev envValue) String() string {
+	if value, ok := ev.Value.(interface{ FlagString() string }); ok {
+		return value.FlagString()
+	}
+
+	return ev.Value.String()
+}
+
+// Type returns the type name of the flag.
+func (ev envValue) Type() string {
+	return ev.typeName
+}
+
+// Register walks the config struct and registers a flag for each Env field to the flag set.
+//
+// The flag name is the kebab-case field path, e.g. --server-port for the Server.Port field,
+// and can be overridden by the flag tag. A flag:"-" tag skips the field. The usage tag sets the usage message.
+// The default value of the flag is the current value of the field. Setting the flag with a literal value
+// takes precedence over the environment variable, and an env:<VAR_NAME> argument replaces the variable name.
+// Nil pointer fields are skipped.
+func Register(flags *pflag.FlagSet, config any) error {
+	value := reflect.ValueOf(config)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return ErrInvalidConfig
+	}
+
+	registerStruct(flags, value.Elem(), nil)
+
+	return nil
+}
+
+func registerStruct(flags *pflag.FlagSet, value reflect.Value, path []string) {
+	valueType := value.Type()
+
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := field.Tag.Lookup(flagTag)
+		if name == "-" {
+			continue
+		}
+
+		if !ok || name == "" {
+			name = toKebabCase(field.Name)
+		}
+
+		fieldValue := value.Field(i)
+
+		if fieldValue.Kind() == reflect.Pointer {
+			if fieldValue.IsNil() {
+				continue
+			}
+
+			fieldValue = fieldValue.Elem()
+		}
+
+		fieldPath := path
+		if !field.Anonymous || ok {
+			fieldPath = append(append([]string{}, path...), name)
+		}
+
+		if !fieldValue.Type().Implements(envValueType) {
+			if fieldValue.Kind() == reflect.Struct {
+				registerStruct(flags, fieldValue, fieldPath)
+			}
+
+			continue
+		}
+
+		flagValue, isFlag := fieldValue.Addr().Interface().(flag.Value)
+		if !isFlag {
+			continue
+		}
+
+		typeName := flagTypeName(fieldValue.Type())
+		result := flags.VarPF(
+			envValue{Value: flagValue, typeName: typeName},
+			strings.Join(fieldPath, "-"),
+			"",
+			field.Tag.Get(usageTag),
+		)
+
+		if typeName == "bool" {
+			result.NoOptDefVal = "true"
+		}
+	}
+}
+
+// flagTypeName returns the pflag type name of the Env type by the type of its literal value,
+// e.g. ints for EnvIntSlice and stringToString for EnvMapString.
+func flagTypeName(envType reflect.Type) string {
+	field, ok := envType.FieldByName("Value")
+	if !ok {
+		return "json"
+	}
+
+	valueType := field.Type
+	if valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	switch valueType.Kind() {
+	case reflect.Slice:
+		return scalarTypeName(valueType.Elem()) + "s"
+	case reflect.Map:
+		name := scalarTypeName(valueType.Elem())
+
+		return "stringTo" + strings.ToUpper(name[:1]) + name[1:]
+	default:
+		return scalarTypeName(valueType)
+	}
+}
+
+// scalarTypeName returns the pflag type name of the scalar type, or json for other types.
+func scalarTypeName(valueType reflect.Type) string {
+	switch valueType.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	default:
+		return "json"
+	}
+}
+
+// toKebabCase converts a Go field name to kebab-case, e.g. HTTPPort to http-port.
+func toKebabCase(name string) string {
+	runes := []rune(name)
+
+	var sb strings.Builder
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			sb.WriteRune('-')
+		}
+
+		sb.WriteRune(unicode.ToLower(r))
+	}
+
+	return sb.String()
+}
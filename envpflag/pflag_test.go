@@ -0,0 +1,160 @@
+package envpflag
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+	"github.com/spf13/pflag"
+)
+
+type serverConfig struct {
+	Host     goenvconf.EnvString `usage:"the server host"`
+	HTTPPort goenvconf.EnvInt    `flag:"port"`
+	Origins  goenvconf.EnvStringSlice
+	Headers  goenvconf.EnvMapString
+}
+
+type testConfig struct {
+	Server  serverConfig
+	Debug   *goenvconf.EnvBool
+	Extra   *goenvconf.EnvAny
+	Ignored goenvconf.EnvString `flag:"-"`
+	Name    string
+}
+
+func newTestConfig() *testConfig {
+	return &testConfig{
+		Server: serverConfig{
+			Host:     goenvconf.NewEnvString("SERVER_HOST", "localhost"),
+			HTTPPort: goenvconf.NewEnvIntVariable("SERVER_PORT"),
+		},
+		Debug: &goenvconf.EnvBool{},
+	}
+}
+
+func TestRegister(t *testing.T) {
+	cfg := newTestConfig()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	assertNilError(t, Register(flags, cfg))
+
+	hostFlag := flags.Lookup("server-host")
+	assertDeepEqual(t, "localhost", hostFlag.DefValue)
+	assertDeepEqual(t, "the server host", hostFlag.Usage)
+	assertDeepEqual(t, "string", hostFlag.Value.Type())
+	assertDeepEqual(t, "env:SERVER_PORT", flags.Lookup("server-port").DefValue)
+	assertDeepEqual(t, true, flags.Lookup("server-origins") != nil)
+	assertDeepEqual(t, true, flags.Lookup("debug") != nil)
+	assertDeepEqual(t, true, flags.Lookup("extra") == nil)
+	assertDeepEqual(t, true, flags.Lookup("ignored") == nil)
+	assertDeepEqual(t, true, flags.Lookup("name") == nil)
+
+	assertNilError(t, flags.Parse([]string{
+		"--server-port", "9090",
+		"--server-origins", "env:ORIGINS",
+		"--server-headers", "a=1;b=2",
+		"--debug",
+	}))
+
+	assertDeepEqual(t, &testConfig{
+		Server: serverConfig{
+			Host:     goenvconf.NewEnvString("SERVER_HOST", "localhost"),
+			HTTPPort: goenvconf.NewEnvIntValue(9090),
+			Origins:  goenvconf.NewEnvStringSliceVariable("ORIGINS"),
+			Headers:  goenvconf.NewEnvMapStringValue(map[string]string{"a": "1", "b": "2"}),
+		},
+		Debug: toPtr(goenvconf.NewEnvBoolValue(true)),
+	}, cfg)
+
+	port, err := cfg.Server.HTTPPort.GetCustom(func(string) (string, error) {
+		return "8080", nil
+	})
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(9090), port)
+}
+
+func TestRegister_Errors(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+
+	assertErrorContains(t, Register(flags, testConfig{}), ErrInvalidConfig.Error())
+	assertErrorContains(t, Register(flags, (*testConfig)(nil)), ErrInvalidConfig.Error())
+
+	assertNilError(t, Register(flags, newTestConfig()))
+	assertErrorContains(t, flags.Parse([]string{"--server-port", "foo"}), "invalid integer value")
+}
+
+func TestFlagTypeName(t *testing.T) {
+	testCases := map[reflect.Type]string{
+		reflect.TypeFor[goenvconf.EnvString]():      "string",
+		reflect.TypeFor[goenvconf.EnvInt]():         "int",
+		reflect.TypeFor[goenvconf.EnvBool]():        "bool",
+		reflect.TypeFor[goenvconf.EnvAny]():         "json",
+		reflect.TypeFor[goenvconf.EnvFloatSlice]():  "floats",
+		reflect.TypeFor[goenvconf.EnvMapString]():   "stringToString",
+		reflect.TypeFor[goenvconf.EnvMapBool]():     "stringToBool",
+		reflect.TypeFor[goenvconf.EnvStringSlice](): "strings",
+	}
+
+	for input, expected := range testCases {
+		t.Run(input.Name(), func(t *testing.T) {
+			assertDeepEqual(t, expected, flagTypeName(input))
+		})
+	}
+}
+
+func TestToKebabCase(t *testing.T) {
+	testCases := map[string]string{
+		"Port":       "port",
+		"HTTPPort":   "http-port",
+		"ServerHost": "server-host",
+		"APIKey":     "api-key",
+		"ID":         "id",
+	}
+
+	for input, expected := range testCases {
+		t.Run(input, func(t *testing.T) {
+			assertDeepEqual(t, expected, toKebabCase(input))
+		})
+	}
+}
+
+func toPtr[T any](value T) *T {
+	return &value
+}
+
+func assertNilError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Errorf("expected nil error, got: %s", err)
+		t.FailNow()
+	}
+}
+
+func assertErrorContains(t *testing.T, err error, msg string) {
+	t.Helper()
+
+	if err == nil {
+		t.Errorf("expected error with content: `%s`, got: nil", msg)
+		t.FailNow()
+	}
+
+	if !strings.Contains(err.Error(), msg) {
+		t.Errorf("expected error with content: %s, got: %s", msg, err)
+		t.FailNow()
+	}
+}
+
+func assertDeepEqual(t *testing.T, expected, reality any) {
+	t.Helper()
+
+	if !reflect.DeepEqual(expected, reality) {
+		t.Errorf("%v != %v", expected, reality)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,308 @@
+package goenvconf
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// EnvValue is the common interface of all Env types, so generic tooling such as binders,
+// documentation generators and validators can handle them uniformly.
+type EnvValue interface {
+	// IsZero checks if the instance is empty.
+	IsZero() bool
+	// Validate checks the structural correctness of the instance without touching the environment.
+	Validate() error
+	// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+	Variables() []string
+	// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+	Resolve(getFunc GetEnvFunc) (any, error)
+	// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions,
+	// e.g. int64 to int or []int64 to []int32.
+	ResolveInto(target any, getFunc GetEnvFunc) error
+}
+
+var envValueType = reflect.TypeFor[EnvValue]()
+
+// variableNames returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func variableNames(variable *string, options *envOptions) []string {
+	var results []string
+
+	if variable != nil && *variable != "" {
+		results = append(results, *variable)
+
+		results = append(results, options.alternateNames()...)
+	}
+
+	if options != nil {
+		results = append(results, options.orElseVariables...)
+	}
+
+	return results
+}
+
+// resolveInto resolves the Env value and assigns it to the target pointer.
+func resolveInto(ev EnvValue, target any, getFunc GetEnvFunc) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Pointer || targetValue.IsNil() {
+		return fmt.Errorf("%w, got %T", ErrInvalidBindTarget, target)
+	}
+
+	result, err := ev.Resolve(getFunc)
+	if err != nil {
+		return err
+	}
+
+	return assignValue(targetValue.Elem(), reflect.ValueOf(result))
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+// Returns [ErrEnvironmentValueRequired] if the instance is empty.
+func (ev EnvAny) Resolve(getFunc GetEnvFunc) (any, error) {
+	if ev.IsZero() {
+		return nil, ErrEnvironmentValueRequired
+	}
+
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvString) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvString) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvString) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvInt) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvInt) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvInt) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvBool) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvBool) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvBool) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvFloat) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvFloat) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvFloat) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvAny) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvAny) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvStringSlice) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvStringSlice) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvStringSlice) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvIntSlice) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvIntSlice) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvIntSlice) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvFloatSlice) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvFloatSlice) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvFloatSlice) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvBoolSlice) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvBoolSlice) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvBoolSlice) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvMapString) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvMapString) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvMapString) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvMapInt) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvMapInt) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvMapInt) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvMapFloat) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvMapFloat) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvMapFloat) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
+
+// Variables returns the variable name, candidate variables, deprecated aliases, default variables and variable names of alternatives.
+func (ev EnvMapBool) Variables() []string {
+	return variableNames(ev.Variable, ev.options)
+}
+
+// Resolve resolves the value by the getter, which defaults to [GetOSEnv] if nil.
+func (ev EnvMapBool) Resolve(getFunc GetEnvFunc) (any, error) {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return ev.GetCustom(getFunc)
+}
+
+// ResolveInto resolves the value and assigns it to the target pointer with compatible type conversions.
+func (ev EnvMapBool) ResolveInto(target any, getFunc GetEnvFunc) error {
+	return resolveInto(ev, target, getFunc)
+}
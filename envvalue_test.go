@@ -0,0 +1,95 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEnvValue(t *testing.T) {
+	getFunc := newBinderGetter(map[string]string{
+		"PORT":    "8080",
+		"ORIGINS": "a,b",
+		"LIMITS":  "a=1;b=2",
+	})
+
+	values := []EnvValue{
+		NewEnvStringValue("foo"),
+		NewEnvIntVariable("PORT"),
+		NewEnvBoolValue(true),
+		NewEnvFloatValue(0.5),
+		NewEnvAnyValue(map[string]any{"foo": "bar"}),
+		NewEnvStringSliceVariable("ORIGINS"),
+		NewEnvIntSliceValue([]int64{1}),
+		NewEnvFloatSliceValue([]float64{1.5}),
+		NewEnvBoolSliceValue([]bool{true}),
+		NewEnvMapStringValue(map[string]string{"a": "b"}),
+		NewEnvMapIntVariable("LIMITS"),
+		NewEnvMapFloatValue(map[string]float64{"a": 1.5}),
+		NewEnvMapBoolValue(map[string]bool{"a": true}),
+	}
+
+	expected := []any{
+		"foo",
+		int64(8080),
+		true,
+		0.5,
+		map[string]any{"foo": "bar"},
+		[]string{"a", "b"},
+		[]int64{1},
+		[]float64{1.5},
+		[]bool{true},
+		map[string]string{"a": "b"},
+		map[string]int64{"a": 1, "b": 2},
+		map[string]float64{"a": 1.5},
+		map[string]bool{"a": true},
+	}
+
+	for i, value := range values {
+		assertDeepEqual(t, false, value.IsZero())
+		assertNilError(t, value.Validate())
+
+		result, err := value.Resolve(getFunc)
+		assertNilError(t, err)
+		assertDeepEqual(t, expected[i], result)
+	}
+}
+
+func TestEnvValue_Variables(t *testing.T) {
+	assertDeepEqual(t, []string(nil), NewEnvStringValue("foo").Variables())
+	assertDeepEqual(t, []string{"PORT"}, NewEnvIntVariable("PORT").Variables())
+	assertDeepEqual(
+		t,
+		[]string{"NEW_HOST", "OLD_HOST"},
+		NewEnvStringVariable("NEW_HOST").WithDeprecatedAlias("OLD_HOST").Variables(),
+	)
+}
+
+func TestEnvValue_ResolveInto(t *testing.T) {
+	getFunc := newBinderGetter(map[string]string{"PORT": "8080", "IDS": "1,2"})
+
+	var port int32
+
+	assertNilError(t, NewEnvIntVariable("PORT").ResolveInto(&port, getFunc))
+	assertDeepEqual(t, int32(8080), port)
+
+	var ids []int32
+
+	assertNilError(t, NewEnvIntSliceVariable("IDS").ResolveInto(&ids, getFunc))
+	assertDeepEqual(t, []int32{1, 2}, ids)
+
+	var timeout *float32
+
+	assertNilError(t, NewEnvFloatValue(1.5).ResolveInto(&timeout, nil))
+	assertDeepEqual(t, toPtr(float32(1.5)), timeout)
+
+	var name string
+
+	assertErrorContains(t, NewEnvIntVariable("PORT").ResolveInto(&name, getFunc), "cannot assign int64 to string")
+	assertErrorContains(t, NewEnvIntVariable("PORT").ResolveInto(name, getFunc), ErrInvalidBindTarget.Error())
+	assertErrorContains(t, NewEnvIntVariable("MISSING").ResolveInto(&port, getFunc), "MISSING")
+
+	var extra any
+
+	err := EnvAny{}.ResolveInto(&extra, getFunc)
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentValueRequired))
+}
@@ -0,0 +1,172 @@
+// Package envviper integrates goenvconf types with [viper].
+//
+// [viper]: https://github.com/spf13/viper
+package envviper
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/hasura/goenvconf"
+	"github.com/spf13/viper"
+)
+
+const keyDelimiter = "."
+
+// envValueType is the interface of Env types, whose fields are leaf keys.
+var envValueType = reflect.TypeFor[goenvconf.EnvValue]()
+
+// DecodeHook returns a decoder config option that registers [goenvconf.DecodeHook]
+// along with the default decode hooks of viper and the optional extra hooks.
+func DecodeHook(hooks ...mapstructure.DecodeHookFunc) viper.DecoderConfigOption {
+	allHooks := append([]mapstructure.DecodeHookFunc{
+		goenvconf.DecodeHook,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	}, hooks...)
+
+	return viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(allHooks...))
+}
+
+// Unmarshal unmarshals the config of viper into a struct containing Env fields.
+//
+// Every Env field is also looked up as a whole key, so the environment variable handling of viper
+// (AutomaticEnv, BindEnv, SetEnvPrefix and SetEnvKeyReplacer) and bound flags can override it, e.g.
+// SERVER_PORT=8080 overrides the server.port field with a literal value. The key delimiter must be the default dot.
+func Unmarshal(v *viper.Viper, rawVal any, opts ...viper.DecoderConfigOption) error {
+	return decode(v, "", v.AllSettings(), rawVal, opts...)
+}
+
+// UnmarshalKey takes a single key of the viper config and unmarshals it into a struct containing Env fields.
+func UnmarshalKey(v *viper.Viper, key string, rawVal any, opts ...viper.DecoderConfigOption) error {
+	settings, ok := v.Get(key).(map[string]any)
+	if !ok {
+		return decodeValue(v.Get(key), rawVal, opts...)
+	}
+
+	return decode(v, key, settings, rawVal, opts...)
+}
+
+func decode(
+	v *viper.Viper,
+	prefix string,
+	settings map[string]any,
+	rawVal any,
+	opts ...viper.DecoderConfigOption,
+) error {
+	for _, key := range envFieldKeys(reflect.TypeOf(rawVal), nil, map[reflect.Type]bool{}) {
+		fullKey := strings.Join(key, keyDelimiter)
+		if prefix != "" {
+			fullKey = prefix + keyDelimiter + fullKey
+		}
+
+		if value := v.Get(fullKey); value != nil {
+			setNestedValue(settings, key, value)
+		}
+	}
+
+	return decodeValue(settings, rawVal, opts...)
+}
+
+func decodeValue(input any, rawVal any, opts ...viper.DecoderConfigOption) error {
+	config := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+	}
+
+	DecodeHook()(config)
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	config.Result = rawVal
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(input)
+}
+
+// envFieldKeys returns key paths of all Env fields in the struct type.
+// Struct types which are being visited are skipped, so recursive types such as linked lists terminate.
+func envFieldKeys(structType reflect.Type, parent []string, visiting map[reflect.Type]bool) [][]string {
+	for structType != nil && structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	if structType == nil || structType.Kind() != reflect.Struct || visiting[structType] {
+		return nil
+	}
+
+	visiting[structType] = true
+	defer delete(visiting, structType)
+
+	var results [][]string
+
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, squash := fieldKeyName(field)
+		if name == "-" {
+			continue
+		}
+
+		key := parent
+		if !squash {
+			key = append(append([]string{}, parent...), strings.ToLower(name))
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Implements(envValueType) {
+			results = append(results, key)
+
+			continue
+		}
+
+		results = append(results, envFieldKeys(fieldType, key, visiting)...)
+	}
+
+	return results
+}
+
+func fieldKeyName(field reflect.StructField) (string, bool) {
+	name := field.Name
+	tag := field.Tag.Get("mapstructure")
+
+	if tag == "" {
+		return name, false
+	}
+
+	tagName, options, _ := strings.Cut(tag, ",")
+	if tagName != "" {
+		name = tagName
+	}
+
+	return name, strings.Contains(options, "squash")
+}
+
+func setNestedValue(settings map[string]any, key []string, value any) {
+	current := settings
+
+	for _, segment := range key[:len(key)-1] {
+		next, ok := current[segment].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			current[segment] = next
+		}
+
+		current = next
+	}
+
+	current[key[len(key)-1]] = value
+}
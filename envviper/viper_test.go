@@ -0,0 +1,149 @@
+package envviper
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hasura/goenvconf"
+	"github.com/spf13/viper"
+)
+
+type serverConfig struct {
+	Host    goenvconf.EnvString      `mapstructure:"host"`
+	Port    goenvconf.EnvInt         `mapstructure:"port"`
+	Origins goenvconf.EnvStringSlice `mapstructure:"origins"`
+	Timeout time.Duration            `mapstructure:"timeout"`
+}
+
+type testConfig struct {
+	Server  serverConfig        `mapstructure:"server"`
+	Debug   *goenvconf.EnvBool  `mapstructure:"debug"`
+	Ignored goenvconf.EnvString `mapstructure:"-"`
+}
+
+const testConfigYAML = `
+server:
+  host:
+    env: SERVER_HOST_NAME
+    value: localhost
+  port: 8080
+  origins: "${ORIGINS}"
+  timeout: 5s
+`
+
+func newTestViper(t *testing.T) *viper.Viper {
+	t.Helper()
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+
+	if err := v.ReadConfig(strings.NewReader(testConfigYAML)); err != nil {
+		t.Fatal(err)
+	}
+
+	return v
+}
+
+func TestUnmarshal(t *testing.T) {
+	v := newTestViper(t)
+
+	var cfg testConfig
+
+	assertNilError(t, Unmarshal(v, &cfg))
+	assertDeepEqual(t, testConfig{
+		Server: serverConfig{
+			Host:    goenvconf.NewEnvString("SERVER_HOST_NAME", "localhost"),
+			Port:    goenvconf.NewEnvIntValue(8080),
+			Origins: goenvconf.NewEnvStringSliceVariable("ORIGINS"),
+			Timeout: 5 * time.Second,
+		},
+	}, cfg)
+}
+
+func TestUnmarshal_AutomaticEnv(t *testing.T) {
+	t.Setenv("APP_SERVER_PORT", "9090")
+	t.Setenv("APP_DEBUG", "${DEBUG}")
+
+	v := newTestViper(t)
+	v.SetEnvPrefix("app")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	var cfg testConfig
+
+	assertNilError(t, Unmarshal(v, &cfg))
+	assertDeepEqual(t, goenvconf.NewEnvIntValue(9090), cfg.Server.Port)
+	assertDeepEqual(t, goenvconf.NewEnvBoolVariable("DEBUG"), *cfg.Debug)
+	assertDeepEqual(t, goenvconf.NewEnvString("SERVER_HOST_NAME", "localhost"), cfg.Server.Host)
+}
+
+func TestUnmarshalKey(t *testing.T) {
+	t.Setenv("SERVER_PORT", "not_a_number")
+
+	v := newTestViper(t)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	var cfg serverConfig
+
+	err := UnmarshalKey(v, "server", &cfg)
+	assertErrorContains(t, err, "invalid integer value")
+
+	var host goenvconf.EnvString
+
+	assertNilError(t, UnmarshalKey(v, "server.host", &host))
+	assertDeepEqual(t, goenvconf.NewEnvString("SERVER_HOST_NAME", "localhost"), host)
+}
+
+type nodeConfig struct {
+	Name goenvconf.EnvString `mapstructure:"name"`
+	Next *nodeConfig         `mapstructure:"next"`
+}
+
+func TestUnmarshal_recursiveType(t *testing.T) {
+	t.Setenv("NAME", "root")
+
+	v := viper.New()
+	v.AutomaticEnv()
+
+	var cfg nodeConfig
+
+	assertNilError(t, Unmarshal(v, &cfg))
+	assertDeepEqual(t, goenvconf.NewEnvStringValue("root"), cfg.Name)
+	assertDeepEqual(t, (*nodeConfig)(nil), cfg.Next)
+	assertDeepEqual(t, [][]string{{"name"}}, envFieldKeys(reflect.TypeFor[nodeConfig](), nil, map[reflect.Type]bool{}))
+}
+
+func assertNilError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Errorf("expected nil error, got: %s", err)
+		t.FailNow()
+	}
+}
+
+func assertErrorContains(t *testing.T, err error, msg string) {
+	t.Helper()
+
+	if err == nil {
+		t.Errorf("expected error with content: `%s`, got: nil", msg)
+		t.FailNow()
+	}
+
+	if !strings.Contains(err.Error(), msg) {
+		t.Errorf("expected error with content: %s, got: %s", msg, err)
+		t.FailNow()
+	}
+}
+
+func assertDeepEqual(t *testing.T, expected, reality any) {
+	t.Helper()
+
+	if !reflect.DeepEqual(expected, reality) {
+		t.Errorf("%v != %v", expected, reality)
+		t.FailNow()
+	}
+}
@@ -14,16 +14,30 @@ var (
 		Code:   "EmptyVar",
 		Detail: "the environment variable value is empty",
 	}
+
+	// ErrGetEnvTimeout occurs when the getter does not return the value of the environment variable in time.
+	ErrGetEnvTimeout = ParseEnvError{
+		Code:   "GetEnvTimeout",
+		Detail: "timed out getting the environment variable",
+	}
+
+	// ErrCircuitOpen occurs when the circuit breaker rejects the call because the provider failed repeatedly.
+	ErrCircuitOpen = ParseEnvError{
+		Code:   "CircuitOpen",
+		Detail: "the circuit breaker of the provider is open",
+	}
 )
 
 const (
 	// ErrCodeParseEnvFailed is the error code when parsing environment variable failed.
 	ErrCodeParseEnvFailed = "ParseEnvFailed"
+	// ErrCodeValidationFailed is the error code when the resolved value violates a constraint.
+	ErrCodeValidationFailed = "ValidationFailed"
 )
 
 // ParseEnvError structures a detailed error for parsed env.
 type ParseEnvError struct {
-	Code   string `json:"code"           jsonschema:"enum=EmptyEnv,enum=EmptyVar,enum=ParseEnvFailed"`
+	Code   string `json:"code"           jsonschema:"enum=CircuitOpen,enum=EmptyEnv,enum=EmptyVar,enum=GetEnvTimeout,enum=ParseEnvFailed,enum=ValidationFailed"`
 	Detail string `json:"detail"`
 	Hint   string `json:"hint,omitempty"`
 }
@@ -37,6 +51,15 @@ func NewParseEnvFailedError(detail string, hint string) ParseEnvError {
 	}
 }
 
+// NewValidationFailedError creates a [ParseEnvError] for values which violate constraints.
+func NewValidationFailedError(detail string, hint string) ParseEnvError {
+	return ParseEnvError{
+		Code:   ErrCodeValidationFailed,
+		Detail: detail,
+		Hint:   hint,
+	}
+}
+
 // Error returns the error message.
 func (pee ParseEnvError) Error() string {
 	if pee.Hint != "" {
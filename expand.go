@@ -0,0 +1,248 @@
+package goenvconf
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// Literal values of EnvString, EnvStringSlice and EnvMapString can contain placeholders,
+// which are expanded by the active getter when the value is resolved, e.g. "https://${HOST}:${PORT}/api".
+// The expansion can be disabled per field with the WithoutExpansion method.
+
+// ExpandString replaces placeholders in the input string with values of the getter.
+// The getter defaults to [GetOSEnv] if nil. The placeholder syntax follows shell and docker-compose conventions:
+//   - ${VAR}: the value of VAR. Returns an error if VAR is unset.
+//   - ${VAR:-default}: the default value if VAR is unset or empty. ${VAR-default} only checks if VAR is unset.
+//   - ${VAR:?message}: returns an error with the message if VAR is unset or empty.
+//     ${VAR?message} only checks if VAR is unset.
+//
+// Default values can contain nested placeholders, e.g. ${PUBLIC_URL:-http://${HOST}}.
+// Use $$ to escape a literal dollar sign, e.g. "pa$$word" is resolved to "pa$word".
+// Other dollar signs which do not start a placeholder are kept as-is.
+func ExpandString(input string, getFunc GetEnvFunc) (string, error) {
+	if !strings.Contains(input, "$") {
+		return input, nil
+	}
+
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	var sb strings.Builder
+
+	remain := input
+
+	for {
+		index := strings.IndexByte(remain, '$')
+		if index < 0 {
+			sb.WriteString(remain)
+
+			break
+		}
+
+		sb.WriteString(remain[:index])
+		remain = remain[index:]
+
+		switch {
+		case strings.HasPrefix(remain, "$$"):
+			sb.WriteByte('$')
+
+			remain = remain[2:]
+		case strings.HasPrefix(remain, "${"):
+			end := findPlaceholderEnd(remain[2:])
+			if end < 0 {
+				return "", NewParseEnvFailedError("invalid interpolation syntax", input)
+			}
+
+			value, err := expandPlaceholder(remain[2:end+2], getFunc)
+			if err != nil {
+				return "", err
+			}
+
+			sb.WriteString(value)
+
+			remain = remain[end+3:]
+		default:
+			sb.WriteByte('$')
+
+			remain = remain[1:]
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// ExpandEnv replaces $VAR and ${VAR} placeholders in the input string with values of the getter,
+// following the semantics of [os.Expand] exactly. Unset variables and getter errors are replaced by empty strings.
+// It is a drop-in replacement of [os.ExpandEnv] if the getter is nil. Use [ExpandString] for the strict mode.
+func ExpandEnv(input string, getFunc GetEnvFunc) string {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return os.Expand(input, func(name string) string {
+		value, err := getFunc(name)
+		if err != nil {
+			return ""
+		}
+
+		return value
+	})
+}
+
+// findPlaceholderEnd returns the index of the closing brace of the placeholder, or -1 if not found.
+func findPlaceholderEnd(input string) int {
+	depth := 1
+
+	for i := 0; i < len(input); i++ {
+		switch {
+		case input[i] == '$' && i+1 < len(input) && input[i+1] == '$':
+			i++
+		case input[i] == '$' && i+1 < len(input) && input[i+1] == '{':
+			depth++
+			i++
+		case input[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// expandPlaceholder evaluates the expression inside a ${...} placeholder.
+func expandPlaceholder(expr string, getFunc GetEnvFunc) (string, error) { //nolint:cyclop
+	nameEnd := strings.IndexFunc(expr, func(r rune) bool {
+		return r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	})
+	if nameEnd < 0 {
+		nameEnd = len(expr)
+	}
+
+	name, operator := expr[:nameEnd], expr[nameEnd:]
+	if !isValidVariableName(name) {
+		return "", NewParseEnvFailedError("invalid interpolation syntax", "${"+expr+"}")
+	}
+
+	checkEmpty := strings.HasPrefix(operator, ":")
+	if checkEmpty {
+		operator = operator[1:]
+	}
+
+	if (checkEmpty && operator == "") || (operator != "" && operator[0] != '-' && operator[0] != '?') {
+		return "", NewParseEnvFailedError("invalid interpolation syntax", "${"+expr+"}")
+	}
+
+	value, err := getFunc(name)
+
+	isSet := err == nil
+	if err != nil && !errors.Is(err, ErrEnvironmentVariableValueRequired) {
+		return "", err
+	}
+
+	if isSet && (!checkEmpty || value != "") {
+		return value, nil
+	}
+
+	switch {
+	case operator == "":
+		return "", NewParseEnvFailedError("the interpolated environment variable is not set", name)
+	case operator[0] == '-':
+		return ExpandString(operator[1:], getFunc)
+	default:
+		message := operator[1:]
+		if message == "" {
+			message = "the interpolated environment variable is required"
+		}
+
+		return "", NewParseEnvFailedError(message, name)
+	}
+}
+
+// WithoutExpansion returns a copy of the instance with the placeholder expansion of the literal value disabled.
+func (ev EnvString) WithoutExpansion() EnvString {
+	ev.options = ev.options.clone()
+	ev.options.noExpansion = true
+
+	return ev
+}
+
+// WithoutExpansion returns a copy of the instance with the placeholder expansion of literal values disabled.
+func (ev EnvStringSlice) WithoutExpansion() EnvStringSlice {
+	ev.options = ev.options.clone()
+	ev.options.noExpansion = true
+
+	return ev
+}
+
+// WithoutExpansion returns a copy of the instance with the placeholder expansion of literal values disabled.
+func (ev EnvMapString) WithoutExpansion() EnvMapString {
+	ev.options = ev.options.clone()
+	ev.options.noExpansion = true
+
+	return ev
+}
+
+// expandStrings expands placeholders in every item of the slice. The input slice is returned as-is if no item changes.
+func expandStrings(values []string, getFunc GetEnvFunc) ([]string, error) {
+	var results []string
+
+	for i, value := range values {
+		expanded, err := ExpandString(value, getFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		if results == nil {
+			if expanded == value {
+				continue
+			}
+
+			results = make([]string, len(values))
+			copy(results, values[:i])
+		}
+
+		results[i] = expanded
+	}
+
+	if results == nil {
+		return values, nil
+	}
+
+	return results, nil
+}
+
+// expandStringMap expands placeholders in every value of the map. The input map is returned as-is if no value changes.
+func expandStringMap(values map[string]string, getFunc GetEnvFunc) (map[string]string, error) {
+	var results map[string]string
+
+	for key, value := range values {
+		expanded, err := ExpandString(value, getFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		if expanded == value {
+			continue
+		}
+
+		if results == nil {
+			results = make(map[string]string, len(values))
+
+			for k, v := range values {
+				results[k] = v
+			}
+		}
+
+		results[key] = expanded
+	}
+
+	if results == nil {
+		return values, nil
+	}
+
+	return results, nil
+}
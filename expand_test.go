@@ -0,0 +1,206 @@
+package goenvconf
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestExpandString(t *testing.T) {
+	getFunc := func(name string) (string, error) {
+		switch name {
+		case "HOST":
+			return "example.com", nil
+		case "PORT":
+			return "8080", nil
+		case "EMPTY":
+			return "", nil
+		case "BROKEN":
+			return "", errors.New("broken")
+		default:
+			return "", ErrEnvironmentVariableValueRequired
+		}
+	}
+
+	testCases := []struct {
+		Input    string
+		Expected string
+		ErrorMsg string
+	}{
+		{Input: "", Expected: ""},
+		{Input: "plain $text", Expected: "plain $text"},
+		{Input: "pa$$word", Expected: "pa$word"},
+		{Input: "$${HOST}", Expected: "${HOST}"},
+		{Input: "$$$${HOST}", Expected: "$${HOST}"},
+		{Input: "$$${HOST}", Expected: "$example.com"},
+		{Input: "cost: 5$", Expected: "cost: 5$"},
+		{Input: "${MISSING:-$$}", Expected: "$"},
+		{Input: "${MISSING:-$${HOST}}", Expected: "${HOST}"},
+		{Input: "${HOST}", Expected: "example.com"},
+		{Input: "https://${HOST}:${PORT}/api", Expected: "https://example.com:8080/api"},
+		{Input: "a${EMPTY}b", Expected: "ab"},
+		{Input: "${MISSING}", ErrorMsg: "the interpolated environment variable is not set. Hint: MISSING"},
+		{Input: "${BROKEN}", ErrorMsg: "broken"},
+		{Input: "${HOST", ErrorMsg: "invalid interpolation syntax. Hint: ${HOST"},
+		{Input: "${1HOST}", ErrorMsg: "invalid interpolation syntax"},
+		{Input: "${HOST:}", ErrorMsg: "invalid interpolation syntax. Hint: ${HOST:}"},
+		{Input: "${HOST+x}", ErrorMsg: "invalid interpolation syntax"},
+		{Input: "${HOST:-fallback}", Expected: "example.com"},
+		{Input: "${MISSING:-fallback}", Expected: "fallback"},
+		{Input: "${MISSING:-}", Expected: ""},
+		{Input: "${EMPTY:-fallback}", Expected: "fallback"},
+		{Input: "${EMPTY-fallback}", Expected: ""},
+		{Input: "${MISSING-fallback}", Expected: "fallback"},
+		{Input: "${MISSING:-http://${HOST}:${PORT}}/api", Expected: "http://example.com:8080/api"},
+		{Input: "${MISSING:-${OTHER:-a}}", Expected: "a"},
+		{Input: "${MISSING:-{\"a\":1}", Expected: "{\"a\":1"},
+		{Input: "${HOST:?host is required}", Expected: "example.com"},
+		{Input: "${MISSING:?host is required}", ErrorMsg: "host is required. Hint: MISSING"},
+		{Input: "${EMPTY:?}", ErrorMsg: "the interpolated environment variable is required. Hint: EMPTY"},
+		{Input: "${EMPTY?must be set}", Expected: ""},
+		{Input: "${MISSING?must be set}", ErrorMsg: "must be set. Hint: MISSING"},
+		{Input: "${MISSING:-${HOST}", ErrorMsg: "invalid interpolation syntax"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			result, err := ExpandString(tc.Input, getFunc)
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestInterpolation(t *testing.T) {
+	t.Setenv("TEST_INTERPOLATION_HOST", "example.com")
+
+	getFunc := func(name string) (string, error) {
+		if name == "HOST" {
+			return "custom.com", nil
+		}
+
+		return "", ErrEnvironmentVariableValueRequired
+	}
+
+	t.Run("string", func(t *testing.T) {
+		ev := NewEnvStringValue("https://${TEST_INTERPOLATION_HOST}/api")
+
+		result, err := ev.Get()
+		assertNilError(t, err)
+		assertDeepEqual(t, "https://example.com/api", result)
+
+		result, err = NewEnvString("HOST_URL", "https://${HOST}/api").GetCustom(getFunc)
+		assertNilError(t, err)
+		assertDeepEqual(t, "https://custom.com/api", result)
+
+		_, err = NewEnvStringValue("${TEST_INTERPOLATION_MISSING}").GetOrDefault("default")
+		assertErrorContains(t, err, "the interpolated environment variable is not set")
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		value := []string{"a", "${TEST_INTERPOLATION_HOST}"}
+
+		result, err := NewEnvStringSliceValue(value).Get()
+		assertNilError(t, err)
+		assertDeepEqual(t, []string{"a", "example.com"}, result)
+		assertDeepEqual(t, []string{"a", "${TEST_INTERPOLATION_HOST}"}, value)
+
+		result, err = NewEnvStringSliceValue([]string{"${HOST}"}).GetCustom(getFunc)
+		assertNilError(t, err)
+		assertDeepEqual(t, []string{"custom.com"}, result)
+
+		_, err = NewEnvStringSliceValue([]string{"${MISSING}"}).GetCustom(getFunc)
+		assertErrorContains(t, err, "the interpolated environment variable is not set")
+	})
+
+	t.Run("map", func(t *testing.T) {
+		value := map[string]string{"a": "1", "host": "${TEST_INTERPOLATION_HOST}"}
+
+		result, err := NewEnvMapStringValue(value).Get()
+		assertNilError(t, err)
+		assertDeepEqual(t, map[string]string{"a": "1", "host": "example.com"}, result)
+		assertDeepEqual(t, "${TEST_INTERPOLATION_HOST}", value["host"])
+
+		result, err = NewEnvMapStringValue(map[string]string{"host": "${HOST}"}).GetCustom(getFunc)
+		assertNilError(t, err)
+		assertDeepEqual(t, map[string]string{"host": "custom.com"}, result)
+
+		_, err = NewEnvMapStringValue(map[string]string{"host": "${MISSING}"}).GetCustom(getFunc)
+		assertErrorContains(t, err, "the interpolated environment variable is not set")
+	})
+}
+
+func TestWithoutExpansion(t *testing.T) {
+	t.Setenv("TEST_INTERPOLATION_HOST", "example.com")
+
+	ev := NewEnvStringValue("${TEST_INTERPOLATION_HOST}$$")
+
+	result, err := ev.WithoutExpansion().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "${TEST_INTERPOLATION_HOST}$$", result)
+	assertDeepEqual(t, false, ev.options.isExpansionDisabled())
+
+	result, err = ev.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "example.com$", result)
+
+	sliceResult, err := NewEnvStringSliceValue([]string{"${HOST}"}).WithoutExpansion().GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"${HOST}"}, sliceResult)
+
+	mapResult, err := NewEnvMapStringValue(map[string]string{"a": "${HOST}"}).WithoutExpansion().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]string{"a": "${HOST}"}, mapResult)
+
+	var decoded EnvString
+
+	decoded = decoded.WithoutExpansion()
+	assertNilError(t, decoded.UnmarshalJSON([]byte(`{"value": "${HOST}"}`)))
+
+	result, err = decoded.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "${HOST}", result)
+}
+
+func TestExpandString_DefaultGetter(t *testing.T) {
+	t.Setenv("TEST_EXPAND_HOST", "example.com")
+
+	result, err := ExpandString("https://${TEST_EXPAND_HOST}/api", nil)
+	assertNilError(t, err)
+	assertDeepEqual(t, "https://example.com/api", result)
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("TEST_EXPAND_HOST", "example.com")
+	t.Setenv("TEST_EXPAND_PORT", "8080")
+
+	testCases := []string{
+		"",
+		"plain text",
+		"$TEST_EXPAND_HOST:${TEST_EXPAND_PORT}",
+		"https://${TEST_EXPAND_HOST}/$TEST_EXPAND_MISSING/api",
+		"${TEST_EXPAND_HOST",
+		"cost: $$ 5$",
+		"${TEST_EXPAND_MISSING:-default}",
+	}
+
+	for _, input := range testCases {
+		t.Run(input, func(t *testing.T) {
+			assertDeepEqual(t, os.ExpandEnv(input), ExpandEnv(input, nil))
+		})
+	}
+
+	getFunc := func(name string) (string, error) {
+		if name == "HOST" {
+			return "custom.com", nil
+		}
+
+		return "unused", ErrEnvironmentVariableValueRequired
+	}
+
+	assertDeepEqual(t, "custom.com:", ExpandEnv("$HOST:${PORT}", getFunc))
+}
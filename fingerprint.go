@@ -0,0 +1,107 @@
+package goenvconf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"reflect"
+)
+
+// Fingerprint resolves the config struct with the getter and returns a stable SHA-256 hash in hex,
+// so deployments can detect whether the effective configuration actually changed.
+//
+// Env fields contribute their resolved values, and other fields contribute their JSON encodings.
+// Fields are hashed in declaration order with their paths, and map keys are sorted, so the result is deterministic.
+// Resolved values of secret fields are hashed individually, so they are never embedded in the hashed content.
+// Zero Env fields and nil pointers are hashed as null.
+// All resolution errors are returned together as [ConfigErrors]. The getter defaults to [GetOSEnv] if nil.
+func Fingerprint(config any, getFunc GetEnvFunc) (string, error) {
+	value := reflect.Indirect(reflect.ValueOf(config))
+	if value.Kind() != reflect.Struct {
+		return "", fmt.Errorf("%w, got %T", ErrInvalidBindTarget, config)
+	}
+
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	digest := sha256.New()
+
+	if errs := writeStructFingerprint(digest, value, "", getFunc); len(errs) > 0 {
+		return "", errs.toError()
+	}
+
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+func writeStructFingerprint(digest hash.Hash, value reflect.Value, path string, getFunc GetEnvFunc) ConfigErrors {
+	var errs ConfigErrors
+
+	valueType := value.Type()
+
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		fieldValue := reflect.Indirect(value.Field(i))
+
+		switch {
+		case !fieldValue.IsValid():
+			writeFieldFingerprint(digest, fieldPath, nil, false)
+		case isEnvType(fieldValue.Type()):
+			if err := writeEnvFingerprint(digest, fieldValue, fieldPath, getFunc); err != nil {
+				errs = append(errs, *err)
+			}
+		case fieldValue.Kind() == reflect.Struct && !fieldValue.Type().Implements(textMarshalerType) &&
+			!fieldValue.Type().Implements(jsonMarshalerType):
+			errs = append(errs, writeStructFingerprint(digest, fieldValue, fieldPath, getFunc)...)
+		default:
+			writeFieldFingerprint(digest, fieldPath, fieldValue.Interface(), false)
+		}
+	}
+
+	return errs
+}
+
+var jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+
+func writeEnvFingerprint(digest hash.Hash, field reflect.Value, path string, getFunc GetEnvFunc) *ConfigError {
+	ev, _ := field.Interface().(EnvValue)
+
+	result, err := ev.Resolve(getFunc)
+	if err != nil && !errors.Is(err, ErrEnvironmentValueRequired) {
+		return &ConfigError{Path: path, Variable: envVariableName(field), Err: err}
+	}
+
+	secret, ok := ev.(interface{ IsSecret() bool })
+
+	writeFieldFingerprint(digest, path, result, ok && secret.IsSecret())
+
+	return nil
+}
+
+// writeFieldFingerprint writes the path and the JSON encoding of the value to the digest.
+// The encoding of a secret value is replaced by its own hash.
+func writeFieldFingerprint(digest hash.Hash, path string, value any, isSecret bool) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded = []byte(fmt.Sprintf("%#v", value))
+	}
+
+	if isSecret {
+		secretDigest := sha256.Sum256(encoded)
+		encoded = []byte(hex.EncodeToString(secretDigest[:]))
+	}
+
+	_, _ = fmt.Fprintf(digest, "%q=%s\n", path, encoded)
+}
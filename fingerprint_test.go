@@ -0,0 +1,109 @@
+package goenvconf
+
+import (
+	"testing"
+	"time"
+)
+
+type fingerprintConfig struct {
+	Server   binderServerConfig
+	Debug    *EnvBool
+	APIKey   EnvString
+	Timeout  time.Duration
+	Started  time.Time
+	Optional EnvString
+	internal string
+}
+
+func TestFingerprint(t *testing.T) {
+	newConfig := func() fingerprintConfig {
+		return fingerprintConfig{
+			Server: binderServerConfig{
+				Host: NewEnvString("SERVER_HOST", "localhost"),
+				Port: NewEnvIntVariable("SERVER_PORT"),
+			},
+			APIKey:  NewEnvStringVariable("API_KEY").Secret(),
+			Timeout: time.Second,
+		}
+	}
+
+	env := map[string]string{"SERVER_PORT": "8080", "API_KEY": "s3cr3t"}
+
+	base, err := Fingerprint(newConfig(), newBinderGetter(env))
+	assertNilError(t, err)
+	assertDeepEqual(t, 64, len(base))
+
+	same, err := Fingerprint(toPtr(newConfig()), newBinderGetter(map[string]string{"SERVER_PORT": "8080", "API_KEY": "s3cr3t"}))
+	assertNilError(t, err)
+	assertDeepEqual(t, base, same)
+
+	config := newConfig()
+	config.Server.Port = NewEnvIntValue(8080)
+	config.internal = "ignored"
+
+	sameResolved, err := Fingerprint(config, newBinderGetter(env))
+	assertNilError(t, err)
+	assertDeepEqual(t, base, sameResolved)
+
+	testCases := []struct {
+		Name   string
+		Config func() fingerprintConfig
+		Env    map[string]string
+	}{
+		{
+			Name:   "port",
+			Config: newConfig,
+			Env:    map[string]string{"SERVER_PORT": "9090", "API_KEY": "s3cr3t"},
+		},
+		{
+			Name:   "secret",
+			Config: newConfig,
+			Env:    map[string]string{"SERVER_PORT": "8080", "API_KEY": "rotated"},
+		},
+		{
+			Name: "timeout",
+			Config: func() fingerprintConfig {
+				config := newConfig()
+				config.Timeout = time.Minute
+
+				return config
+			},
+			Env: env,
+		},
+		{
+			Name: "debug",
+			Config: func() fingerprintConfig {
+				config := newConfig()
+				config.Debug = toPtr(NewEnvBoolValue(false))
+
+				return config
+			},
+			Env: env,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := Fingerprint(tc.Config(), newBinderGetter(tc.Env))
+			assertNilError(t, err)
+			assertDeepEqual(t, false, result == base)
+		})
+	}
+}
+
+func TestFingerprint_Errors(t *testing.T) {
+	config := fingerprintConfig{
+		Server: binderServerConfig{
+			Host: NewEnvStringVariable("SERVER_HOST"),
+			Port: NewEnvIntVariable("SERVER_PORT"),
+		},
+	}
+
+	_, err := Fingerprint(config, newBinderGetter(map[string]string{"SERVER_PORT": "abc"}))
+	assertDeepEqual(t, `2 config errors:
+  - Server.Host: SERVER_HOST: EmptyVar: the environment variable value is empty
+  - Server.Port (SERVER_PORT): strconv.ParseInt: parsing "abc": invalid syntax`, err.Error())
+
+	_, err = Fingerprint("foo", nil)
+	assertErrorContains(t, err, ErrInvalidBindTarget.Error())
+}
@@ -0,0 +1,510 @@
+package goenvconf
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// The Set methods, together with the String methods, implement the [flag.Value] interface on pointers of Env types.
+// The FlagString methods return the flag argument form, which flag adapters print as default values.
+// Set accepts either a literal value, which overrides the value and clears the variable name,
+// or an env:<VAR_NAME> string, which sets the variable name and keeps the value as the fallback.
+// Slices use the comma-separated format, maps use the <key1>=<value1>;<key2>=<value2> format
+// and EnvAny accepts JSON or a plain string.
+
+const flagVariablePrefix = "env:"
+
+// formatFlagValue formats the flag value, which can be parsed by the Set method.
+func formatFlagValue(variable *string, hasValue bool, formatValue func() string) string {
+	if hasValue {
+		return formatValue()
+	}
+
+	if variable != nil && *variable != "" {
+		return flagVariablePrefix + *variable
+	}
+
+	return ""
+}
+
+// flagInput converts a flag argument to the raw object form.
+func flagInput(value string) (map[string]any, error) {
+	name, ok := strings.CutPrefix(value, flagVariablePrefix)
+	if !ok {
+		return map[string]any{envObjectValueKey: value}, nil
+	}
+
+	if !isValidVariableName(name) {
+		return nil, NewParseEnvFailedError("invalid environment variable name", value)
+	}
+
+	return map[string]any{envObjectVariableKey: name}, nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvString) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvString) formatLiteral() string {
+	return *ev.Value
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvString) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvString(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvInt) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvInt) formatLiteral() string {
+	return formatIntText(*ev.Value)
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvInt) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvInt(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvBool) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvBool) formatLiteral() string {
+	return strconv.FormatBool(*ev.Value)
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvBool) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvBool(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
+
+// IsBoolFlag allows the flag to be set without an explicit value, e.g. -debug.
+func (ev *EnvBool) IsBoolFlag() bool {
+	return true
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvFloat) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvFloat) formatLiteral() string {
+	return formatFloatText(*ev.Value)
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvFloat) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvFloat(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvAny) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvAny) formatLiteral() string {
+	if str, ok := ev.Value.(string); ok {
+		return str
+	}
+
+	result, _ := json.Marshal(ev.Value)
+
+	return string(result)
+}
+
+// Set implements the flag.Value interface. The literal value is decoded as JSON if valid, or a plain string otherwise.
+func (ev *EnvAny) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	if name, ok := input[envObjectVariableKey].(string); ok {
+		ev.Variable = &name
+
+		return nil
+	}
+
+	var result any
+
+	if err := json.Unmarshal([]byte(value), &result); err != nil {
+		result = value
+	}
+
+	ev.Value = result
+	ev.Variable = nil
+
+	return nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvStringSlice) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvStringSlice) formatLiteral() string {
+	return formatStringSliceText(ev.Value)
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvStringSlice) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvStringSlice(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvIntSlice) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvIntSlice) formatLiteral() string {
+	return formatSliceText(ev.Value, formatIntText)
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvIntSlice) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvIntSlice(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvFloatSlice) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvFloatSlice) formatLiteral() string {
+	return formatSliceText(ev.Value, formatFloatText)
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvFloatSlice) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvFloatSlice(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvBoolSlice) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvBoolSlice) formatLiteral() string {
+	return formatSliceText(ev.Value, strconv.FormatBool)
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvBoolSlice) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvBoolSlice(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvMapString) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvMapString) formatLiteral() string {
+	return formatMapText(ev.Value, func(s string) string { return s })
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvMapString) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvMapString(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvMapInt) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvMapInt) formatLiteral() string {
+	return formatMapText(ev.Value, formatIntText)
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvMapInt) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvMapInt(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvMapFloat) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvMapFloat) formatLiteral() string {
+	return formatMapText(ev.Value, formatFloatText)
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvMapFloat) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvMapFloat(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
+
+// FlagString returns the literal value, or env:<VAR_NAME> if only the variable is set,
+// which can be parsed by the Set method.
+func (ev EnvMapBool) FlagString() string {
+	return formatFlagValue(ev.Variable, ev.Value != nil, ev.formatLiteral)
+}
+
+// formatLiteral formats the literal value, which must be set.
+func (ev EnvMapBool) formatLiteral() string {
+	return formatMapText(ev.Value, strconv.FormatBool)
+}
+
+// Set implements the flag.Value interface.
+func (ev *EnvMapBool) Set(value string) error {
+	input, err := flagInput(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeEnvMapBool(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Variable != nil {
+		ev.Variable = result.Variable
+
+		return nil
+	}
+
+	ev.Value = result.Value
+	ev.Variable = nil
+
+	return nil
+}
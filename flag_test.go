@@ -0,0 +1,92 @@
+package goenvconf
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestFlagValue(t *testing.T) {
+	port := NewEnvInt("PORT", 8080)
+	debug := NewEnvBoolVariable("DEBUG")
+	extra := EnvAny{}
+	hosts := EnvStringSlice{}
+	headers := NewEnvMapStringValue(map[string]string{"a": "1"})
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.Var(&port, "port", "")
+	flags.Var(&debug, "debug", "")
+	flags.Var(&extra, "extra", "")
+	flags.Var(&hosts, "hosts", "")
+	flags.Var(&headers, "headers", "")
+
+	assertNilError(t, flags.Parse([]string{
+		"-port", "9090",
+		"-debug",
+		"-extra", `{"foo":"bar"}`,
+		"-hosts", "env:HOSTS",
+		"-headers", "env:HEADERS",
+	}))
+
+	assertDeepEqual(t, NewEnvIntValue(9090), port)
+	assertDeepEqual(t, NewEnvBoolValue(true), debug)
+	assertDeepEqual(t, NewEnvAnyValue(map[string]any{"foo": "bar"}), extra)
+	assertDeepEqual(t, NewEnvStringSliceVariable("HOSTS"), hosts)
+	assertDeepEqual(t, NewEnvMapString("HEADERS", map[string]string{"a": "1"}), headers)
+}
+
+func TestFlagValue_Set(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    string
+		Value    flag.Value
+		Expected any
+		ErrorMsg string
+	}{
+		{Name: "string", Input: "foo", Value: &EnvString{}, Expected: toPtr(NewEnvStringValue("foo"))},
+		{Name: "string_env", Input: "env:FOO", Value: &EnvString{}, Expected: toPtr(NewEnvStringVariable("FOO"))},
+		{Name: "float", Input: "0.5", Value: &EnvFloat{}, Expected: toPtr(NewEnvFloatValue(0.5))},
+		{Name: "any_string", Input: "foo", Value: &EnvAny{}, Expected: toPtr(NewEnvAnyValue("foo"))},
+		{Name: "int_slice", Input: "1,2", Value: &EnvIntSlice{}, Expected: toPtr(NewEnvIntSliceValue([]int64{1, 2}))},
+		{Name: "float_slice", Input: "1.5", Value: &EnvFloatSlice{}, Expected: toPtr(NewEnvFloatSliceValue([]float64{1.5}))},
+		{Name: "bool_slice", Input: "true", Value: &EnvBoolSlice{}, Expected: toPtr(NewEnvBoolSliceValue([]bool{true}))},
+		{Name: "map_int", Input: "a=1", Value: &EnvMapInt{}, Expected: toPtr(NewEnvMapIntValue(map[string]int64{"a": 1}))},
+		{Name: "map_float", Input: "a=1.5", Value: &EnvMapFloat{}, Expected: toPtr(NewEnvMapFloatValue(map[string]float64{"a": 1.5}))},
+		{Name: "map_bool", Input: "a=true", Value: &EnvMapBool{}, Expected: toPtr(NewEnvMapBoolValue(map[string]bool{"a": true}))},
+		{Name: "invalid_int", Input: "foo", Value: &EnvInt{}, ErrorMsg: "invalid integer value"},
+		{Name: "invalid_env", Input: "env:1FOO", Value: &EnvString{}, ErrorMsg: "invalid environment variable name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Value.Set(tc.Input)
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, tc.Value)
+			}
+		})
+	}
+}
+
+func TestFlagString(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    interface{ FlagString() string }
+		Expected string
+	}{
+		{Name: "zero", Input: &EnvString{}, Expected: ""},
+		{Name: "variable", Input: toPtr(NewEnvIntVariable("PORT")), Expected: "env:PORT"},
+		{Name: "value", Input: toPtr(NewEnvInt("PORT", 8080)), Expected: "8080"},
+		{Name: "any", Input: toPtr(NewEnvAnyValue([]any{1})), Expected: "[1]"},
+		{Name: "map", Input: toPtr(NewEnvMapBoolValue(map[string]bool{"b": false, "a": true})), Expected: "a=true;b=false"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertDeepEqual(t, tc.Expected, tc.Input.FlagString())
+		})
+	}
+}
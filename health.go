@@ -0,0 +1,73 @@
+package goenvconf
+
+import (
+	"context"
+	"errors"
+)
+
+// ProviderCheck is a lightweight probe of a configuration source, e.g. reading a known secret from Vault.
+type ProviderCheck struct {
+	// Name identifies the provider in errors.
+	Name string
+	// Check verifies that the provider is reachable and authorized.
+	Check func(ctx context.Context) error
+}
+
+// ProviderError is the error of a failed provider check.
+type ProviderError struct {
+	Name string
+	Err  error
+}
+
+// Error returns the error message.
+func (pe ProviderError) Error() string {
+	return pe.Name + ": " + pe.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (pe ProviderError) Unwrap() error {
+	return pe.Err
+}
+
+// NewGetEnvProviderCheck creates a check which gets the probe variable by the getter.
+// The provider is healthy if the variable exists or the getter returns [ErrEnvironmentVariableValueRequired],
+// i.e. the provider answered. Other errors, e.g. network or permission errors, fail the check.
+// The check returns the context error if the context is done before the getter returns.
+func NewGetEnvProviderCheck(name string, getFunc GetEnvFunc, probeVariable string) ProviderCheck {
+	return ProviderCheck{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			done := make(chan error, 1)
+
+			go func() {
+				_, err := getFunc(probeVariable)
+				done <- err
+			}()
+
+			select {
+			case err := <-done:
+				if err != nil && !errors.Is(err, ErrEnvironmentVariableValueRequired) {
+					return err
+				}
+
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+// CheckProviders runs the checks of all providers concurrently, e.g. in a readiness probe before the service
+// accepts traffic. Failed checks are returned together as [ProviderError] values in the order of the providers.
+func CheckProviders(ctx context.Context, providers ...ProviderCheck) error {
+	errs := make([]error, len(providers))
+
+	runConcurrently(len(providers), len(providers), func(i int) {
+		if err := providers[i].Check(ctx); err != nil {
+			errs[i] = ProviderError{Name: providers[i].Name, Err: err}
+		}
+	})
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,36 @@
+package goenvconf
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckProviders(t *testing.T) {
+	healthy := NewGetEnvProviderCheck("env", newBinderGetter(map[string]string{"PROBE": "ok"}), "PROBE")
+	missing := NewGetEnvProviderCheck("dotenv", newBinderGetter(map[string]string{}), "PROBE")
+	forbidden := NewGetEnvProviderCheck("vault", func(string) (string, error) {
+		return "", errors.New("permission denied")
+	}, "PROBE")
+	hanging := NewGetEnvProviderCheck("ssm", func(string) (string, error) {
+		time.Sleep(time.Second)
+
+		return "", nil
+	}, "PROBE")
+
+	assertNilError(t, CheckProviders(context.Background()))
+	assertNilError(t, CheckProviders(context.Background(), healthy, missing))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := CheckProviders(ctx, healthy, forbidden, missing, hanging)
+	assertDeepEqual(t, "vault: permission denied\nssm: context deadline exceeded", err.Error())
+	assertDeepEqual(t, true, errors.Is(err, context.DeadlineExceeded))
+
+	var providerErr ProviderError
+
+	assertDeepEqual(t, true, errors.As(err, &providerErr))
+	assertDeepEqual(t, "vault", providerErr.Name)
+}
@@ -0,0 +1,133 @@
+package goenvconf
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// ResolveEvent describes the resolution of an Env field, which is passed to resolve hooks.
+type ResolveEvent struct {
+	// Path is the field path in the config struct, e.g. Server.Port.
+	Path string
+	// Variable is the name of the environment variable which supplies the value, or the primary variable of the field
+	// if the value does not come from a variable.
+	Variable string
+	// Value is the resolved value, or nil if the field is unset or fails.
+	// Secret values are replaced with a redacted placeholder.
+	Value any
+	// Source describes where the value comes from.
+	Source ValueSource
+	// Getter is the name of the getter which supplies the variable, if the binder has named getters.
+	Getter string
+	// Err is the resolution error if the source is [SourceError].
+	// Errors of secret fields are redacted, but [errors.Is] still matches the original error.
+	Err error
+}
+
+// ResolveHook is called after an Env field is resolved, e.g. for custom logging, metrics or policy enforcement.
+// Hooks are called in the field order of the config struct, even if fields are resolved concurrently.
+type ResolveHook func(event ResolveEvent)
+
+var resolveHookRegistry = struct {
+	mu    sync.RWMutex
+	hooks []ResolveHook
+}{}
+
+// OnResolve registers a package-level hook which is called after every Env field resolution of all binders.
+func OnResolve(hook ResolveHook) {
+	if hook == nil {
+		return
+	}
+
+	resolveHookRegistry.mu.Lock()
+	defer resolveHookRegistry.mu.Unlock()
+
+	resolveHookRegistry.hooks = append(resolveHookRegistry.hooks, hook)
+}
+
+// ResetResolveHooks removes all package-level resolve hooks.
+func ResetResolveHooks() {
+	resolveHookRegistry.mu.Lock()
+	defer resolveHookRegistry.mu.Unlock()
+
+	resolveHookRegistry.hooks = nil
+}
+
+// OnResolve returns a copy of the binder which calls the hook after every Env field resolution.
+// Binder hooks are called after package-level hooks.
+func (b Binder) OnResolve(hook ResolveHook) *Binder {
+	if hook != nil {
+		b.hooks = append(slices.Clip(b.hooks), hook)
+	}
+
+	return &b
+}
+
+// resolveHooks returns package-level hooks followed by hooks of the binder.
+func (b Binder) resolveHooks() []ResolveHook {
+	resolveHookRegistry.mu.RLock()
+	defer resolveHookRegistry.mu.RUnlock()
+
+	if len(resolveHookRegistry.hooks) == 0 {
+		return b.hooks
+	}
+
+	return append(slices.Clone(resolveHookRegistry.hooks), b.hooks...)
+}
+
+// traces checks if resolved fields must be traced, for a report or resolve hooks.
+func (b Binder) traces() bool {
+	if b.report != nil || len(b.hooks) > 0 {
+		return true
+	}
+
+	resolveHookRegistry.mu.RLock()
+	defer resolveHookRegistry.mu.RUnlock()
+
+	return len(resolveHookRegistry.hooks) > 0
+}
+
+// notifyResolve calls resolve hooks with the resolved field. The value and the error are redacted if the field is secret.
+func (b Binder) notifyResolve(field reflect.Value, resolved ResolvedField) {
+	hooks := b.resolveHooks()
+	if len(hooks) == 0 {
+		return
+	}
+
+	event := ResolveEvent{
+		Path:     resolved.Path,
+		Variable: resolved.Variable,
+		Value:    resolved.Value,
+		Source:   resolved.Source,
+		Getter:   resolved.Getter,
+		Err:      resolved.Err,
+	}
+
+	if event.Variable == "" {
+		event.Variable = envVariableName(field)
+	}
+
+	if isSecretField(field, event.Variable) {
+		if event.Value != nil {
+			event.Value = redactedValue
+		}
+
+		event.Err = redactError(event.Err)
+	}
+
+	for _, hook := range hooks {
+		hook(event)
+	}
+}
+
+// isSecretField checks if the Env field is marked as secret, or the variable matches the secret registry.
+func isSecretField(field reflect.Value, variable string) bool {
+	if field.IsValid() && field.CanInterface() {
+		if secret, ok := field.Interface().(interface{ IsSecret() bool }); ok && secret.IsSecret() {
+			return true
+		}
+	}
+
+	return IsSecretVariable(variable)
+}
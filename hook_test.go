@@ -0,0 +1,93 @@
+package goenvconf
+
+import (
+	"testing"
+)
+
+type hookConfig struct {
+	Host     EnvString
+	Port     EnvInt
+	Password EnvString
+	APIKey   EnvString
+}
+
+type hookTarget struct {
+	Host     string
+	Port     int
+	Password string
+	APIKey   string
+}
+
+func TestBinder_OnResolve(t *testing.T) {
+	t.Cleanup(ResetResolveHooks)
+	t.Cleanup(ResetSecretVariables)
+
+	assertNilError(t, RegisterSecretVariables("*_KEY"))
+
+	config := hookConfig{
+		Host:     NewEnvString("HOST", "localhost"),
+		Port:     NewEnvIntVariable("PORT"),
+		Password: NewEnvStringVariable("PASSWORD").Secret(),
+		APIKey:   NewEnvStringVariable("API_KEY"),
+	}
+	getter := newBinderGetter(map[string]string{"PORT": "x", "PASSWORD": "secret", "API_KEY": "key"})
+
+	var globalEvents, binderEvents []ResolveEvent
+
+	OnResolve(func(event ResolveEvent) {
+		globalEvents = append(globalEvents, event)
+	})
+
+	binder := NewBinder(getter).OnResolve(func(event ResolveEvent) {
+		binderEvents = append(binderEvents, event)
+	})
+
+	var target hookTarget
+
+	err := binder.Bind(&target, config)
+	assertErrorContains(t, err, "Port (PORT): strconv.ParseInt")
+	assertDeepEqual(t, globalEvents, binderEvents)
+	assertDeepEqual(t, []ResolveEvent{
+		{Path: "Host", Variable: "HOST", Value: "localhost", Source: SourceLiteral},
+		{Path: "Port", Variable: "PORT", Source: SourceError, Err: binderEvents[1].Err},
+		{Path: "Password", Variable: "PASSWORD", Value: redactedValue, Source: SourceVariable},
+		{Path: "APIKey", Variable: "API_KEY", Value: redactedValue, Source: SourceVariable},
+	}, binderEvents)
+
+	t.Run("concurrent", func(t *testing.T) {
+		var events []ResolveEvent
+
+		err := NewBinder(getter).WithConcurrency(4).OnResolve(func(event ResolveEvent) {
+			events = append(events, event)
+		}).ValidateOnly(config)
+		assertErrorContains(t, err, "Port (PORT): strconv.ParseInt")
+		assertDeepEqual(t, binderEvents, events[len(events)-len(binderEvents):])
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		ResetResolveHooks()
+
+		var events []ResolveEvent
+
+		config.Port = NewEnvInt("PORT", 8080)
+		_, err := NewBinder(newBinderGetter(nil)).OnResolve(func(event ResolveEvent) {
+			events = append(events, event)
+		}).Resolve(config)
+		assertErrorContains(t, err, "Password: PASSWORD: EmptyVar")
+		assertDeepEqual(t, []string{"Host", "Port", "Password", "APIKey"}, []string{
+			events[0].Path, events[1].Path, events[2].Path, events[3].Path,
+		})
+		assertDeepEqual(t, int64(8080), events[1].Value)
+		assertDeepEqual(t, SourceError, events[2].Source)
+	})
+}
+
+func TestOnResolve_nilHook(t *testing.T) {
+	t.Cleanup(ResetResolveHooks)
+
+	OnResolve(nil)
+
+	binder := NewBinder(nil).OnResolve(nil)
+	assertDeepEqual(t, 0, len(binder.hooks))
+	assertDeepEqual(t, false, binder.traces())
+}
@@ -0,0 +1,112 @@
+package goenvconf
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The WithIndexedVariables methods populate slices from numbered variables, e.g. MY_LIST_0, MY_LIST_1 and so on,
+// instead of a single delimited value, so items can contain arbitrary content including commas and empty strings.
+// The lookup stops at the first unset index. The single variable and the literal value are used if MY_LIST_0 is unset.
+
+// WithIndexedVariables returns a copy of the instance which reads items from numbered variables VAR_0, VAR_1, ...
+func (ev EnvStringSlice) WithIndexedVariables() EnvStringSlice {
+	ev.options = ev.options.clone()
+	ev.options.indexed = true
+
+	return ev
+}
+
+// WithIndexedVariables returns a copy of the instance which reads items from numbered variables VAR_0, VAR_1, ...
+func (ev EnvIntSlice) WithIndexedVariables() EnvIntSlice {
+	ev.options = ev.options.clone()
+	ev.options.indexed = true
+
+	return ev
+}
+
+func (ev EnvStringSlice) indexedVariable() string {
+	return ev.options.indexedVariable(ev.Variable)
+}
+
+func (ev EnvIntSlice) indexedVariable() string {
+	return ev.options.indexedVariable(ev.Variable)
+}
+
+// indexedSlice is implemented by slice types which can read items from numbered variables.
+type indexedSlice interface {
+	// indexedVariable returns the variable name of numbered variables, or an empty string if the indexed mode is disabled.
+	indexedVariable() string
+}
+
+func (eo *envOptions) indexedVariable(variable *string) string {
+	if eo == nil || !eo.indexed || variable == nil {
+		return ""
+	}
+
+	return *variable
+}
+
+// isIndexedVariableName checks if the name is a numbered variable of the variable, e.g. MY_LIST_0 of MY_LIST.
+func isIndexedVariableName(name string, variable string) bool {
+	if variable == "" {
+		return false
+	}
+
+	index, ok := strings.CutPrefix(name, variable+"_")
+	if !ok || index == "" {
+		return false
+	}
+
+	_, err := strconv.ParseUint(index, 10, 0)
+
+	return err == nil
+}
+
+// IndexedVariableName returns the name of the numbered variable at the index, e.g. MY_LIST_0.
+func IndexedVariableName(variable string, index int) string {
+	return variable + "_" + strconv.Itoa(index)
+}
+
+// lookupIndexedItems returns items of numbered variables if the indexed mode is enabled,
+// or nil if the first numbered variable is unset.
+func (eo *envOptions) lookupIndexedItems(variable *string, getFunc GetEnvFunc) ([]string, error) {
+	if eo == nil || !eo.indexed || variable == nil || *variable == "" {
+		return nil, nil
+	}
+
+	var results []string
+
+	for index := 0; ; index++ {
+		value, err := getFunc(IndexedVariableName(*variable, index))
+		if err != nil {
+			if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+				return results, nil
+			}
+
+			return nil, err
+		}
+
+		results = append(results, value)
+	}
+}
+
+func (ev EnvIntSlice) parseIndexedItems(items []string) ([]int64, error) {
+	results := make([]int64, len(items))
+
+	for index, item := range items {
+		value, err := ev.options.parseInt(strings.TrimSpace(item))
+		if err != nil {
+			return nil, NewParseEnvFailedError(
+				fmt.Sprintf("failed to parse %s: invalid integer slice syntax", *ev.Variable),
+				strconv.Itoa(index),
+			)
+		}
+
+		results[index] = value
+	}
+
+	return results, nil
+}
@@ -0,0 +1,52 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithIndexedVariables(t *testing.T) {
+	t.Setenv("INDEXED_LIST_0", "a,b")
+	t.Setenv("INDEXED_LIST_1", "")
+	t.Setenv("INDEXED_LIST_2", "c")
+	t.Setenv("INDEXED_LIST_4", "skipped")
+	t.Setenv("INDEXED_INTS", "1,2")
+	t.Setenv("INDEXED_INTS_0", "10")
+	t.Setenv("INDEXED_INTS_1", " 0x20 ")
+	t.Setenv("INDEXED_BAD_0", "x")
+
+	values, err := NewEnvStringSliceVariable("INDEXED_LIST").WithIndexedVariables().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a,b", "", "c"}, values)
+
+	values, err = NewEnvStringSlice("INDEXED_MISSING", []string{"x"}).WithIndexedVariables().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"x"}, values)
+
+	ints, err := NewEnvIntSliceVariable("INDEXED_INTS").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{1, 2}, ints)
+
+	ints, err = NewEnvIntSliceVariable("INDEXED_INTS").WithIndexedVariables().WithAlternateBases().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{10, 32}, ints)
+
+	_, err = NewEnvIntSliceVariable("INDEXED_BAD").WithIndexedVariables().Get()
+	assertErrorContains(t, err, "ParseEnvFailed: failed to parse INDEXED_BAD: invalid integer slice syntax. Hint: 0")
+
+	_, err = NewEnvIntSliceVariable("INDEXED_MISSING").WithIndexedVariables().Strict().Get()
+	assertErrorContains(t, err, ErrEnvironmentVariableValueRequired.Error())
+
+	errGetter := errors.New("getter failed")
+	getFunc := func(name string) (string, error) {
+		if name == "FOO_0" {
+			return "", errGetter
+		}
+
+		return "", ErrEnvironmentVariableValueRequired
+	}
+
+	_, err = NewEnvStringSliceVariable("FOO").WithIndexedVariables().GetCustom(getFunc)
+	assertDeepEqual(t, errGetter, err)
+	assertDeepEqual(t, "LIST_3", IndexedVariableName("LIST", 3))
+}
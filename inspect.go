@@ -0,0 +1,317 @@
+package goenvconf
+
+import "errors"
+
+// The inspection methods report the structure and environment state of an Env instance without parsing:
+//   - HasVariable checks if the instance references a variable.
+//   - HasValue checks if the instance has a literal value.
+//   - IsSet and IsSetCustom check if the variable, one of its candidate variables, deprecated aliases or default variables is set.
+//     Variables which are set to an empty string are unset unless the strict mode is enabled.
+
+// isVariableSet checks if the variable, one of its candidate variables, deprecated aliases or default variables is set by the getter.
+func isVariableSet(variable *string, options *envOptions, getFunc GetEnvFunc) (bool, error) {
+	if variable == nil || *variable == "" {
+		return false, nil
+	}
+
+	names := append([]string{*variable}, options.alternateNames()...)
+
+	for _, name := range names {
+		value, err := getFunc(name)
+		if err != nil && !errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return false, err
+		}
+
+		if !options.isUnsetValue(value, err) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvString) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvString) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvString) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvString) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvInt) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvInt) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvInt) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvInt) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvBool) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvBool) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvBool) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvBool) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvFloat) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvFloat) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvFloat) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvFloat) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvAny) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvAny) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvAny) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvAny) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvStringSlice) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvStringSlice) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvStringSlice) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvStringSlice) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvIntSlice) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvIntSlice) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvIntSlice) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvIntSlice) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvFloatSlice) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvFloatSlice) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvFloatSlice) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvFloatSlice) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvBoolSlice) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvBoolSlice) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvBoolSlice) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvBoolSlice) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvMapString) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvMapString) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvMapString) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvMapString) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvMapInt) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvMapInt) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvMapInt) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvMapInt) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvMapFloat) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvMapFloat) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvMapFloat) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvMapFloat) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
+
+// HasVariable checks if the instance references a variable.
+func (ev EnvMapBool) HasVariable() bool {
+	return ev.Variable != nil && *ev.Variable != ""
+}
+
+// HasValue checks if the instance has a literal value.
+func (ev EnvMapBool) HasValue() bool {
+	return ev.Value != nil
+}
+
+// IsSet checks if the variable is set in the system environment.
+func (ev EnvMapBool) IsSet() bool {
+	result, _ := isVariableSet(ev.Variable, ev.options, GetOSEnv)
+
+	return result
+}
+
+// IsSetCustom checks if the variable is set by a custom function.
+func (ev EnvMapBool) IsSetCustom(getFunc GetEnvFunc) (bool, error) {
+	return isVariableSet(ev.Variable, ev.options, getFunc)
+}
@@ -0,0 +1,77 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	t.Setenv("TEST_INSPECT_PORT", "8080")
+	t.Setenv("TEST_INSPECT_EMPTY", "")
+	t.Setenv("TEST_INSPECT_OLD_HOST", "localhost")
+
+	testCases := []struct {
+		Name  string
+		Input interface {
+			HasVariable() bool
+			HasValue() bool
+			IsSet() bool
+		}
+		HasVariable bool
+		HasValue    bool
+		IsSet       bool
+	}{
+		{Name: "zero", Input: EnvString{}},
+		{Name: "value", Input: NewEnvIntValue(1), HasValue: true},
+		{Name: "set", Input: NewEnvIntVariable("TEST_INSPECT_PORT"), HasVariable: true, IsSet: true},
+		{Name: "unset", Input: NewEnvMapIntVariable("TEST_INSPECT_MISSING"), HasVariable: true},
+		{Name: "both", Input: NewEnvIntSlice("TEST_INSPECT_MISSING", []int64{1}), HasVariable: true, HasValue: true},
+		{Name: "empty", Input: NewEnvStringVariable("TEST_INSPECT_EMPTY"), HasVariable: true},
+		{Name: "empty_strict", Input: NewEnvStringVariable("TEST_INSPECT_EMPTY").Strict(), HasVariable: true, IsSet: true},
+		{
+			Name:        "alias",
+			Input:       NewEnvStringVariable("TEST_INSPECT_HOST").WithDeprecatedAlias("TEST_INSPECT_OLD_HOST"),
+			HasVariable: true,
+			IsSet:       true,
+		},
+		{
+			Name:        "candidate",
+			Input:       NewEnvAnyVariable("TEST_INSPECT_MISSING").WithCandidateVariables("TEST_INSPECT_PORT"),
+			HasVariable: true,
+			IsSet:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertDeepEqual(t, tc.HasVariable, tc.Input.HasVariable())
+			assertDeepEqual(t, tc.HasValue, tc.Input.HasValue())
+			assertDeepEqual(t, tc.IsSet, tc.Input.IsSet())
+		})
+	}
+}
+
+func TestIsSetCustom(t *testing.T) {
+	errGetter := errors.New("getter failed")
+	getFunc := func(name string) (string, error) {
+		switch name {
+		case "DEBUG":
+			return "true", nil
+		case "BROKEN":
+			return "", errGetter
+		default:
+			return "", ErrEnvironmentVariableValueRequired
+		}
+	}
+
+	result, err := NewEnvBoolVariable("DEBUG").IsSetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, true, result)
+
+	result, err = NewEnvBoolValue(true).IsSetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, false, result)
+
+	_, err = NewEnvBoolVariable("BROKEN").IsSetCustom(getFunc)
+	assertDeepEqual(t, true, errors.Is(err, errGetter))
+}
@@ -0,0 +1,114 @@
+package goenvconf
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+var sizeSuffixes = []struct {
+	Suffix     string
+	Multiplier int64
+}{
+	{Suffix: "Ki", Multiplier: 1 << 10},
+	{Suffix: "Mi", Multiplier: 1 << 20},
+	{Suffix: "Gi", Multiplier: 1 << 30},
+	{Suffix: "Ti", Multiplier: 1 << 40},
+	{Suffix: "Pi", Multiplier: 1 << 50},
+	{Suffix: "Ei", Multiplier: 1 << 60},
+	{Suffix: "k", Multiplier: 1e3},
+	{Suffix: "K", Multiplier: 1e3},
+	{Suffix: "M", Multiplier: 1e6},
+	{Suffix: "G", Multiplier: 1e9},
+	{Suffix: "T", Multiplier: 1e12},
+	{Suffix: "P", Multiplier: 1e15},
+	{Suffix: "E", Multiplier: 1e18},
+}
+
+// ParseIntWithSizeSuffix parses an integer with an optional size suffix, e.g. 10k, 2M or 1Gi.
+// Decimal suffixes k (or K), M, G, T, P and E are powers of 1000,
+// and binary suffixes Ki, Mi, Gi, Ti, Pi and Ei are powers of 1024.
+func ParseIntWithSizeSuffix(input string) (int64, error) {
+	return parseIntWithSizeSuffix(strings.TrimSpace(input), 10)
+}
+
+func parseIntWithSizeSuffix(value string, base int) (int64, error) {
+	result, err := strconv.ParseInt(value, base, 64)
+	if err == nil {
+		return result, nil
+	}
+
+	for _, unit := range sizeSuffixes {
+		number, ok := strings.CutSuffix(value, unit.Suffix)
+		if !ok || number == "" {
+			continue
+		}
+
+		result, err := strconv.ParseInt(number, base, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		if result > math.MaxInt64/unit.Multiplier || result < math.MinInt64/unit.Multiplier {
+			return 0, NewParseEnvFailedError("the value overflows int64", value)
+		}
+
+		return result * unit.Multiplier, nil
+	}
+
+	return 0, err
+}
+
+// WithAlternateBases returns a copy of the instance which accepts 0x, 0o and 0b prefixes and digit underscores
+// in the environment value, e.g. 0xFFFF or 1_000_000, following the base 0 semantics of [strconv.ParseInt].
+func (ev EnvInt) WithAlternateBases() EnvInt {
+	ev.options = ev.options.clone()
+	ev.options.alternateBases = true
+
+	return ev
+}
+
+// WithAlternateBases returns a copy of the instance which accepts 0x, 0o and 0b prefixes and digit underscores
+// in environment items, following the base 0 semantics of [strconv.ParseInt].
+func (ev EnvIntSlice) WithAlternateBases() EnvIntSlice {
+	ev.options = ev.options.clone()
+	ev.options.alternateBases = true
+
+	return ev
+}
+
+// WithAlternateBases returns a copy of the instance which accepts 0x, 0o and 0b prefixes and digit underscores
+// in environment values, following the base 0 semantics of [strconv.ParseInt].
+func (ev EnvMapInt) WithAlternateBases() EnvMapInt {
+	ev.options = ev.options.clone()
+	ev.options.alternateBases = true
+
+	return ev
+}
+
+// WithSizeSuffixes returns a copy of the instance which accepts size suffixes in the environment value,
+// e.g. 10k or 1Gi. See [ParseIntWithSizeSuffix] for supported suffixes.
+func (ev EnvInt) WithSizeSuffixes() EnvInt {
+	ev.options = ev.options.clone()
+	ev.options.sizeSuffixes = true
+
+	return ev
+}
+
+// WithSizeSuffixes returns a copy of the instance which accepts size suffixes in environment items,
+// e.g. 10k or 1Gi. See [ParseIntWithSizeSuffix] for supported suffixes.
+func (ev EnvIntSlice) WithSizeSuffixes() EnvIntSlice {
+	ev.options = ev.options.clone()
+	ev.options.sizeSuffixes = true
+
+	return ev
+}
+
+// WithSizeSuffixes returns a copy of the instance which accepts size suffixes in environment values,
+// e.g. 10k or 1Gi. See [ParseIntWithSizeSuffix] for supported suffixes.
+func (ev EnvMapInt) WithSizeSuffixes() EnvMapInt {
+	ev.options = ev.options.clone()
+	ev.options.sizeSuffixes = true
+
+	return ev
+}
@@ -0,0 +1,110 @@
+package goenvconf
+
+import (
+	"testing"
+)
+
+func TestWithAlternateBases(t *testing.T) {
+	t.Setenv("ALT_BASE_INT", "0xFFFF")
+	t.Setenv("ALT_BASE_INT_SLICE", "0b101, 0o17,1_000_000,-0x10")
+	t.Setenv("ALT_BASE_INT_MAP", "mask=0xff;limit=1_000")
+
+	_, err := NewEnvIntVariable("ALT_BASE_INT").Get()
+	assertErrorContains(t, err, "invalid syntax")
+
+	value, err := NewEnvIntVariable("ALT_BASE_INT").WithAlternateBases().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(0xFFFF), value)
+
+	value, err = NewEnvIntVariable("ALT_BASE_INT").WithAlternateBases().Strict().GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(0xFFFF), value)
+
+	_, err = NewEnvIntSliceVariable("ALT_BASE_INT_SLICE").Get()
+	assertErrorContains(t, err, "failed to parse ALT_BASE_INT_SLICE: invalid integer slice syntax")
+
+	values, err := NewEnvIntSliceVariable("ALT_BASE_INT_SLICE").WithAlternateBases().GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{5, 15, 1_000_000, -16}, values)
+
+	values, err = NewEnvIntSliceVariable("ALT_BASE_INT_SLICE").WithAlternateBases().Strict().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{5, 15, 1_000_000, -16}, values)
+
+	t.Setenv("ALT_BASE_INT_SLICE", `["0x10", 7]`)
+
+	values, err = NewEnvIntSliceVariable("ALT_BASE_INT_SLICE").WithAlternateBases().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{16, 7}, values)
+
+	_, err = NewEnvMapIntVariable("ALT_BASE_INT_MAP").Get()
+	assertErrorContains(t, err, "invalid integer map syntax")
+
+	mapValues, err := NewEnvMapIntVariable("ALT_BASE_INT_MAP").WithAlternateBases().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"mask": 0xff, "limit": 1000}, mapValues)
+
+	mapValues, err = NewEnvMapIntVariable("ALT_BASE_INT_MAP").WithAlternateBases().Strict().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"mask": 0xff, "limit": 1000}, mapValues)
+}
+
+func TestParseIntWithSizeSuffix(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected int64
+		ErrorMsg string
+	}{
+		{Input: "100", Expected: 100},
+		{Input: " 10k ", Expected: 10_000},
+		{Input: "10K", Expected: 10_000},
+		{Input: "2M", Expected: 2_000_000},
+		{Input: "-3G", Expected: -3_000_000_000},
+		{Input: "1Ki", Expected: 1024},
+		{Input: "1Gi", Expected: 1 << 30},
+		{Input: "7Ei", Expected: 7 << 60},
+		{Input: "9E", Expected: 9e18},
+		{Input: "10E", ErrorMsg: "ParseEnvFailed: the value overflows int64. Hint: 10E"},
+		{Input: "1.5M", ErrorMsg: "invalid syntax"},
+		{Input: "k", ErrorMsg: "invalid syntax"},
+		{Input: "10x", ErrorMsg: "invalid syntax"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			result, err := ParseIntWithSizeSuffix(tc.Input)
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestWithSizeSuffixes(t *testing.T) {
+	t.Setenv("SIZE_INT", "512Mi")
+	t.Setenv("SIZE_INT_HEX", "0xE")
+	t.Setenv("SIZE_INT_SLICE", "1k,2Ki")
+	t.Setenv("SIZE_INT_MAP", "a=1M")
+
+	_, err := NewEnvIntVariable("SIZE_INT").Get()
+	assertErrorContains(t, err, "invalid syntax")
+
+	value, err := NewEnvIntVariable("SIZE_INT").WithSizeSuffixes().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(512<<20), value)
+
+	value, err = NewEnvIntVariable("SIZE_INT_HEX").WithSizeSuffixes().WithAlternateBases().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(14), value)
+
+	values, err := NewEnvIntSliceVariable("SIZE_INT_SLICE").WithSizeSuffixes().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{1000, 2048}, values)
+
+	mapValues, err := NewEnvMapIntVariable("SIZE_INT_MAP").WithSizeSuffixes().Strict().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"a": 1_000_000}, mapValues)
+}
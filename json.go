@@ -0,0 +1,702 @@
+package goenvconf
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// isJSONObject checks if the raw JSON bytes is an object.
+func isJSONObject(b []byte) bool {
+	trimmed := bytes.TrimSpace(b)
+
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+// decodeJSONShorthand decodes the raw JSON bytes of a shorthand form.
+// Numbers are decoded as [json.Number] to keep the precision of integers if useNumber is true.
+// A bare $VAR string is converted to ${VAR}, because the bare form is a JSON-only convention.
+func decodeJSONShorthand(b []byte, useNumber bool) (any, error) {
+	var result any
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	if useNumber {
+		decoder.UseNumber()
+	}
+
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	if str, ok := result.(string); ok && isBareEnvReference(str) {
+		return "${" + str[1:] + "}", nil
+	}
+
+	return result, nil
+}
+
+// compactJSON returns the compact JSON form of an Env instance if the form can be decoded back faithfully.
+// The variable reference is used if only the variable is set, and the bare literal if only the value is set.
+func compactJSON(hasValue bool, value any, variable *string) ([]byte, bool) {
+	hasVariable := variable != nil && *variable != ""
+
+	switch {
+	case hasVariable && !hasValue:
+		result, err := json.Marshal("${" + *variable + "}")
+
+		return result, err == nil
+	case hasValue && variable == nil:
+		result, err := json.Marshal(value)
+		if err != nil || isJSONObject(result) {
+			return nil, false
+		}
+
+		var str string
+
+		if json.Unmarshal(result, &str) == nil {
+			if _, isVariable := parseEnvReference(str); isVariable || isBareEnvReference(str) {
+				return nil, false
+			}
+		}
+
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvString) WithCompactEncoding() EnvString {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvString) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvString
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvString) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvString
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvString(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvInt) WithCompactEncoding() EnvInt {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvInt) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvInt
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvInt) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvInt
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvInt(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvBool) WithCompactEncoding() EnvBool {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvBool) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvBool
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvBool) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvBool
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvBool(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvFloat) WithCompactEncoding() EnvFloat {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvFloat) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvFloat
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvFloat) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvFloat
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvFloat(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvAny) WithCompactEncoding() EnvAny {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvAny) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvAny
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvAny) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvAny
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, false)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvAny(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvStringSlice) WithCompactEncoding() EnvStringSlice {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvStringSlice) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvStringSlice
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvStringSlice) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvStringSlice
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvStringSlice(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvIntSlice) WithCompactEncoding() EnvIntSlice {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvIntSlice) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvIntSlice
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvIntSlice) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvIntSlice
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvIntSlice(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvFloatSlice) WithCompactEncoding() EnvFloatSlice {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvFloatSlice) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvFloatSlice
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvFloatSlice) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvFloatSlice
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvFloatSlice(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvBoolSlice) WithCompactEncoding() EnvBoolSlice {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvBoolSlice) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvBoolSlice
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvBoolSlice) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvBoolSlice
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvBoolSlice(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvMapString) WithCompactEncoding() EnvMapString {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvMapString) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvMapString
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvMapString) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvMapString
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvMapString(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvMapInt) WithCompactEncoding() EnvMapInt {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvMapInt) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvMapInt
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvMapInt) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvMapInt
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvMapInt(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvMapFloat) WithCompactEncoding() EnvMapFloat {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvMapFloat) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvMapFloat
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvMapFloat) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvMapFloat
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvMapFloat(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// WithCompactEncoding returns a copy of the instance which is marshaled to the compact form,
+// that is "${VAR}" if only the variable is set, or the bare literal if only the value is set.
+// Otherwise, the object form is used.
+func (ev EnvMapBool) WithCompactEncoding() EnvMapBool {
+	ev.options = ev.options.clone()
+	ev.options.compact = true
+
+	return ev
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ev EnvMapBool) MarshalJSON() ([]byte, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactJSON(ev.Value != nil, ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvMapBool
+
+	return json.Marshal(plain(ev))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// In addition to the object form, it accepts a variable reference string, e.g. "$VAR" or "${VAR}",
+// and other shorthand forms of [DecodeHook] except objects.
+func (ev *EnvMapBool) UnmarshalJSON(b []byte) error {
+	if isJSONObject(b) {
+		type plain EnvMapBool
+
+		return json.Unmarshal(b, (*plain)(ev))
+	}
+
+	rawValue, err := decodeJSONShorthand(b, true)
+	if err != nil || rawValue == nil {
+		return err
+	}
+
+	result, err := decodeEnvMapBool(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
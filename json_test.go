@@ -0,0 +1,158 @@
+package goenvconf
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type jsonTestConfig struct {
+	Name     EnvString      `json:"name"`
+	Port     EnvInt         `json:"port"`
+	Debug    EnvBool        `json:"debug"`
+	Ratio    EnvFloat       `json:"ratio"`
+	Extra    EnvAny         `json:"extra"`
+	Hosts    EnvStringSlice `json:"hosts"`
+	Ports    EnvIntSlice    `json:"ports"`
+	Weights  EnvFloatSlice  `json:"weights"`
+	Flags    EnvBoolSlice   `json:"flags"`
+	Headers  EnvMapString   `json:"headers"`
+	Limits   EnvMapInt      `json:"limits"`
+	Scores   EnvMapFloat    `json:"scores"`
+	Features EnvMapBool     `json:"features"`
+	Pointer  *EnvString     `json:"pointer"`
+}
+
+func TestJSON_UnmarshalShorthand(t *testing.T) {
+	var output jsonTestConfig
+
+	assertNilError(t, json.Unmarshal([]byte(`{
+		"name": "$APP_NAME",
+		"port": 9007199254740993,
+		"debug": { "env": "DEBUG", "value": true },
+		"ratio": 0.5,
+		"extra": "${EXTRA}",
+		"hosts": ["a", "b"],
+		"ports": "1,2",
+		"weights": "$WEIGHTS",
+		"flags": [true],
+		"headers": "${HEADERS}",
+		"limits": "a=1;b=2",
+		"scores": { "value": { "a": 1.5 } },
+		"features": null,
+		"pointer": "literal"
+	}`), &output))
+
+	assertDeepEqual(t, jsonTestConfig{
+		Name:    NewEnvStringVariable("APP_NAME"),
+		Port:    NewEnvIntValue(9007199254740993),
+		Debug:   NewEnvBool("DEBUG", true),
+		Ratio:   NewEnvFloatValue(0.5),
+		Extra:   NewEnvAnyVariable("EXTRA"),
+		Hosts:   NewEnvStringSliceValue([]string{"a", "b"}),
+		Ports:   NewEnvIntSliceValue([]int64{1, 2}),
+		Weights: NewEnvFloatSliceVariable("WEIGHTS"),
+		Flags:   NewEnvBoolSliceValue([]bool{true}),
+		Headers: NewEnvMapStringVariable("HEADERS"),
+		Limits:  NewEnvMapIntValue(map[string]int64{"a": 1, "b": 2}),
+		Scores:  NewEnvMapFloatValue(map[string]float64{"a": 1.5}),
+		Pointer: toPtr(NewEnvStringValue("literal")),
+	}, output)
+}
+
+func TestJSON_UnmarshalErrors(t *testing.T) {
+	var output jsonTestConfig
+
+	assertErrorContains(t, json.Unmarshal([]byte(`{"port": "eighty"}`), &output), "invalid integer value")
+	assertErrorContains(t, json.Unmarshal([]byte(`{"port": {"value": "8080"}}`), &output), "cannot unmarshal string")
+	assertErrorContains(t, json.Unmarshal([]byte(`{"ports": [1, true]}`), &output), "invalid slice value")
+	assertErrorContains(t, json.Unmarshal([]byte(`{"debug": 1}`), &output), "invalid boolean value")
+}
+
+func TestJSON_MarshalCompact(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    any
+		Expected string
+	}{
+		{
+			Name:     "variable",
+			Input:    NewEnvStringVariable("FOO").WithCompactEncoding(),
+			Expected: `"${FOO}"`,
+		},
+		{
+			Name:     "value",
+			Input:    NewEnvIntValue(8080).WithCompactEncoding(),
+			Expected: `8080`,
+		},
+		{
+			Name:     "both",
+			Input:    NewEnvBool("FOO", true).WithCompactEncoding(),
+			Expected: `{"value":true,"env":"FOO"}`,
+		},
+		{
+			Name:     "variable_like_string_value",
+			Input:    NewEnvStringValue("$FOO").WithCompactEncoding(),
+			Expected: `{"value":"$FOO"}`,
+		},
+		{
+			Name:     "slice_value",
+			Input:    NewEnvFloatSliceValue([]float64{1.5}).WithCompactEncoding(),
+			Expected: `[1.5]`,
+		},
+		{
+			Name:     "map_value",
+			Input:    NewEnvMapIntValue(map[string]int64{"a": 1}).WithCompactEncoding(),
+			Expected: `{"value":{"a":1}}`,
+		},
+		{
+			Name:     "map_variable",
+			Input:    NewEnvMapBoolVariable("FOO").WithCompactEncoding(),
+			Expected: `"${FOO}"`,
+		},
+		{
+			Name:     "any_object",
+			Input:    NewEnvAnyValue(map[string]any{"a": 1}).WithCompactEncoding(),
+			Expected: `{"value":{"a":1}}`,
+		},
+		{
+			Name:     "zero",
+			Input:    EnvStringSlice{}.WithCompactEncoding(),
+			Expected: `{}`,
+		},
+		{
+			Name:     "not_compact",
+			Input:    NewEnvStringVariable("FOO"),
+			Expected: `{"env":"FOO"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := json.Marshal(tc.Input)
+			assertNilError(t, err)
+			assertDeepEqual(t, tc.Expected, string(result))
+		})
+	}
+
+	t.Run("round_trip", func(t *testing.T) {
+		input := jsonTestConfig{
+			Name:     NewEnvStringVariable("APP_NAME").WithCompactEncoding(),
+			Port:     NewEnvIntValue(8080).WithCompactEncoding(),
+			Extra:    NewEnvAnyValue([]any{"a"}).WithCompactEncoding(),
+			Hosts:    NewEnvStringSlice("HOSTS", []string{"a"}).WithCompactEncoding(),
+			Features: NewEnvMapBoolValue(map[string]bool{"a": true}).WithCompactEncoding(),
+		}
+
+		bytes, err := json.Marshal(input)
+		assertNilError(t, err)
+
+		var output jsonTestConfig
+
+		assertNilError(t, json.Unmarshal(bytes, &output))
+		assertDeepEqual(t, true, input.Name.Equal(output.Name))
+		assertDeepEqual(t, true, input.Port.Equal(output.Port))
+		assertDeepEqual(t, true, input.Extra.Equal(output.Extra))
+		assertDeepEqual(t, true, input.Hosts.Equal(output.Hosts))
+		assertDeepEqual(t, true, input.Features.Equal(output.Features))
+	})
+}
@@ -0,0 +1,156 @@
+package goenvconf
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const (
+	maxSuggestionDistance = 2
+	// prefixVariableSuffix marks a declared name as the prefix of prefix-scanned variables, e.g. HEADERS_*.
+	prefixVariableSuffix = "_*"
+	// indexedVariableSuffix marks a declared name as numbered variables of an indexed slice, e.g. ORIGINS_#.
+	indexedVariableSuffix = "_#"
+)
+
+// ListVariables returns the sorted and unique names of environment variables declared by Env fields
+// of the config struct, including deprecated aliases. Nil pointer fields are skipped.
+// Prefix-scanned maps also declare the pattern <VAR>_* and indexed slices declare the pattern <VAR>_#,
+// which match any suffix and any index respectively in [FindUnknownVariables].
+func ListVariables(config any) ([]string, error) {
+	value := reflect.Indirect(reflect.ValueOf(config))
+	if value.Kind() != reflect.Struct {
+		return nil, ErrInvalidBindTarget
+	}
+
+	var results []string
+
+	walkEnvFields(value, "", func(_ string, field reflect.Value) {
+		declared, ok := field.Interface().(EnvValue)
+		if !ok {
+			return
+		}
+
+		results = append(results, declared.Variables()...)
+
+		if scanner, ok := declared.(prefixScanner); ok && scanner.scanPrefix() != "" {
+			results = append(results, scanner.scanPrefix()+"*")
+		}
+
+		if indexed, ok := declared.(indexedSlice); ok && indexed.indexedVariable() != "" {
+			results = append(results, indexed.indexedVariable()+indexedVariableSuffix)
+		}
+	})
+
+	slices.Sort(results)
+
+	return slices.Compact(results), nil
+}
+
+// FindUnknownVariables returns the sorted names of environment variables which start with the prefix
+// but are not in the declared list. The environ argument has the format of [os.Environ], i.e. KEY=VALUE items.
+// Declared patterns of [ListVariables] match prefix-scanned and numbered variables, e.g. HEADERS_* and ORIGINS_#.
+func FindUnknownVariables(declared []string, environ []string, prefix string) []string {
+	var results []string
+
+	for _, item := range environ {
+		name, _, _ := strings.Cut(item, "=")
+		if name == "" || !strings.HasPrefix(name, prefix) || isDeclaredVariable(declared, name) {
+			continue
+		}
+
+		results = append(results, name)
+	}
+
+	slices.Sort(results)
+
+	return slices.Compact(results)
+}
+
+// isDeclaredVariable checks if the name is declared, or matches a declared pattern.
+func isDeclaredVariable(declared []string, name string) bool {
+	return slices.ContainsFunc(declared, func(item string) bool {
+		if variable, ok := strings.CutSuffix(item, prefixVariableSuffix); ok {
+			return len(name) > len(variable)+1 && strings.HasPrefix(name, variable+"_")
+		}
+
+		if variable, ok := strings.CutSuffix(item, indexedVariableSuffix); ok {
+			return isIndexedVariableName(name, variable)
+		}
+
+		return item == name
+	})
+}
+
+// CheckUnknownVariables reports environment variables which start with the prefix but are not consumed
+// by the config struct, e.g. typos like APP_TIMEOUTE which silently fall back to defaults.
+// The error suggests the closest declared variable name if any. Use os.Environ() for the process environment.
+func CheckUnknownVariables(config any, environ []string, prefix string) error {
+	declared, err := ListVariables(config)
+	if err != nil {
+		return err
+	}
+
+	unknowns := FindUnknownVariables(declared, environ, prefix)
+	errs := make([]error, len(unknowns))
+	candidates := slices.DeleteFunc(slices.Clone(declared), func(item string) bool {
+		return strings.HasSuffix(item, prefixVariableSuffix) || strings.HasSuffix(item, indexedVariableSuffix)
+	})
+
+	for i, name := range unknowns {
+		hint := ""
+		if suggestion := SuggestVariableName(name, candidates); suggestion != "" {
+			hint = "did you mean " + suggestion + "?"
+		}
+
+		errs[i] = withVariableName(&name, NewValidationFailedError("the environment variable is not consumed by the config", hint))
+	}
+
+	return errors.Join(errs...)
+}
+
+// SuggestVariableName returns the closest candidate to the variable name within the edit distance of 2,
+// e.g. APP_TIMEOUT for APP_TIMEOUTE, or an empty string if no candidate is close enough.
+func SuggestVariableName(name string, candidates []string) string {
+	result := ""
+	minDistance := maxSuggestionDistance + 1
+
+	for _, candidate := range candidates {
+		distance := levenshteinDistance(name, candidate)
+		if distance < minDistance {
+			result = candidate
+			minDistance = distance
+		}
+	}
+
+	return result
+}
+
+// levenshteinDistance calculates the edit distance between two strings.
+func levenshteinDistance(source string, target string) int {
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
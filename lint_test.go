@@ -0,0 +1,113 @@
+package goenvconf
+
+import (
+	"testing"
+)
+
+func TestListVariables(t *testing.T) {
+	config := binderConfig{
+		Server: binderServerConfig{
+			Host: NewEnvStringVariable("SERVER_HOST").WithDeprecatedAlias("HOST"),
+			Port: NewEnvIntVariable("SERVER_PORT"),
+		},
+		Debug:  toPtr(NewEnvBoolVariable("DEBUG")),
+		Ratio:  NewEnvFloatValue(1),
+		Limits: NewEnvMapIntVariable("SERVER_PORT"),
+	}
+
+	result, err := ListVariables(&config)
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"DEBUG", "HOST", "SERVER_HOST", "SERVER_PORT"}, result)
+
+	_, err = ListVariables("foo")
+	assertDeepEqual(t, ErrInvalidBindTarget, err)
+}
+
+func TestFindUnknownVariables(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Declared []string
+		Environ  []string
+		Prefix   string
+		Expected []string
+	}{
+		{
+			Name:     "prefix",
+			Declared: []string{"APP_TIMEOUT"},
+			Environ:  []string{"APP_TIMEOUT=1", "APP_TIMEOUTE=2", "HOME=/root", "APP_B=", "APP_A=x=y"},
+			Prefix:   "APP_",
+			Expected: []string{"APP_A", "APP_B", "APP_TIMEOUTE"},
+		},
+		{
+			Name:     "no_prefix",
+			Declared: []string{"HOME"},
+			Environ:  []string{"HOME=/root", "=C:", "PATH=/bin"},
+			Expected: []string{"PATH"},
+		},
+		{
+			Name:     "none",
+			Declared: []string{"APP_TIMEOUT"},
+			Environ:  []string{"APP_TIMEOUT=1"},
+			Prefix:   "APP_",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertDeepEqual(t, tc.Expected, FindUnknownVariables(tc.Declared, tc.Environ, tc.Prefix))
+		})
+	}
+}
+
+func TestCheckUnknownVariables(t *testing.T) {
+	config := binderServerConfig{
+		Host: NewEnvStringVariable("APP_HOST"),
+		Port: NewEnvIntVariable("APP_TIMEOUT"),
+	}
+
+	err := CheckUnknownVariables(config, []string{"APP_HOST=localhost", "APP_TIMEOUTE=10", "APP_SECRET=x", "HOME=/root"}, "APP_")
+	assertDeepEqual(t, `APP_SECRET: ValidationFailed: the environment variable is not consumed by the config
+APP_TIMEOUTE: ValidationFailed: the environment variable is not consumed by the config. Hint: did you mean APP_TIMEOUT?`, err.Error())
+
+	assertNilError(t, CheckUnknownVariables(config, []string{"APP_HOST=localhost"}, "APP_"))
+
+	type scanConfig struct {
+		Headers EnvMapString
+		List    EnvStringSlice
+	}
+
+	scanned := scanConfig{Headers: NewEnvMapStringVariable("APP_HDR").WithPrefixScan(nil)}
+
+	declared, err := ListVariables(scanned)
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"APP_HDR", "APP_HDR_*"}, declared)
+
+	err = CheckUnknownVariables(scanned, []string{"APP_HDR_A=1", "APP_HDR=a=1", "APP_HDR_=x", "APP_HDRX=1"}, "APP_")
+	assertDeepEqual(t, `APP_HDRX: ValidationFailed: the environment variable is not consumed by the config. Hint: did you mean APP_HDR?
+APP_HDR_: ValidationFailed: the environment variable is not consumed by the config. Hint: did you mean APP_HDR?`, err.Error())
+
+	indexed := scanConfig{List: NewEnvStringSliceVariable("APP_LST").WithIndexedVariables()}
+
+	declared, err = ListVariables(indexed)
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"APP_LST", "APP_LST_#"}, declared)
+
+	err = CheckUnknownVariables(indexed, []string{"APP_LST_0=a", "APP_LST_12=b", "APP_LST=a,b", "APP_LST_X=c"}, "APP_")
+	assertDeepEqual(t, `APP_LST_X: ValidationFailed: the environment variable is not consumed by the config. Hint: did you mean APP_LST?`, err.Error())
+	assertDeepEqual(t, ErrInvalidBindTarget, CheckUnknownVariables(nil, nil, ""))
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	assertDeepEqual(t, 0, levenshteinDistance("abc", "abc"))
+	assertDeepEqual(t, 1, levenshteinDistance("APP_TIMEOUT", "APP_TIMEOUTE"))
+	assertDeepEqual(t, 3, levenshteinDistance("", "abc"))
+	assertDeepEqual(t, 2, levenshteinDistance("abcd", "bacd"))
+}
+
+func TestSuggestVariableName(t *testing.T) {
+	candidates := []string{"APP_HOST", "APP_TIMEOUT"}
+
+	assertDeepEqual(t, "APP_TIMEOUT", SuggestVariableName("APP_TIMEOUTE", candidates))
+	assertDeepEqual(t, "APP_HOST", SuggestVariableName("APP_HOTS", candidates))
+	assertDeepEqual(t, "", SuggestVariableName("DATABASE_URL", candidates))
+}
@@ -0,0 +1,166 @@
+package goenvconf
+
+import (
+	"encoding"
+	"fmt"
+	"log/slog"
+	"reflect"
+)
+
+// The LogValue methods implement the [slog.LogValuer] interface. An Env instance is logged as a group
+// of the variable name and the literal value, e.g. env=PORT value=8080. Secret literal values are masked,
+// including those of variables registered by [RegisterSecretVariables].
+
+// envLogValue returns the log value of an Env instance.
+func envLogValue(variable *string, hasValue bool, options *envOptions, value func() slog.Value) slog.Value {
+	var attrs []slog.Attr
+
+	if variable != nil && *variable != "" {
+		attrs = append(attrs, slog.String(envObjectVariableKey, *variable))
+	}
+
+	if hasValue {
+		if isSecretEnv(variable, options) {
+			attrs = append(attrs, slog.String(envObjectValueKey, redactedValue))
+		} else {
+			attrs = append(attrs, slog.Attr{Key: envObjectValueKey, Value: value()})
+		}
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// ConfigLogValue returns the log value of a config struct. Env fields, including nested ones, are logged
+// by their LogValue methods so secret literal values stay masked. Other fields are logged as they are.
+func ConfigLogValue(config any) slog.Value {
+	value := reflect.Indirect(reflect.ValueOf(config))
+	if value.Kind() != reflect.Struct {
+		return slog.AnyValue(config)
+	}
+
+	return structLogValue(value)
+}
+
+var (
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	stringerType      = reflect.TypeFor[fmt.Stringer]()
+)
+
+func structLogValue(value reflect.Value) slog.Value {
+	valueType := value.Type()
+	attrs := make([]slog.Attr, 0, valueType.NumField())
+
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldValue := reflect.Indirect(value.Field(i))
+		if !fieldValue.IsValid() {
+			continue
+		}
+
+		fieldType := fieldValue.Type()
+
+		if fieldValue.Kind() == reflect.Struct && !isEnvType(fieldType) &&
+			!fieldType.Implements(textMarshalerType) && !fieldType.Implements(stringerType) {
+			attrs = append(attrs, slog.Attr{Key: field.Name, Value: structLogValue(fieldValue)})
+		} else {
+			attrs = append(attrs, slog.Any(field.Name, fieldValue.Interface()))
+		}
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvString) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.StringValue(*ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvInt) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.Int64Value(*ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvBool) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.BoolValue(*ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvFloat) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.Float64Value(*ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvAny) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.AnyValue(ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvStringSlice) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.AnyValue(ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvIntSlice) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.AnyValue(ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvFloatSlice) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.AnyValue(ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvBoolSlice) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.AnyValue(ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvMapString) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.AnyValue(ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvMapInt) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.AnyValue(ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvMapFloat) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.AnyValue(ev.Value)
+	})
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (ev EnvMapBool) LogValue() slog.Value {
+	return envLogValue(ev.Variable, ev.Value != nil, ev.options, func() slog.Value {
+		return slog.AnyValue(ev.Value)
+	})
+}
@@ -0,0 +1,90 @@
+package goenvconf
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogValue(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    slog.LogValuer
+		Expected string
+	}{
+		{Name: "variable", Input: NewEnvStringVariable("HOST"), Expected: "config.env=HOST"},
+		{Name: "value", Input: NewEnvIntValue(8080), Expected: "config.value=8080"},
+		{Name: "both", Input: NewEnvBool("DEBUG", true), Expected: "config.env=DEBUG config.value=true"},
+		{Name: "float", Input: NewEnvFloatValue(0.5), Expected: "config.value=0.5"},
+		{Name: "any", Input: NewEnvAnyValue(map[string]any{"a": 1}), Expected: "config.value=map[a:1]"},
+		{Name: "slice", Input: NewEnvStringSliceValue([]string{"a", "b"}), Expected: "config.value=\"[a b]\""},
+		{Name: "map", Input: NewEnvMapIntValue(map[string]int64{"a": 1}), Expected: "config.value=map[a:1]"},
+		{
+			Name:     "secret",
+			Input:    NewEnvString("API_KEY", "s3cr3t").Secret(),
+			Expected: "config.env=API_KEY config.value=[REDACTED]",
+		},
+		{
+			Name:     "secret_map",
+			Input:    NewEnvMapStringValue(map[string]string{"token": "s3cr3t"}).Secret(),
+			Expected: "config.value=[REDACTED]",
+		},
+		{Name: "zero", Input: EnvString{}, Expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertDeepEqual(t, tc.Expected, logText(slog.Any("config", tc.Input)))
+		})
+	}
+}
+
+func TestConfigLogValue(t *testing.T) {
+	config := struct {
+		Server struct {
+			Host EnvString
+			Port *EnvInt
+		}
+		APIKey  EnvString
+		Timeout time.Duration
+		Started time.Time
+		Debug   *EnvBool
+		secret  string
+	}{
+		APIKey:  NewEnvStringValue("s3cr3t").Secret(),
+		Timeout: time.Second,
+		Started: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		secret:  "s3cr3t",
+	}
+	config.Server.Host = NewEnvString("HOST", "localhost")
+	config.Server.Port = toPtr(NewEnvIntVariable("PORT"))
+
+	result := logText(slog.Any("config", ConfigLogValue(&config)))
+	assertDeepEqual(
+		t,
+		"config.Server.Host.env=HOST config.Server.Host.value=localhost config.Server.Port.env=PORT "+
+			"config.APIKey.value=[REDACTED] config.Timeout=1s config.Started=2024-01-02T03:04:05.000Z",
+		result,
+	)
+	assertDeepEqual(t, false, strings.Contains(result, "s3cr3t"))
+	assertDeepEqual(t, "config=foo", logText(slog.Any("config", ConfigLogValue("foo"))))
+}
+
+func logText(attr slog.Attr) string {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && (attr.Key == slog.TimeKey || attr.Key == slog.LevelKey || attr.Key == slog.MessageKey) {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	}))
+	logger.Info("", attr)
+
+	return strings.TrimSpace(buf.String())
+}
@@ -7,9 +7,13 @@ import (
 )
 
 // EnvMapString represents either a literal string map or an environment reference.
+// Get and GetCustom return a nil map without error if neither the variable is set nor the literal value exists,
+// unlike scalar and slice types. Use RequireValue to return errors instead.
 type EnvMapString struct {
-	Value    map[string]string `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string           `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    map[string]string `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string           `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvMapString creates an EnvMapString instance.
@@ -54,6 +58,23 @@ func (ev EnvMapString) Equal(target EnvMapString) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvMapString) Get() (map[string]string, error) {
+	result, err := ev.get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ev.options.checkRequiredMap(ev.Variable, result == nil); err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvMapString) get() (map[string]string, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue := os.Getenv(*ev.Variable)
 		if rawValue != "" {
@@ -61,11 +82,69 @@ func (ev EnvMapString) Get() (map[string]string, error) {
 		}
 	}
 
-	return ev.Value, nil
+	if ev.options.isExpansionDisabled() {
+		return ev.Value, nil
+	}
+
+	return expandStringMap(ev.Value, GetOSEnv)
+}
+
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty
+// and there is no literal value.
+func (ev EnvMapString) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue map[string]string) (map[string]string, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	if result == nil {
+		return defaultValue, nil
+	}
+
+	return result, nil
 }
 
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvMapString) GetCustom(getFunc GetEnvFunc) (map[string]string, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ev.options.checkRequiredMap(ev.Variable, result == nil); err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvMapString) getCustom(getFunc GetEnvFunc) (map[string]string, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	if ev.options.isPrefixScan() {
+		result, err := scanPrefixedMap(ev.Variable, getFunc, ev.options, "invalid string map syntax", func(value string) (string, error) {
+			return value, nil
+		})
+		if err != nil || result != nil {
+			return result, err
+		}
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue, err := getFunc(*ev.Variable)
 		if err != nil && !errors.Is(err, ErrEnvironmentVariableValueRequired) {
@@ -77,13 +156,21 @@ func (ev EnvMapString) GetCustom(getFunc GetEnvFunc) (map[string]string, error)
 		}
 	}
 
-	return ev.Value, nil
+	if ev.options.isExpansionDisabled() {
+		return ev.Value, nil
+	}
+
+	return expandStringMap(ev.Value, getFunc)
 }
 
 // EnvMapInt represents either a literal int map or an environment reference.
+// Get and GetCustom return a nil map without error if neither the variable is set nor the literal value exists,
+// unlike scalar and slice types. Use RequireValue to return errors instead.
 type EnvMapInt struct {
-	Value    map[string]int64 `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string          `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    map[string]int64 `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string          `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvMapInt creates an EnvMapInt instance.
@@ -128,18 +215,87 @@ func (ev EnvMapInt) Equal(target EnvMapInt) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvMapInt) Get() (map[string]int64, error) {
+	result, err := ev.get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ev.options.checkRequiredMap(ev.Variable, result == nil); err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvMapInt) get() (map[string]int64, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue := os.Getenv(*ev.Variable)
 		if rawValue != "" {
-			return ParseIntegerMapFromString[int64](rawValue)
+			return parseIntegerMapFromString(rawValue, ev.options.parseInt)
 		}
 	}
 
 	return ev.Value, nil
 }
 
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty
+// and there is no literal value.
+func (ev EnvMapInt) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue map[string]int64) (map[string]int64, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	if result == nil {
+		return defaultValue, nil
+	}
+
+	return result, nil
+}
+
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvMapInt) GetCustom(getFunc GetEnvFunc) (map[string]int64, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ev.options.checkRequiredMap(ev.Variable, result == nil); err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvMapInt) getCustom(getFunc GetEnvFunc) (map[string]int64, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	if ev.options.isPrefixScan() {
+		result, err := scanPrefixedMap(ev.Variable, getFunc, ev.options, "invalid integer map syntax", ev.options.parseInt)
+		if err != nil || result != nil {
+			return result, err
+		}
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue, err := getFunc(*ev.Variable)
 		if err != nil && !errors.Is(err, ErrEnvironmentVariableValueRequired) {
@@ -147,7 +303,7 @@ func (ev EnvMapInt) GetCustom(getFunc GetEnvFunc) (map[string]int64, error) {
 		}
 
 		if rawValue != "" {
-			return ParseIntegerMapFromString[int64](rawValue)
+			return parseIntegerMapFromString(rawValue, ev.options.parseInt)
 		}
 	}
 
@@ -155,9 +311,13 @@ func (ev EnvMapInt) GetCustom(getFunc GetEnvFunc) (map[string]int64, error) {
 }
 
 // EnvMapFloat represents either a literal float map or an environment reference.
+// Get and GetCustom return a nil map without error if neither the variable is set nor the literal value exists,
+// unlike scalar and slice types. Use RequireValue to return errors instead.
 type EnvMapFloat struct {
-	Value    map[string]float64 `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string            `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    map[string]float64 `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string            `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvMapFloat creates an EnvMapFloat instance.
@@ -202,6 +362,23 @@ func (ev EnvMapFloat) Equal(target EnvMapFloat) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvMapFloat) Get() (map[string]float64, error) {
+	result, err := ev.get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ev.options.checkRequiredMap(ev.Variable, result == nil); err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvMapFloat) get() (map[string]float64, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue := os.Getenv(*ev.Variable)
 		if rawValue != "" {
@@ -212,8 +389,60 @@ func (ev EnvMapFloat) Get() (map[string]float64, error) {
 	return ev.Value, nil
 }
 
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty
+// and there is no literal value.
+func (ev EnvMapFloat) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue map[string]float64) (map[string]float64, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	if result == nil {
+		return defaultValue, nil
+	}
+
+	return result, nil
+}
+
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvMapFloat) GetCustom(getFunc GetEnvFunc) (map[string]float64, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ev.options.checkRequiredMap(ev.Variable, result == nil); err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvMapFloat) getCustom(getFunc GetEnvFunc) (map[string]float64, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	if ev.options.isPrefixScan() {
+		result, err := scanPrefixedMap(ev.Variable, getFunc, ev.options, "invalid float map syntax", parseFloat[float64])
+		if err != nil || result != nil {
+			return result, err
+		}
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue, err := getFunc(*ev.Variable)
 		if err != nil && !errors.Is(err, ErrEnvironmentVariableValueRequired) {
@@ -229,9 +458,13 @@ func (ev EnvMapFloat) GetCustom(getFunc GetEnvFunc) (map[string]float64, error)
 }
 
 // EnvMapBool represents either a literal bool map or an environment reference.
+// Get and GetCustom return a nil map without error if neither the variable is set nor the literal value exists,
+// unlike scalar and slice types. Use RequireValue to return errors instead.
 type EnvMapBool struct {
-	Value    map[string]bool `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string         `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    map[string]bool `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string         `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvMapBool creates an EnvMapBool instance.
@@ -276,18 +509,87 @@ func (ev EnvMapBool) Equal(target EnvMapBool) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvMapBool) Get() (map[string]bool, error) {
+	result, err := ev.get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ev.options.checkRequiredMap(ev.Variable, result == nil); err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvMapBool) get() (map[string]bool, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue := os.Getenv(*ev.Variable)
 		if rawValue != "" {
-			return ParseBoolMapFromString(rawValue)
+			return parseBoolMapFromString(rawValue, ev.options.parseBool)
 		}
 	}
 
 	return ev.Value, nil
 }
 
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty
+// and there is no literal value.
+func (ev EnvMapBool) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue map[string]bool) (map[string]bool, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	if result == nil {
+		return defaultValue, nil
+	}
+
+	return result, nil
+}
+
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvMapBool) GetCustom(getFunc GetEnvFunc) (map[string]bool, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ev.options.checkRequiredMap(ev.Variable, result == nil); err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvMapBool) getCustom(getFunc GetEnvFunc) (map[string]bool, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	if ev.options.isPrefixScan() {
+		result, err := scanPrefixedMap(ev.Variable, getFunc, ev.options, "invalid boolean map syntax", ev.options.parseBool)
+		if err != nil || result != nil {
+			return result, err
+		}
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.Variable != nil && *ev.Variable != "" {
 		rawValue, err := getFunc(*ev.Variable)
 		if err != nil && !errors.Is(err, ErrEnvironmentVariableValueRequired) {
@@ -295,7 +597,7 @@ func (ev EnvMapBool) GetCustom(getFunc GetEnvFunc) (map[string]bool, error) {
 		}
 
 		if rawValue != "" {
-			return ParseBoolMapFromString(rawValue)
+			return parseBoolMapFromString(rawValue, ev.options.parseBool)
 		}
 	}
 
@@ -339,6 +339,7 @@ func TestEnvMapBool(t *testing.T) {
 
 func TestEnvMapInt(t *testing.T) {
 	t.Setenv("SOME_FOO", "foo=2;bar=3")
+	t.Setenv("SOME_JSON_FOO", `{"foo": 4}`)
 	testCases := []struct {
 		Input    EnvMapInt
 		Expected map[string]int64
@@ -359,6 +360,10 @@ func TestEnvMapInt(t *testing.T) {
 				"bar": 3,
 			},
 		},
+		{
+			Input:    NewEnvMapIntVariable("SOME_JSON_FOO"),
+			Expected: map[string]int64{"foo": 4},
+		},
 		{
 			Input:    EnvMapInt{},
 			Expected: nil,
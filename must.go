@@ -0,0 +1,208 @@
+package goenvconf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The MustGet and MustGetCustom methods are designed for initialization in main functions, tests and examples.
+// They panic with an error which wraps the resolution error, so recovered values work with errors.Is and errors.As.
+
+// mustResolve returns the value or panics with a descriptive error.
+func mustResolve[T any](value T, err error, variable *string) T {
+	if err == nil {
+		return value
+	}
+
+	if variable != nil && *variable != "" {
+		if strings.HasPrefix(err.Error(), *variable+":") {
+			panic(fmt.Errorf("goenvconf: failed to resolve the environment variable %w", err))
+		}
+
+		panic(fmt.Errorf("goenvconf: failed to resolve the environment variable %s: %w", *variable, err))
+	}
+
+	panic(fmt.Errorf("goenvconf: failed to resolve the value: %w", err))
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvString) MustGet() string {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvString) MustGetCustom(getFunc GetEnvFunc) string {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvInt) MustGet() int64 {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvInt) MustGetCustom(getFunc GetEnvFunc) int64 {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvBool) MustGet() bool {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvBool) MustGetCustom(getFunc GetEnvFunc) bool {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvFloat) MustGet() float64 {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvFloat) MustGetCustom(getFunc GetEnvFunc) float64 {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvAny) MustGet() any {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvAny) MustGetCustom(getFunc GetEnvFunc) any {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvStringSlice) MustGet() []string {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvStringSlice) MustGetCustom(getFunc GetEnvFunc) []string {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvIntSlice) MustGet() []int64 {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvIntSlice) MustGetCustom(getFunc GetEnvFunc) []int64 {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvFloatSlice) MustGet() []float64 {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvFloatSlice) MustGetCustom(getFunc GetEnvFunc) []float64 {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvBoolSlice) MustGet() []bool {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvBoolSlice) MustGetCustom(getFunc GetEnvFunc) []bool {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvMapString) MustGet() map[string]string {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvMapString) MustGetCustom(getFunc GetEnvFunc) map[string]string {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvMapInt) MustGet() map[string]int64 {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvMapInt) MustGetCustom(getFunc GetEnvFunc) map[string]int64 {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvMapFloat) MustGet() map[string]float64 {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvMapFloat) MustGetCustom(getFunc GetEnvFunc) map[string]float64 {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGet is like Get but panics if the value cannot be resolved.
+func (ev EnvMapBool) MustGet() map[string]bool {
+	result, err := ev.Get()
+
+	return mustResolve(result, err, ev.Variable)
+}
+
+// MustGetCustom is like GetCustom but panics if the value cannot be resolved.
+func (ev EnvMapBool) MustGetCustom(getFunc GetEnvFunc) map[string]bool {
+	result, err := ev.GetCustom(getFunc)
+
+	return mustResolve(result, err, ev.Variable)
+}
@@ -0,0 +1,52 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMustGet(t *testing.T) {
+	t.Setenv("MUST_PORT", "8080")
+
+	assertDeepEqual(t, int64(8080), NewEnvIntVariable("MUST_PORT").MustGet())
+	assertDeepEqual(t, "foo", NewEnvStringValue("foo").MustGetCustom(GetOSEnv))
+	assertDeepEqual(t, []bool{true}, NewEnvBoolSliceValue([]bool{true}).MustGet())
+	assertDeepEqual(t, map[string]float64{"a": 1}, NewEnvMapFloatValue(map[string]float64{"a": 1}).MustGetCustom(nil))
+
+	testCases := []struct {
+		Name     string
+		Call     func()
+		ErrorMsg string
+	}{
+		{
+			Name:     "variable",
+			Call:     func() { NewEnvIntVariable("MUST_MISSING").MustGet() },
+			ErrorMsg: "goenvconf: failed to resolve the environment variable MUST_MISSING: EmptyVar: the environment variable value is empty",
+		},
+		{
+			Name:     "zero",
+			Call:     func() { EnvString{}.MustGetCustom(GetOSEnv) },
+			ErrorMsg: "goenvconf: failed to resolve the value: EmptyEnv: require either value or env",
+		},
+		{
+			Name:     "parse",
+			Call:     func() { NewEnvIntVariable("MUST_PORT").WithMax(80).MustGet() },
+			ErrorMsg: "goenvconf: failed to resolve the environment variable MUST_PORT: ValidationFailed: the value must be less than or equal to 80. Hint: 8080",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			defer func() {
+				err, ok := recover().(error)
+				assertDeepEqual(t, true, ok)
+				assertDeepEqual(t, tc.ErrorMsg, err.Error())
+
+				var parseErr ParseEnvError
+				assertDeepEqual(t, true, errors.As(err, &parseErr))
+			}()
+
+			tc.Call()
+		})
+	}
+}
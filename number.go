@@ -0,0 +1,97 @@
+package goenvconf
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Number is the constraint of numeric types which [GetAs] converts values to.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// GetAs resolves the value of an EnvInt or EnvFloat instance from system environment,
+// and converts it to the numeric type with range checks, e.g. GetAs[uint16](port).
+// An error naming the variable is returned if the value overflows the type, or is fractional for integer types.
+func GetAs[T Number](ev EnvValue) (T, error) {
+	return GetCustomAs[T](ev, GetOSEnv)
+}
+
+// GetCustomAs resolves the value of an EnvInt or EnvFloat instance by a custom function,
+// and converts it to the numeric type with range checks.
+func GetCustomAs[T Number](ev EnvValue, getFunc GetEnvFunc) (T, error) {
+	var zero T
+
+	value, err := ev.Resolve(getFunc)
+	if err != nil {
+		return zero, err
+	}
+
+	result, err := convertNumber[T](value)
+	if err != nil {
+		variable := envVariableName(reflect.ValueOf(ev))
+
+		return zero, withVariableName(&variable, err)
+	}
+
+	return result, nil
+}
+
+// convertNumber converts an int64 or float64 value to the numeric type with range checks.
+func convertNumber[T Number](value any) (T, error) { //nolint:cyclop
+	var result T
+
+	target := reflect.ValueOf(&result).Elem()
+	overflowErr := NewParseEnvFailedError("the value overflows "+target.Type().String(), fmt.Sprint(value))
+
+	switch number := value.(type) {
+	case int64:
+		switch {
+		case target.CanInt():
+			if target.OverflowInt(number) {
+				return result, overflowErr
+			}
+
+			target.SetInt(number)
+		case target.CanUint():
+			if number < 0 || target.OverflowUint(uint64(number)) {
+				return result, overflowErr
+			}
+
+			target.SetUint(uint64(number))
+		default:
+			target.SetFloat(float64(number))
+		}
+	case float64:
+		if target.CanFloat() {
+			if !math.IsInf(number, 0) && !math.IsNaN(number) && target.OverflowFloat(number) {
+				return result, overflowErr
+			}
+
+			target.SetFloat(number)
+
+			return result, nil
+		}
+
+		if number != math.Trunc(number) {
+			return result, NewParseEnvFailedError("cannot convert a fractional value to "+target.Type().String(), fmt.Sprint(number))
+		}
+
+		integer, err := convertFloatToInt64(number)
+		if err != nil {
+			return result, overflowErr
+		}
+
+		return convertNumber[T](integer)
+	default:
+		return result, NewParseEnvFailedError(
+			fmt.Sprintf("cannot convert %T to %s", value, target.Type()),
+			"",
+		)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package goenvconf
+
+import (
+	"math"
+	"testing"
+)
+
+type portNumber uint16
+
+func TestGetAs(t *testing.T) {
+	t.Setenv("TEST_GET_AS_PORT", "8080")
+
+	port, err := GetAs[uint16](NewEnvIntVariable("TEST_GET_AS_PORT"))
+	assertNilError(t, err)
+	assertDeepEqual(t, uint16(8080), port)
+
+	_, err = GetAs[int8](NewEnvIntVariable("TEST_GET_AS_PORT"))
+	assertErrorContains(t, err, "TEST_GET_AS_PORT: ParseEnvFailed: the value overflows int8. Hint: 8080")
+
+	_, err = GetAs[int32](NewEnvIntVariable("TEST_GET_AS_MISSING"))
+	assertErrorContains(t, err, "TEST_GET_AS_MISSING: EmptyVar")
+
+	testCases := []struct {
+		Name     string
+		Call     func() (any, error)
+		Expected any
+		ErrorMsg string
+	}{
+		{
+			Name:     "named",
+			Call:     func() (any, error) { return GetCustomAs[portNumber](NewEnvIntValue(443), nil) },
+			Expected: portNumber(443),
+		},
+		{
+			Name:     "negative_uint",
+			Call:     func() (any, error) { return GetCustomAs[uint](NewEnvIntValue(-1), nil) },
+			ErrorMsg: "the value overflows uint. Hint: -1",
+		},
+		{
+			Name:     "int_to_float",
+			Call:     func() (any, error) { return GetCustomAs[float32](NewEnvIntValue(3), nil) },
+			Expected: float32(3),
+		},
+		{
+			Name:     "float32",
+			Call:     func() (any, error) { return GetCustomAs[float32](NewEnvFloatValue(0.5), nil) },
+			Expected: float32(0.5),
+		},
+		{
+			Name:     "float32_overflow",
+			Call:     func() (any, error) { return GetCustomAs[float32](NewEnvFloatValue(math.MaxFloat64), nil) },
+			ErrorMsg: "the value overflows float32",
+		},
+		{
+			Name:     "float_to_int",
+			Call:     func() (any, error) { return GetCustomAs[int32](NewEnvFloatValue(2), nil) },
+			Expected: int32(2),
+		},
+		{
+			Name:     "fractional",
+			Call:     func() (any, error) { return GetCustomAs[int32](NewEnvFloat("RATIO", 1.5), newBinderGetter(nil)) },
+			ErrorMsg: "RATIO: ParseEnvFailed: cannot convert a fractional value to int32. Hint: 1.5",
+		},
+		{
+			Name:     "float_overflow",
+			Call:     func() (any, error) { return GetCustomAs[uint8](NewEnvFloatValue(256), nil) },
+			ErrorMsg: "the value overflows uint8. Hint: 256",
+		},
+		{
+			Name:     "unsupported",
+			Call:     func() (any, error) { return GetCustomAs[int](NewEnvStringValue("1"), nil) },
+			ErrorMsg: "cannot convert string to int",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := tc.Call()
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, result)
+			}
+		})
+	}
+}
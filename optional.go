@@ -0,0 +1,291 @@
+package goenvconf
+
+import "errors"
+
+// OptionalState describes how the value of an [Optional] is resolved.
+type OptionalState int
+
+const (
+	// OptionalUnreferenced means the instance neither references a variable nor has a literal value.
+	OptionalUnreferenced OptionalState = iota
+	// OptionalUnset means the variable is unset and there is no literal value.
+	OptionalUnset
+	// OptionalEmpty means the variable is set to an empty string and there is no literal value.
+	OptionalEmpty
+	// OptionalPresent means the value is resolved from the variable or the literal value.
+	OptionalPresent
+)
+
+// String implements the fmt.Stringer interface.
+func (s OptionalState) String() string {
+	switch s {
+	case OptionalUnreferenced:
+		return "unreferenced"
+	case OptionalUnset:
+		return "unset"
+	case OptionalEmpty:
+		return "empty"
+	case OptionalPresent:
+		return "present"
+	default:
+		return "unknown"
+	}
+}
+
+// Optional is the result of the GetOptional methods, which distinguishes an unreferenced variable,
+// an unset variable, a variable set to an empty string and a resolved value without comparing errors.
+type Optional[T any] struct {
+	value T
+	state OptionalState
+}
+
+// State returns the state of the value.
+func (o Optional[T]) State() OptionalState {
+	return o.state
+}
+
+// IsPresent checks if the value is resolved.
+func (o Optional[T]) IsPresent() bool {
+	return o.state == OptionalPresent
+}
+
+// Get returns the value and whether it is resolved.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == OptionalPresent
+}
+
+// OrElse returns the value if resolved, otherwise the default value.
+func (o Optional[T]) OrElse(defaultValue T) T {
+	if o.state == OptionalPresent {
+		return o.value
+	}
+
+	return defaultValue
+}
+
+// The GetOptional methods resolve the value as an [Optional]. Unset and empty variables are reported
+// by the state instead of errors, while other errors, e.g. parse errors, are still returned.
+// The variable is set to an empty string if neither the variable nor its candidate variables, deprecated aliases or default variables
+// are set to a non-empty value, and at least one of them is set to an empty string.
+
+// getOptional resolves the value of an Env instance as an [Optional].
+func getOptional[T any](
+	variable *string,
+	hasValue bool,
+	options *envOptions,
+	getFunc GetEnvFunc,
+	get func() (T, error),
+) (Optional[T], error) {
+	if (variable == nil || *variable == "") && !hasValue {
+		return Optional[T]{state: OptionalUnreferenced}, nil
+	}
+
+	if !hasValue {
+		isEmpty, err := isVariableEmpty(variable, options, getFunc)
+		if err != nil {
+			return Optional[T]{}, err
+		}
+
+		if isEmpty {
+			return Optional[T]{state: OptionalEmpty}, nil
+		}
+	}
+
+	value, err := get()
+	if isUnsetResult(value, err) {
+		return Optional[T]{state: OptionalUnset}, nil
+	}
+
+	if err != nil {
+		return Optional[T]{}, err
+	}
+
+	return Optional[T]{value: value, state: OptionalPresent}, nil
+}
+
+// isVariableEmpty checks if the variable is set to an empty string, and none of its candidate variables,
+// deprecated aliases and default variables is set to a non-empty value.
+func isVariableEmpty(variable *string, options *envOptions, getFunc GetEnvFunc) (bool, error) {
+	if variable == nil || *variable == "" {
+		return false, nil
+	}
+
+	names := append([]string{*variable}, options.alternateNames()...)
+
+	var isEmpty bool
+
+	for _, name := range names {
+		value, err := getFunc(name)
+		if err != nil {
+			if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+				continue
+			}
+
+			return false, err
+		}
+
+		if value != "" {
+			return false, nil
+		}
+
+		isEmpty = true
+	}
+
+	return isEmpty, nil
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvString) GetOptional() (Optional[string], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvString) GetOptionalCustom(getFunc GetEnvFunc) (Optional[string], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() (string, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvInt) GetOptional() (Optional[int64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvInt) GetOptionalCustom(getFunc GetEnvFunc) (Optional[int64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() (int64, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvBool) GetOptional() (Optional[bool], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvBool) GetOptionalCustom(getFunc GetEnvFunc) (Optional[bool], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() (bool, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvFloat) GetOptional() (Optional[float64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvFloat) GetOptionalCustom(getFunc GetEnvFunc) (Optional[float64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() (float64, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvAny) GetOptional() (Optional[any], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvAny) GetOptionalCustom(getFunc GetEnvFunc) (Optional[any], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() (any, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvStringSlice) GetOptional() (Optional[[]string], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvStringSlice) GetOptionalCustom(getFunc GetEnvFunc) (Optional[[]string], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() ([]string, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvIntSlice) GetOptional() (Optional[[]int64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvIntSlice) GetOptionalCustom(getFunc GetEnvFunc) (Optional[[]int64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() ([]int64, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvFloatSlice) GetOptional() (Optional[[]float64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvFloatSlice) GetOptionalCustom(getFunc GetEnvFunc) (Optional[[]float64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() ([]float64, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvBoolSlice) GetOptional() (Optional[[]bool], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvBoolSlice) GetOptionalCustom(getFunc GetEnvFunc) (Optional[[]bool], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() ([]bool, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvMapString) GetOptional() (Optional[map[string]string], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvMapString) GetOptionalCustom(getFunc GetEnvFunc) (Optional[map[string]string], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() (map[string]string, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvMapInt) GetOptional() (Optional[map[string]int64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvMapInt) GetOptionalCustom(getFunc GetEnvFunc) (Optional[map[string]int64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() (map[string]int64, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvMapFloat) GetOptional() (Optional[map[string]float64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvMapFloat) GetOptionalCustom(getFunc GetEnvFunc) (Optional[map[string]float64], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() (map[string]float64, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
+
+// GetOptional resolves the value from system environment as an [Optional].
+func (ev EnvMapBool) GetOptional() (Optional[map[string]bool], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, GetOSEnv, ev.Get)
+}
+
+// GetOptionalCustom resolves the value by a custom function as an [Optional].
+func (ev EnvMapBool) GetOptionalCustom(getFunc GetEnvFunc) (Optional[map[string]bool], error) {
+	return getOptional(ev.Variable, ev.Value != nil, ev.options, getFunc, func() (map[string]bool, error) {
+		return ev.GetCustom(getFunc)
+	})
+}
@@ -0,0 +1,88 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetOptional(t *testing.T) {
+	t.Setenv("TEST_OPTIONAL_PORT", "8080")
+	t.Setenv("TEST_OPTIONAL_EMPTY", "")
+	t.Setenv("TEST_OPTIONAL_INVALID", "abc")
+
+	testCases := []struct {
+		Name     string
+		Input    EnvInt
+		State    OptionalState
+		Expected int64
+	}{
+		{Name: "unreferenced", Input: EnvInt{}, State: OptionalUnreferenced},
+		{Name: "unset", Input: NewEnvIntVariable("TEST_OPTIONAL_MISSING"), State: OptionalUnset},
+		{Name: "empty", Input: NewEnvIntVariable("TEST_OPTIONAL_EMPTY"), State: OptionalEmpty},
+		{Name: "present", Input: NewEnvIntVariable("TEST_OPTIONAL_PORT"), State: OptionalPresent, Expected: 8080},
+		{Name: "literal", Input: NewEnvIntValue(1), State: OptionalPresent, Expected: 1},
+		{Name: "empty_literal", Input: NewEnvInt("TEST_OPTIONAL_EMPTY", 1), State: OptionalPresent, Expected: 1},
+		{
+			Name:     "candidate",
+			Input:    NewEnvIntVariable("TEST_OPTIONAL_EMPTY").WithCandidateVariables("TEST_OPTIONAL_PORT"),
+			State:    OptionalPresent,
+			Expected: 8080,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := tc.Input.GetOptional()
+			assertNilError(t, err)
+			assertDeepEqual(t, tc.State, result.State())
+			assertDeepEqual(t, tc.State == OptionalPresent, result.IsPresent())
+
+			value, ok := result.Get()
+			assertDeepEqual(t, tc.Expected, value)
+			assertDeepEqual(t, tc.State == OptionalPresent, ok)
+		})
+	}
+
+	_, err := NewEnvIntVariable("TEST_OPTIONAL_INVALID").GetOptional()
+	assertErrorContains(t, err, `parsing "abc": invalid syntax`)
+}
+
+func TestGetOptionalCustom(t *testing.T) {
+	errGetter := errors.New("getter failed")
+	getFunc := func(name string) (string, error) {
+		switch name {
+		case "HOST":
+			return "", nil
+		case "LIMITS":
+			return "a=1", nil
+		case "BROKEN":
+			return "", errGetter
+		default:
+			return "", ErrEnvironmentVariableValueRequired
+		}
+	}
+
+	host, err := NewEnvStringVariable("HOST").GetOptionalCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, OptionalEmpty, host.State())
+	assertDeepEqual(t, "localhost", host.OrElse("localhost"))
+
+	limits, err := NewEnvMapIntVariable("LIMITS").GetOptionalCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"a": 1}, limits.OrElse(nil))
+
+	missing, err := NewEnvMapIntVariable("MISSING").GetOptionalCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, OptionalUnset, missing.State())
+
+	_, err = NewEnvStringSliceVariable("BROKEN").GetOptionalCustom(getFunc)
+	assertDeepEqual(t, true, errors.Is(err, errGetter))
+}
+
+func TestOptionalState_String(t *testing.T) {
+	assertDeepEqual(t, "unreferenced", OptionalUnreferenced.String())
+	assertDeepEqual(t, "unset", OptionalUnset.String())
+	assertDeepEqual(t, "empty", OptionalEmpty.String())
+	assertDeepEqual(t, "present", OptionalPresent.String())
+	assertDeepEqual(t, "unknown", OptionalState(-1).String())
+}
@@ -0,0 +1,356 @@
+package goenvconf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// envOptions holds optional behaviors of an Env instance.
+// It is never serialized and is ignored by the Equal methods.
+type envOptions struct {
+	// compact enables the compact encoding form, e.g. "${VAR}" instead of {"env": "VAR"}.
+	compact bool
+	// noExpansion disables the placeholder expansion of literal values.
+	noExpansion bool
+	// template renders literal values as value templates instead of expanding placeholders.
+	template bool
+	// strict enables the strict resolution mode.
+	strict bool
+	// skipEmpty removes empty items of comma-separated slice values before parsing.
+	skipEmpty bool
+	// alternateBases accepts 0x, 0o and 0b prefixes and digit underscores in integer values.
+	alternateBases bool
+	// sizeSuffixes accepts size suffixes such as k, M and Gi in integer values.
+	sizeSuffixes bool
+	// base64 decodes the environment value from base64 before parsing.
+	base64 bool
+	// lenientBool accepts operator-friendly boolean spellings such as yes, no, on and off.
+	lenientBool bool
+	// indexed populates slices from numbered variables such as VAR_0, VAR_1.
+	indexed bool
+	// prefixScan assembles maps from all environment variables whose names start with the variable name and "_".
+	prefixScan bool
+	// normalizeKey converts the suffix of a prefix-scanned variable name to the map key.
+	normalizeKey func(string) string
+	// envLister lists the variable names of the getter source for prefix scans.
+	envLister EnvLister
+	// jsonPointer extracts the value at the JSON pointer from the JSON document of the variable.
+	jsonPointer string
+	// unmarshalAny decodes the environment value of EnvAny instead of the JSON decoder.
+	unmarshalAny func(in []byte, out any) error
+	// rawStringFallback returns the raw environment value of EnvAny if it cannot be decoded.
+	rawStringFallback bool
+	// secret masks the literal value in logs and formatted output.
+	secret bool
+	// orElse resolves the alternative instance if the value is unset.
+	orElse func(getFunc GetEnvFunc) (any, error)
+	// orElseVariables are variable names of alternative instances.
+	orElseVariables []string
+	// requireMap returns errors instead of nil maps if the map value is not resolved.
+	requireMap bool
+	// candidates are alternate variable names which are looked up in order if the variable is unset or empty.
+	candidates []string
+	// aliases are deprecated variable names which are looked up if the variable is unset or empty.
+	aliases []string
+	// defaultVariables are variable names which are looked up in order after candidate variables and deprecated aliases.
+	defaultVariables []string
+	// errs are errors of conflicting options, which are reported by the Validate methods.
+	errs []error
+	// transforms run on the resolved value before validators.
+	transforms []func(value any) (any, error)
+	// validators run on the resolved value.
+	validators []func(value any) error
+}
+
+// clone returns a copy of the options so the source instance is never mutated.
+func (eo *envOptions) clone() *envOptions {
+	if eo == nil {
+		return &envOptions{}
+	}
+
+	result := *eo
+
+	return &result
+}
+
+// isCompact checks if the compact encoding form is enabled.
+func (eo *envOptions) isCompact() bool {
+	return eo != nil && eo.compact
+}
+
+// isExpansionDisabled checks if the placeholder expansion of literal values is disabled.
+func (eo *envOptions) isExpansionDisabled() bool {
+	return eo != nil && eo.noExpansion
+}
+
+// isTemplate checks if literal values are rendered as value templates.
+func (eo *envOptions) isTemplate() bool {
+	return eo != nil && eo.template
+}
+
+// isSecret checks if the literal value must be masked in logs and formatted output.
+func (eo *envOptions) isSecret() bool {
+	return eo != nil && eo.secret
+}
+
+// isStrict checks if the strict resolution mode is enabled.
+func (eo *envOptions) isStrict() bool {
+	return eo != nil && eo.strict
+}
+
+// usesGetFunc checks if the resolution must go through the getter, e.g. to look up deprecated aliases.
+func (eo *envOptions) usesGetFunc() bool {
+	return eo != nil &&
+		(eo.strict || eo.base64 || eo.indexed || eo.prefixScan || eo.jsonPointer != "" || eo.hasAlternateNames() ||
+			eo.orElse != nil)
+}
+
+// wrapGetFunc wraps the getter with the lookup behaviors of the options.
+func (eo *envOptions) wrapGetFunc(variable *string, getFunc GetEnvFunc) GetEnvFunc {
+	if eo == nil || variable == nil || *variable == "" {
+		return getFunc
+	}
+
+	if eo.hasAlternateNames() {
+		getFunc = eo.wrapAliases(*variable, getFunc)
+	}
+
+	if eo.base64 {
+		getFunc = wrapBase64Decoding(*variable, getFunc)
+	}
+
+	if eo.jsonPointer != "" {
+		getFunc = wrapJSONPointer(*variable, eo.jsonPointer, getFunc)
+	}
+
+	return getFunc
+}
+
+// hasAlternateNames checks if the options have candidate variables, deprecated aliases or default variables.
+func (eo *envOptions) hasAlternateNames() bool {
+	return eo != nil && (len(eo.candidates) > 0 || len(eo.aliases) > 0 || len(eo.defaultVariables) > 0)
+}
+
+// alternateNames returns candidate variables, deprecated aliases and default variables in the lookup order.
+func (eo *envOptions) alternateNames() []string {
+	if !eo.hasAlternateNames() {
+		return nil
+	}
+
+	results := make([]string, 0, len(eo.candidates)+len(eo.aliases)+len(eo.defaultVariables))
+	results = append(results, eo.candidates...)
+	results = append(results, eo.aliases...)
+
+	return append(results, eo.defaultVariables...)
+}
+
+// wrapAliases wraps the getter to look up candidate variables, deprecated aliases and default variables in order
+// if the variable is unset.
+func (eo *envOptions) wrapAliases(variable string, getFunc GetEnvFunc) GetEnvFunc {
+	return func(name string) (string, error) {
+		value, err := getFunc(name)
+		if name != variable || !eo.isUnsetValue(value, err) {
+			return value, err
+		}
+
+		candidate, candidateValue, candidateErr := eo.lookupFirstSet(eo.candidates, getFunc)
+		if candidateErr != nil {
+			return "", candidateErr
+		}
+
+		if candidate != "" {
+			return candidateValue, nil
+		}
+
+		alias, aliasValue, aliasErr := eo.lookupFirstSet(eo.aliases, getFunc)
+		if aliasErr != nil {
+			return "", aliasErr
+		}
+
+		if alias != "" {
+			notifyDeprecatedVariable(alias, name)
+
+			return aliasValue, nil
+		}
+
+		defaultVariable, defaultValue, defaultErr := eo.lookupFirstSet(eo.defaultVariables, getFunc)
+		if defaultErr != nil {
+			return "", defaultErr
+		}
+
+		if defaultVariable != "" {
+			return defaultValue, nil
+		}
+
+		return value, err
+	}
+}
+
+// lookupFirstSet returns the name and value of the first variable which is set, or an empty name if none is set.
+func (eo *envOptions) lookupFirstSet(names []string, getFunc GetEnvFunc) (string, string, error) {
+	for _, name := range names {
+		value, err := getFunc(name)
+		if err != nil && !errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return "", "", err
+		}
+
+		if !eo.isUnsetValue(value, err) {
+			return name, value, nil
+		}
+	}
+
+	return "", "", nil
+}
+
+// isUnsetValue checks if the getter result is unset. Empty values are also unset unless the strict mode is enabled.
+func (eo *envOptions) isUnsetValue(value string, err error) bool {
+	if err != nil {
+		return errors.Is(err, ErrEnvironmentVariableValueRequired)
+	}
+
+	return value == "" && !eo.isStrict()
+}
+
+// validate runs the validators on the resolved value. The error is prefixed with the variable name if set.
+// Validation errors of secret values omit the hint, which is usually the value, and other errors are redacted.
+func (eo *envOptions) validate(variable *string, value any) error {
+	if eo == nil {
+		return nil
+	}
+
+	for _, validator := range eo.validators {
+		err := validator(value)
+		if err == nil {
+			continue
+		}
+
+		if isSecretEnv(variable, eo) {
+			if pee, ok := err.(ParseEnvError); ok { //nolint:errorlint
+				pee.Hint = ""
+				err = pee
+			} else {
+				err = redactError(err)
+			}
+		}
+
+		return withVariableName(variable, err)
+	}
+
+	return nil
+}
+
+// withValidator returns a copy of the options with the validator appended.
+func withValidator[T any](eo *envOptions, validator func(T) error) *envOptions {
+	result := eo.clone()
+	result.validators = append(result.validators[:len(result.validators):len(result.validators)], func(value any) error {
+		typedValue, _ := value.(T)
+
+		return validator(typedValue)
+	})
+
+	return result
+}
+
+// sliceInput removes empty items of the comma-separated input if the skip-empty option is enabled.
+func (eo *envOptions) sliceInput(input string) string {
+	if eo == nil || !eo.skipEmpty {
+		return input
+	}
+
+	return skipEmptySliceItems(input)
+}
+
+// parseInt parses an int64 value. The base is implied by the prefix if alternate bases are enabled.
+func (eo *envOptions) parseInt(value string) (int64, error) {
+	base := 10
+	if eo != nil && eo.alternateBases {
+		base = 0
+	}
+
+	if eo != nil && eo.sizeSuffixes {
+		return parseIntWithSizeSuffix(value, base)
+	}
+
+	return strconv.ParseInt(value, base, 64)
+}
+
+// parseBool parses a boolean value, accepting operator-friendly spellings if the lenient mode is enabled.
+func (eo *envOptions) parseBool(value string) (bool, error) {
+	if eo != nil && eo.lenientBool {
+		return ParseBoolLenient(value)
+	}
+
+	return strconv.ParseBool(value)
+}
+
+// parseAny decodes the environment value of EnvAny with the unmarshaler of the options, or JSON by default.
+func (eo *envOptions) parseAny(value string) (any, error) {
+	unmarshal := json.Unmarshal
+	if eo != nil && eo.unmarshalAny != nil {
+		unmarshal = eo.unmarshalAny
+	}
+
+	var result any
+
+	err := unmarshal([]byte(value), &result)
+	if err != nil && eo != nil && eo.rawStringFallback {
+		return value, nil
+	}
+
+	return result, err
+}
+
+// withError returns a copy of the options with the error of conflicting options appended.
+func withError(eo *envOptions, err error) *envOptions {
+	result := eo.clone()
+	result.errs = append(result.errs[:len(result.errs):len(result.errs)], err)
+
+	return result
+}
+
+// withTransform returns a copy of the options with the transform appended.
+func withTransform[T any](eo *envOptions, transform func(T) (T, error)) *envOptions {
+	result := eo.clone()
+	result.transforms = append(result.transforms[:len(result.transforms):len(result.transforms)], func(value any) (any, error) {
+		typedValue, _ := value.(T)
+
+		return transform(typedValue)
+	})
+
+	return result
+}
+
+// resolveResult runs the transforms and validators of the options on the resolved value.
+func resolveResult[T any](eo *envOptions, variable *string, value T) (T, error) {
+	var zero T
+
+	if eo == nil {
+		return value, nil
+	}
+
+	for _, transform := range eo.transforms {
+		result, err := transform(value)
+		if err != nil {
+			return zero, withVariableName(variable, err)
+		}
+
+		value, _ = result.(T)
+	}
+
+	if err := eo.validate(variable, value); err != nil {
+		return zero, err
+	}
+
+	return value, nil
+}
+
+// withVariableName prefixes the error with the variable name if set.
+func withVariableName(variable *string, err error) error {
+	if variable != nil && *variable != "" {
+		return fmt.Errorf("%s: %w", *variable, err)
+	}
+
+	return err
+}
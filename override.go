@@ -0,0 +1,90 @@
+package goenvconf
+
+import "sync"
+
+// OverrideStore is a layer of runtime overrides which is consulted before the underlying getter,
+// so tests and admin endpoints can override individual variables without mutating the process environment.
+// It is safe for concurrent use. Use the GetEnv method as the [GetEnvFunc] of Env types and binders.
+type OverrideStore struct {
+	getFunc   GetEnvFunc
+	mu        sync.RWMutex
+	overrides map[string]*string
+}
+
+// NewOverrideStore creates an [OverrideStore] with the underlying getter. The getter defaults to [GetOSEnv] if nil.
+func NewOverrideStore(getFunc GetEnvFunc) *OverrideStore {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return &OverrideStore{
+		getFunc:   getFunc,
+		overrides: map[string]*string{},
+	}
+}
+
+// Set overrides the value of the variable.
+func (s *OverrideStore) Set(key string, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.overrides[key] = &value
+}
+
+// Unset overrides the variable as unset, even if the underlying getter has its value.
+func (s *OverrideStore) Unset(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.overrides[key] = nil
+}
+
+// Reset removes the override of the variable, so its value comes from the underlying getter again.
+func (s *OverrideStore) Reset(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.overrides, key)
+}
+
+// Clear removes all overrides.
+func (s *OverrideStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.overrides)
+}
+
+// Overrides returns a copy of overridden variables with their values. Unset variables are excluded.
+func (s *OverrideStore) Overrides() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := make(map[string]string, len(s.overrides))
+
+	for key, value := range s.overrides {
+		if value != nil {
+			results[key] = *value
+		}
+	}
+
+	return results
+}
+
+// GetEnv returns the overridden value of the variable, or the value of the underlying getter if not overridden.
+// It returns [ErrEnvironmentVariableValueRequired] if the variable is overridden as unset.
+func (s *OverrideStore) GetEnv(name string) (string, error) {
+	s.mu.RLock()
+	value, ok := s.overrides[name]
+	s.mu.RUnlock()
+
+	if !ok {
+		return s.getFunc(name)
+	}
+
+	if value == nil {
+		return "", ErrEnvironmentVariableValueRequired
+	}
+
+	return *value, nil
+}
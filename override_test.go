@@ -0,0 +1,86 @@
+package goenvconf
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestOverrideStore(t *testing.T) {
+	store := NewOverrideStore(newBinderGetter(map[string]string{"HOST": "localhost", "PORT": "8080"}))
+
+	host := NewEnvStringVariable("HOST")
+	port := NewEnvIntVariable("PORT")
+
+	result, err := host.GetCustom(store.GetEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, "localhost", result)
+
+	store.Set("HOST", "example.com")
+	store.Set("PORT", "9090")
+
+	result, err = host.GetCustom(store.GetEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, "example.com", result)
+
+	portValue, err := port.GetCustom(store.GetEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(9090), portValue)
+
+	store.Unset("HOST")
+
+	_, err = host.GetCustom(store.GetEnv)
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+	assertDeepEqual(t, map[string]string{"PORT": "9090"}, store.Overrides())
+
+	store.Reset("HOST")
+
+	result, err = host.GetCustom(store.GetEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, "localhost", result)
+
+	store.Clear()
+	assertDeepEqual(t, map[string]string{}, store.Overrides())
+
+	portValue, err = port.GetCustom(store.GetEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(8080), portValue)
+}
+
+func TestOverrideStore_OSEnv(t *testing.T) {
+	t.Setenv("OVERRIDE_STORE_HOST", "localhost")
+
+	store := NewOverrideStore(nil)
+
+	result, err := store.GetEnv("OVERRIDE_STORE_HOST")
+	assertNilError(t, err)
+	assertDeepEqual(t, "localhost", result)
+}
+
+func TestOverrideStore_Concurrent(t *testing.T) {
+	store := NewOverrideStore(newBinderGetter(map[string]string{}))
+
+	var wg sync.WaitGroup
+
+	for i := range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			key := "KEY_" + strconv.Itoa(i)
+			store.Set(key, strconv.Itoa(i))
+
+			value, err := store.GetEnv(key)
+			assertNilError(t, err)
+			assertDeepEqual(t, strconv.Itoa(i), value)
+
+			store.Unset(key)
+			_ = store.Overrides()
+		}()
+	}
+
+	wg.Wait()
+	assertDeepEqual(t, map[string]string{}, store.Overrides())
+}
@@ -0,0 +1,88 @@
+package goenvconf
+
+import (
+	"reflect"
+	"sync"
+)
+
+// envField is an Env field of a config struct with its dotted path.
+type envField struct {
+	path  string
+	value reflect.Value
+}
+
+// envFieldResult is the result of an Env field which is resolved ahead of binding.
+type envFieldResult struct {
+	value any
+	err   error
+	// resolved is the traced field if the binder has a report.
+	resolved ResolvedField
+	// dependencies are the variables which the traced field depends on.
+	dependencies envDependencies
+}
+
+// WithConcurrency returns a copy of the binder which resolves Env fields concurrently with at most limit goroutines,
+// e.g. when every lookup is a round trip to a remote secret store. The getter must be safe for concurrent use.
+// Errors are still reported in the field order. Fields are resolved serially if the limit is less than 2.
+func (b Binder) WithConcurrency(limit int) *Binder {
+	b.concurrency = limit
+
+	return &b
+}
+
+// collectEnvFields returns Env fields of the struct recursively in the field order. Nil pointers are skipped.
+func collectEnvFields(value reflect.Value) []envField {
+	var results []envField
+
+	walkEnvFields(value, "", func(path string, field reflect.Value) {
+		results = append(results, envField{path: path, value: field})
+	})
+
+	return results
+}
+
+// resolveEnvFields resolves the Env fields with the concurrency limit of the binder. Results are in the field order.
+func (b Binder) resolveEnvFields(fields []envField, cache *parseCache) []envFieldResult {
+	results := make([]envFieldResult, len(fields))
+
+	runConcurrently(len(fields), b.concurrency, func(i int) {
+		results[i], _ = b.resolveEnv(fields[i].value.Interface(), fields[i].path, cache)
+	})
+
+	return results
+}
+
+// runConcurrently calls the function for every index in [0, size) with at most limit goroutines.
+// The function runs serially if the limit is less than 2.
+func runConcurrently(size int, limit int, fn func(i int)) {
+	if limit < 2 || size < 2 {
+		for i := range size {
+			fn(i)
+		}
+
+		return
+	}
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(limit, size) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := range size {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,135 @@
+package goenvconf
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type parallelConfig struct {
+	A EnvString
+	B EnvInt
+	C EnvStringSlice
+	D struct {
+		E EnvBool
+		F *EnvInt
+	}
+}
+
+type parallelTarget struct {
+	A string
+	B int
+	C []string
+	D struct {
+		E bool
+		F *int
+	}
+}
+
+// newSlowGetter creates a getter of the map which sleeps on every call and records the maximum number of concurrent calls.
+func newSlowGetter(values map[string]string, maxActive *atomic.Int32) GetEnvFunc {
+	var active atomic.Int32
+
+	getFunc := newBinderGetter(values)
+
+	return func(name string) (string, error) {
+		current := active.Add(1)
+		defer active.Add(-1)
+
+		for {
+			previous := maxActive.Load()
+			if current <= previous || maxActive.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+
+		return getFunc(name)
+	}
+}
+
+func TestBinder_WithConcurrency(t *testing.T) {
+	var config parallelConfig
+
+	config.A = NewEnvStringVariable("A")
+	config.B = NewEnvIntVariable("B")
+	config.C = NewEnvStringSliceVariable("C")
+	config.D.E = NewEnvBoolVariable("E")
+	config.D.F = toPtr(NewEnvIntVariable("F"))
+
+	values := map[string]string{"A": "a", "B": "1", "C": "x,y", "E": "true", "F": "2"}
+
+	var maxActive atomic.Int32
+
+	binder := NewBinder(newSlowGetter(values, &maxActive)).WithConcurrency(3)
+
+	var target parallelTarget
+
+	assertNilError(t, binder.Bind(&target, config))
+	assertDeepEqual(t, "a", target.A)
+	assertDeepEqual(t, 1, target.B)
+	assertDeepEqual(t, []string{"x", "y"}, target.C)
+	assertDeepEqual(t, true, target.D.E)
+	assertDeepEqual(t, toPtr(2), target.D.F)
+	assertDeepEqual(t, true, maxActive.Load() > 1 && maxActive.Load() <= 3)
+
+	resolved, err := binder.Resolve(config)
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"A", "B", "C", "D.E", "D.F"}, resolvedPaths(resolved))
+
+	values["B"] = "x"
+	values["F"] = "y"
+
+	err = binder.Bind(&target, config)
+	assertErrorContains(t, err, "B (B): ")
+	assertErrorContains(t, err, "D.F (F): ")
+
+	err = binder.ValidateOnly(config)
+	assertErrorContains(t, err, "B (B): ")
+	assertErrorContains(t, err, "D.F (F): ")
+
+	var errs ConfigErrors
+
+	assertDeepEqual(t, true, errors.As(err, &errs))
+	assertDeepEqual(t, 2, len(errs))
+	assertDeepEqual(t, "B", errs[0].Path)
+	assertDeepEqual(t, "D.F", errs[1].Path)
+}
+
+func resolvedPaths(resolved *ResolvedConfig) []string {
+	var results []string
+
+	for _, field := range resolved.Fields() {
+		results = append(results, field.Path)
+	}
+
+	return results
+}
+
+func TestRunConcurrently(t *testing.T) {
+	for _, limit := range []int{0, 1, 4, 100} {
+		t.Run(strconv.Itoa(limit), func(t *testing.T) {
+			var (
+				mu      sync.Mutex
+				indexes []int
+			)
+
+			results := make([]int, 10)
+
+			runConcurrently(len(results), limit, func(i int) {
+				mu.Lock()
+				indexes = append(indexes, i)
+				mu.Unlock()
+
+				results[i] = i * i
+			})
+
+			assertDeepEqual(t, 10, len(indexes))
+			assertDeepEqual(t, []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}, results)
+		})
+	}
+}
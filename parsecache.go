@@ -0,0 +1,137 @@
+package goenvconf
+
+import (
+	"reflect"
+	"sync"
+)
+
+// parseCache caches resolved values of slice and map instances during one resolution pass of a [Binder],
+// so a large variable which is referenced by many fields, e.g. a FEATURE_FLAGS map, is parsed only once.
+// Entries are keyed by the Env type, the variable name and the raw value. Only instances without options
+// are cached, because options such as transforms and validators change the resolved value.
+// Cached values are copied on every hit, so fields never share the same slice or map.
+// It is safe for concurrent use.
+type parseCache struct {
+	mu     sync.Mutex
+	values map[parseCacheKey]any
+}
+
+type parseCacheKey struct {
+	envType  reflect.Type
+	variable string
+	rawValue string
+}
+
+// parseCacheable is implemented by Env types whose resolved values can be cached by the [parseCache].
+type parseCacheable interface {
+	EnvValue
+
+	// cacheableVariable returns the variable name if the instance can be cached, or nil otherwise.
+	cacheableVariable() *string
+}
+
+func newParseCache() *parseCache {
+	return &parseCache{
+		values: map[parseCacheKey]any{},
+	}
+}
+
+// resolve resolves the Env value, or returns a copy of the cached value if the same variable with
+// the same raw value has been resolved for the same type. The cache may be nil.
+func (pc *parseCache) resolve(ev EnvValue, getFunc GetEnvFunc) (any, error) {
+	cacheable, ok := ev.(parseCacheable)
+	if pc == nil || !ok {
+		return ev.Resolve(getFunc)
+	}
+
+	variable := cacheable.cacheableVariable()
+	if variable == nil || *variable == "" {
+		return ev.Resolve(getFunc)
+	}
+
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	rawValue, err := getFunc(*variable)
+
+	// The fetched value is reused by the resolution, so the getter is called once for the variable.
+	getFunc = withFetchedValue(getFunc, *variable, rawValue, err)
+
+	if err != nil || rawValue == "" {
+		return ev.Resolve(getFunc)
+	}
+
+	key := parseCacheKey{envType: reflect.TypeOf(ev), variable: *variable, rawValue: rawValue}
+
+	pc.mu.Lock()
+	cached, ok := pc.values[key]
+	pc.mu.Unlock()
+
+	if ok {
+		return cloneResolvedValue(cached), nil
+	}
+
+	result, err := ev.Resolve(getFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	pc.mu.Lock()
+	pc.values[key] = cloneResolvedValue(result)
+	pc.mu.Unlock()
+
+	return result, nil
+}
+
+// withFetchedValue wraps the getter to return the fetched value and error of the variable without calling the getter again.
+func withFetchedValue(getFunc GetEnvFunc, variable string, rawValue string, fetchErr error) GetEnvFunc {
+	return func(name string) (string, error) {
+		if name == variable {
+			return rawValue, fetchErr
+		}
+
+		return getFunc(name)
+	}
+}
+
+// cacheableVariable returns the variable name if the instance has no options.
+func cacheableVariable(variable *string, options *envOptions) *string {
+	if options != nil {
+		return nil
+	}
+
+	return variable
+}
+
+func (ev EnvStringSlice) cacheableVariable() *string {
+	return cacheableVariable(ev.Variable, ev.options)
+}
+
+func (ev EnvIntSlice) cacheableVariable() *string {
+	return cacheableVariable(ev.Variable, ev.options)
+}
+
+func (ev EnvFloatSlice) cacheableVariable() *string {
+	return cacheableVariable(ev.Variable, ev.options)
+}
+
+func (ev EnvBoolSlice) cacheableVariable() *string {
+	return cacheableVariable(ev.Variable, ev.options)
+}
+
+func (ev EnvMapString) cacheableVariable() *string {
+	return cacheableVariable(ev.Variable, ev.options)
+}
+
+func (ev EnvMapInt) cacheableVariable() *string {
+	return cacheableVariable(ev.Variable, ev.options)
+}
+
+func (ev EnvMapFloat) cacheableVariable() *string {
+	return cacheableVariable(ev.Variable, ev.options)
+}
+
+func (ev EnvMapBool) cacheableVariable() *string {
+	return cacheableVariable(ev.Variable, ev.options)
+}
@@ -0,0 +1,146 @@
+package goenvconf
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseCache(t *testing.T) {
+	var lookups int
+
+	values := map[string]string{"FEATURE_FLAGS": "a=true;b=false", "IDS": "1,2,3"}
+	getFunc := func(name string) (string, error) {
+		lookups++
+
+		return newBinderGetter(values)(name)
+	}
+
+	cache := newParseCache()
+	flags := NewEnvMapBoolVariable("FEATURE_FLAGS")
+
+	result, err := cache.resolve(flags, getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]bool{"a": true, "b": false}, result)
+	assertDeepEqual(t, 1, lookups)
+
+	result.(map[string]bool)["a"] = false
+
+	result, err = cache.resolve(flags, getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]bool{"a": true, "b": false}, result)
+	assertDeepEqual(t, 2, lookups)
+	assertDeepEqual(t, 1, len(cache.values))
+
+	_, err = cache.resolve(NewEnvMapStringVariable("FEATURE_FLAGS"), getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, 2, len(cache.values))
+
+	// the raw value is a part of the key.
+	values["FEATURE_FLAGS"] = "a=false"
+
+	result, err = cache.resolve(flags, getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]bool{"a": false}, result)
+	assertDeepEqual(t, 3, len(cache.values))
+
+	testCases := []struct {
+		Name  string
+		Input EnvValue
+	}{
+		{Name: "options", Input: NewEnvIntSliceVariable("IDS").WithMinItems(1)},
+		{Name: "literal", Input: NewEnvIntSliceValue([]int64{1})},
+		{Name: "unset", Input: NewEnvIntSliceVariable("MISSING")},
+		{Name: "scalar", Input: NewEnvStringVariable("IDS")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, _ = cache.resolve(tc.Input, getFunc)
+			assertDeepEqual(t, 3, len(cache.values))
+		})
+	}
+
+	// Unset variables are fetched once as well.
+	lookups = 0
+
+	_, err = cache.resolve(NewEnvIntSlice("MISSING", []int64{1}), getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, 1, lookups)
+
+	var nilCache *parseCache
+
+	ids, err := nilCache.resolve(NewEnvIntSliceVariable("IDS"), getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{1, 2, 3}, ids)
+}
+
+func TestBinder_ParseCache(t *testing.T) {
+	type flagsConfig struct {
+		Primary   EnvMapBool
+		Secondary *EnvMapBool
+	}
+
+	type flagsTarget struct {
+		Primary   map[string]bool
+		Secondary map[string]bool
+	}
+
+	config := flagsConfig{
+		Primary:   NewEnvMapBoolVariable("FEATURE_FLAGS"),
+		Secondary: toPtr(NewEnvMapBoolVariable("FEATURE_FLAGS")),
+	}
+
+	var target flagsTarget
+
+	assertNilError(t, NewBinder(newBinderGetter(map[string]string{"FEATURE_FLAGS": "a=true"})).Bind(&target, config))
+	assertDeepEqual(t, flagsTarget{Primary: map[string]bool{"a": true}, Secondary: map[string]bool{"a": true}}, target)
+
+	target.Primary["a"] = false
+	assertDeepEqual(t, map[string]bool{"a": true}, target.Secondary)
+}
+
+func newBenchmarkFeatureFlags() string {
+	items := make([]string, 2000)
+
+	for i := range items {
+		items[i] = "feature_" + strconv.Itoa(i) + "=" + strconv.FormatBool(i%2 == 0)
+	}
+
+	return strings.Join(items, ";")
+}
+
+func BenchmarkParseCache(b *testing.B) {
+	getFunc := newBinderGetter(map[string]string{"FEATURE_FLAGS": newBenchmarkFeatureFlags()})
+	fields := make([]EnvMapBool, 10)
+
+	for i := range fields {
+		fields[i] = NewEnvMapBoolVariable("FEATURE_FLAGS")
+	}
+
+	b.Run("without_cache", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			for _, field := range fields {
+				if _, _, err := resolveEnvValue(field, getFunc, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("with_cache", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			cache := newParseCache()
+
+			for _, field := range fields {
+				if _, _, err := resolveEnvValue(field, getFunc, cache); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
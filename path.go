@@ -0,0 +1,42 @@
+package goenvconf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WithPathExpansion returns a copy of the instance which resolves the value as a file path:
+//   - A leading "~" is expanded to the home directory of the current user, e.g. "~/.config/app".
+//   - A relative path is resolved against the base directory, unless the base directory is empty.
+//
+// The path is cleaned after expansion. An empty value is returned as is.
+func (ev EnvString) WithPathExpansion(baseDir string) EnvString {
+	return ev.WithTransform(func(value string) (string, error) {
+		return ExpandPath(value, baseDir)
+	})
+}
+
+// ExpandPath expands a leading "~" of the path to the home directory of the current user,
+// and resolves a relative path against the base directory if the base directory is not empty.
+// Paths starting with "~user" are not expanded.
+func ExpandPath(path string, baseDir string) (string, error) {
+	if path == "" {
+		return path, nil
+	}
+
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", NewParseEnvFailedError("failed to expand the home directory", err.Error())
+		}
+
+		path = homeDir + path[1:]
+	}
+
+	if baseDir != "" && !filepath.IsAbs(path) && !strings.HasPrefix(path, "~") {
+		path = filepath.Join(baseDir, path)
+	}
+
+	return filepath.Clean(path), nil
+}
@@ -0,0 +1,51 @@
+package goenvconf
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandPath(t *testing.T) {
+	t.Setenv("HOME", "/home/app")
+
+	testCases := []struct {
+		Name     string
+		Path     string
+		BaseDir  string
+		Expected string
+	}{
+		{Name: "empty", Path: "", BaseDir: "/etc", Expected: ""},
+		{Name: "home", Path: "~", Expected: "/home/app"},
+		{Name: "home_subdir", Path: "~/.config/app", BaseDir: "/etc", Expected: "/home/app/.config/app"},
+		{Name: "other_user", Path: "~root/app", Expected: "~root/app"},
+		{Name: "absolute", Path: "/var/lib/../app", BaseDir: "/etc", Expected: "/var/app"},
+		{Name: "relative", Path: "certs/tls.crt", BaseDir: "/etc/app", Expected: "/etc/app/certs/tls.crt"},
+		{Name: "relative_without_base_dir", Path: "./certs/tls.crt", Expected: "certs/tls.crt"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := ExpandPath(tc.Path, tc.BaseDir)
+			assertNilError(t, err)
+			assertDeepEqual(t, filepath.FromSlash(tc.Expected), result)
+		})
+	}
+}
+
+func TestEnvString_WithPathExpansion(t *testing.T) {
+	t.Setenv("HOME", "/home/app")
+
+	getFunc := newBinderGetter(map[string]string{"CERT_FILE": "tls.crt", "KEY_FILE": "~/tls.key"})
+
+	result, err := NewEnvStringVariable("CERT_FILE").WithPathExpansion("/etc/app").GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, filepath.FromSlash("/etc/app/tls.crt"), result)
+
+	result, err = NewEnvStringVariable("KEY_FILE").WithPathExpansion("/etc/app").GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, filepath.FromSlash("/home/app/tls.key"), result)
+
+	result, err = NewEnvString("CA_FILE", "ca.crt").WithPathExpansion("/etc/app").GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, filepath.FromSlash("/etc/app/ca.crt"), result)
+}
@@ -0,0 +1,194 @@
+package goenvconf
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// The WithJSONPointer methods extract the value at a JSON pointer (RFC 6901) from the JSON document of the variable,
+// so one JSON secret, e.g. APP_CONFIG='{"database": {"port": 5432}}', can feed many typed fields.
+// Strings are extracted as-is and other values are extracted in the JSON format, which slice, map and EnvAny types parse.
+// Missing paths and null values are treated as unset, so the literal value is used if set.
+
+func withJSONPointer(eo *envOptions, pointer string) *envOptions {
+	result := eo.clone()
+	result.jsonPointer = pointer
+
+	if !strings.HasPrefix(pointer, "/") {
+		result = withError(result, NewParseEnvFailedError("the JSON pointer must start with '/'", pointer))
+	}
+
+	return result
+}
+
+// wrapJSONPointer wraps the getter to extract the value at the JSON pointer from the value of the variable.
+func wrapJSONPointer(variable string, pointer string, getFunc GetEnvFunc) GetEnvFunc {
+	return func(name string) (string, error) {
+		value, err := getFunc(name)
+		if name != variable || err != nil || value == "" {
+			return value, err
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(value))
+		decoder.UseNumber()
+
+		var document any
+
+		if err := decoder.Decode(&document); err != nil {
+			return "", NewParseEnvFailedError("the environment variable value is not valid JSON", name)
+		}
+
+		result, err := lookupJSONPointer(document, pointer)
+		if err != nil {
+			return "", err
+		}
+
+		switch typedResult := result.(type) {
+		case nil:
+			return "", ErrEnvironmentVariableValueRequired
+		case string:
+			return typedResult, nil
+		case json.Number:
+			return typedResult.String(), nil
+		default:
+			var buf bytes.Buffer
+
+			encoder := json.NewEncoder(&buf)
+			encoder.SetEscapeHTML(false)
+
+			if err := encoder.Encode(typedResult); err != nil {
+				return "", err
+			}
+
+			return strings.TrimSuffix(buf.String(), "\n"), nil
+		}
+	}
+}
+
+// lookupJSONPointer returns the value at the JSON pointer in the decoded JSON document.
+// Returns [ErrEnvironmentVariableValueRequired] if the path does not exist.
+func lookupJSONPointer(document any, pointer string) (any, error) {
+	if pointer == "" {
+		return document, nil
+	}
+
+	current := document
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, ErrEnvironmentVariableValueRequired
+			}
+
+			current = value
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) || (len(token) > 1 && token[0] == '0') {
+				return nil, ErrEnvironmentVariableValueRequired
+			}
+
+			current = node[index]
+		default:
+			return nil, ErrEnvironmentVariableValueRequired
+		}
+	}
+
+	return current, nil
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvString) WithJSONPointer(pointer string) EnvString {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvInt) WithJSONPointer(pointer string) EnvInt {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvBool) WithJSONPointer(pointer string) EnvBool {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvFloat) WithJSONPointer(pointer string) EnvFloat {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvAny) WithJSONPointer(pointer string) EnvAny {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvStringSlice) WithJSONPointer(pointer string) EnvStringSlice {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvIntSlice) WithJSONPointer(pointer string) EnvIntSlice {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvFloatSlice) WithJSONPointer(pointer string) EnvFloatSlice {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvBoolSlice) WithJSONPointer(pointer string) EnvBoolSlice {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvMapString) WithJSONPointer(pointer string) EnvMapString {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvMapInt) WithJSONPointer(pointer string) EnvMapInt {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvMapFloat) WithJSONPointer(pointer string) EnvMapFloat {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
+
+// WithJSONPointer returns a copy of the instance which extracts the value at the JSON pointer, e.g. /database/port.
+func (ev EnvMapBool) WithJSONPointer(pointer string) EnvMapBool {
+	ev.options = withJSONPointer(ev.options, pointer)
+
+	return ev
+}
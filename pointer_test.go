@@ -0,0 +1,75 @@
+package goenvconf
+
+import (
+	"testing"
+)
+
+func TestWithJSONPointer(t *testing.T) {
+	t.Setenv("POINTER_CONFIG", `{
+		"database": {"host": "db", "port": 5432, "ssl": true, "ratio": 0.5, "big": 9007199254740993},
+		"hosts": ["a", "b"],
+		"limits": {"read": 1},
+		"html": {"q": "<a&b>"},
+		"a/b": {"m~n": "escaped"},
+		"nothing": null
+	}`)
+	t.Setenv("POINTER_INVALID", "{")
+
+	host, err := NewEnvStringVariable("POINTER_CONFIG").WithJSONPointer("/database/host").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "db", host)
+
+	port, err := NewEnvIntVariable("POINTER_CONFIG").WithJSONPointer("/database/port").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(5432), port)
+
+	big, err := NewEnvIntVariable("POINTER_CONFIG").WithJSONPointer("/database/big").Strict().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, int64(9007199254740993), big)
+
+	ssl, err := NewEnvBoolVariable("POINTER_CONFIG").WithJSONPointer("/database/ssl").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, true, ssl)
+
+	ratio, err := NewEnvFloatVariable("POINTER_CONFIG").WithJSONPointer("/database/ratio").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, 0.5, ratio)
+
+	hosts, err := NewEnvStringSliceVariable("POINTER_CONFIG").WithJSONPointer("/hosts").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a", "b"}, hosts)
+
+	second, err := NewEnvStringVariable("POINTER_CONFIG").WithJSONPointer("/hosts/1").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "b", second)
+
+	limits, err := NewEnvMapIntVariable("POINTER_CONFIG").WithJSONPointer("/limits").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"read": 1}, limits)
+
+	html, err := NewEnvAnyVariable("POINTER_CONFIG").WithJSONPointer("/html").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]any{"q": "<a&b>"}, html)
+
+	escaped, err := NewEnvStringVariable("POINTER_CONFIG").WithJSONPointer("/a~1b/m~0n").Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "escaped", escaped)
+
+	for _, pointer := range []string{"/missing", "/hosts/2", "/hosts/01", "/hosts/x", "/database/host/x", "/nothing"} {
+		value, err := NewEnvString("POINTER_CONFIG", "fallback").WithJSONPointer(pointer).Get()
+		assertNilError(t, err)
+		assertDeepEqual(t, "fallback", value)
+	}
+
+	_, err = NewEnvIntVariable("POINTER_CONFIG").WithJSONPointer("/missing").Get()
+	assertErrorContains(t, err, ErrEnvironmentVariableValueRequired.Error())
+
+	_, err = NewEnvMapStringVariable("POINTER_INVALID").WithJSONPointer("/a").Get()
+	assertErrorContains(t, err, "ParseEnvFailed: the environment variable value is not valid JSON. Hint: POINTER_INVALID")
+
+	assertErrorContains(
+		t,
+		NewEnvBoolSliceVariable("POINTER_CONFIG").WithJSONPointer("hosts").Validate(),
+		"ParseEnvFailed: the JSON pointer must start with '/'. Hint: hosts",
+	)
+}
@@ -0,0 +1,355 @@
+package goenvconf
+
+import (
+	"errors"
+	"maps"
+	"net/textproto"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// The WithPrefixScan methods treat the variable name as a prefix and assemble the map from all environment variables
+// whose names start with the prefix and an underscore, e.g. HEADERS_AUTHORIZATION and HEADERS_X_TENANT
+// for the HEADERS variable. Values are read through the getter and the literal value is used if no variable matches.
+//
+// Variable names are listed from the [EnvLister] of the WithEnvLister methods, [Binder.WithEnvLister]
+// or the listers of [NamedGetter]. Without a lister, names are listed from [os.Environ] only if the getter is [GetOSEnv]
+// or a getter of [SnapshotOSEnv], and the scan fails with [ErrPrefixScanUnsupported] for other getters,
+// because their variables cannot be enumerated.
+
+// ErrPrefixScanUnsupported occurs when a map scans prefixed variables through a getter whose variable names cannot be listed.
+var ErrPrefixScanUnsupported = errors.New("prefix scans require an EnvLister for getters other than GetOSEnv")
+
+// EnvLister lists the names of all variables of a getter source, e.g. [DotEnvFile], for prefix scans.
+type EnvLister interface {
+	EnvNames() []string
+}
+
+// EnvListerFunc adapts a function to the [EnvLister] interface.
+type EnvListerFunc func() []string
+
+// EnvNames calls the function.
+func (fn EnvListerFunc) EnvNames() []string {
+	return fn()
+}
+
+// KeyToLower converts the suffix of a prefix-scanned variable name to lower case, e.g. X_TENANT to x_tenant.
+func KeyToLower(key string) string {
+	return strings.ToLower(key)
+}
+
+// KeyToHeader converts the suffix of a prefix-scanned variable name to the canonical HTTP header format,
+// e.g. X_TENANT to X-Tenant.
+func KeyToHeader(key string) string {
+	return textproto.CanonicalMIMEHeaderKey(strings.ReplaceAll(key, "_", "-"))
+}
+
+// WithPrefixScan returns a copy of the instance which assembles the map from prefixed environment variables.
+// The suffix of variable names is converted to keys by the normalizer, or kept as-is if nil.
+func (ev EnvMapString) WithPrefixScan(normalizeKey func(string) string) EnvMapString {
+	ev.options = withPrefixScan(ev.options, normalizeKey)
+
+	return ev
+}
+
+// WithEnvLister returns a copy of the instance which lists variable names from the lister for prefix scans.
+func (ev EnvMapString) WithEnvLister(lister EnvLister) EnvMapString {
+	ev.options = withEnvLister(ev.options, lister)
+
+	return ev
+}
+
+func (ev EnvMapString) scanPrefix() string {
+	return scanPrefix(ev.Variable, ev.options)
+}
+
+func (ev EnvMapString) withDefaultEnvLister(lister EnvLister) EnvValue {
+	if ev.options.isPrefixScan() && ev.options.envLister == nil {
+		ev.options = withEnvLister(ev.options, lister)
+	}
+
+	return ev
+}
+
+// WithPrefixScan returns a copy of the instance which assembles the map from prefixed environment variables.
+// The suffix of variable names is converted to keys by the normalizer, or kept as-is if nil.
+func (ev EnvMapInt) WithPrefixScan(normalizeKey func(string) string) EnvMapInt {
+	ev.options = withPrefixScan(ev.options, normalizeKey)
+
+	return ev
+}
+
+// WithEnvLister returns a copy of the instance which lists variable names from the lister for prefix scans.
+func (ev EnvMapInt) WithEnvLister(lister EnvLister) EnvMapInt {
+	ev.options = withEnvLister(ev.options, lister)
+
+	return ev
+}
+
+func (ev EnvMapInt) scanPrefix() string {
+	return scanPrefix(ev.Variable, ev.options)
+}
+
+func (ev EnvMapInt) withDefaultEnvLister(lister EnvLister) EnvValue {
+	if ev.options.isPrefixScan() && ev.options.envLister == nil {
+		ev.options = withEnvLister(ev.options, lister)
+	}
+
+	return ev
+}
+
+// WithPrefixScan returns a copy of the instance which assembles the map from prefixed environment variables.
+// The suffix of variable names is converted to keys by the normalizer, or kept as-is if nil.
+func (ev EnvMapFloat) WithPrefixScan(normalizeKey func(string) string) EnvMapFloat {
+	ev.options = withPrefixScan(ev.options, normalizeKey)
+
+	return ev
+}
+
+// WithEnvLister returns a copy of the instance which lists variable names from the lister for prefix scans.
+func (ev EnvMapFloat) WithEnvLister(lister EnvLister) EnvMapFloat {
+	ev.options = withEnvLister(ev.options, lister)
+
+	return ev
+}
+
+func (ev EnvMapFloat) scanPrefix() string {
+	return scanPrefix(ev.Variable, ev.options)
+}
+
+func (ev EnvMapFloat) withDefaultEnvLister(lister EnvLister) EnvValue {
+	if ev.options.isPrefixScan() && ev.options.envLister == nil {
+		ev.options = withEnvLister(ev.options, lister)
+	}
+
+	return ev
+}
+
+// WithPrefixScan returns a copy of the instance which assembles the map from prefixed environment variables.
+// The suffix of variable names is converted to keys by the normalizer, or kept as-is if nil.
+func (ev EnvMapBool) WithPrefixScan(normalizeKey func(string) string) EnvMapBool {
+	ev.options = withPrefixScan(ev.options, normalizeKey)
+
+	return ev
+}
+
+// WithEnvLister returns a copy of the instance which lists variable names from the lister for prefix scans.
+func (ev EnvMapBool) WithEnvLister(lister EnvLister) EnvMapBool {
+	ev.options = withEnvLister(ev.options, lister)
+
+	return ev
+}
+
+func (ev EnvMapBool) scanPrefix() string {
+	return scanPrefix(ev.Variable, ev.options)
+}
+
+func (ev EnvMapBool) withDefaultEnvLister(lister EnvLister) EnvValue {
+	if ev.options.isPrefixScan() && ev.options.envLister == nil {
+		ev.options = withEnvLister(ev.options, lister)
+	}
+
+	return ev
+}
+
+func withPrefixScan(eo *envOptions, normalizeKey func(string) string) *envOptions {
+	result := eo.clone()
+	result.prefixScan = true
+	result.normalizeKey = normalizeKey
+
+	return result
+}
+
+func withEnvLister(eo *envOptions, lister EnvLister) *envOptions {
+	result := eo.clone()
+	result.envLister = lister
+
+	return result
+}
+
+// WithEnvLister returns a copy of the binder which lists variable names from the lister
+// for prefix-scanned maps without their own lister. See [EnvMapString.WithPrefixScan].
+// If nil, names are listed from [os.Environ] if the getter of the binder is [GetOSEnv].
+func (b Binder) WithEnvLister(lister EnvLister) *Binder {
+	b.envLister = lister
+
+	return &b
+}
+
+// withEnvLister sets the lister of the binder on the value if it is a prefix-scanned map without a lister.
+func (b Binder) withEnvLister(ev EnvValue) EnvValue {
+	setter, ok := ev.(envListerSetter)
+	if !ok {
+		return ev
+	}
+
+	lister := b.envLister
+	if lister == nil {
+		// The getter is wrapped by named getters and when values are traced, so names are listed explicitly.
+		var ok bool
+
+		if len(b.getters) > 0 {
+			lister, ok = namedGettersEnvLister(b.getters)
+		} else {
+			lister, ok = getterEnvLister(b.getFunc, nil)
+		}
+
+		if !ok {
+			return ev
+		}
+	}
+
+	return setter.withDefaultEnvLister(lister)
+}
+
+// getterEnvLister returns the lister, or the lister of the process environment if the getter reads it.
+// It returns false if the variable names of the getter cannot be listed.
+func getterEnvLister(getFunc GetEnvFunc, lister EnvLister) (EnvLister, bool) {
+	switch {
+	case lister != nil:
+		return lister, true
+	case isOSGetEnvFunc(getFunc):
+		return EnvListerFunc(osEnvNames), true
+	default:
+		return nil, false
+	}
+}
+
+// namedGettersEnvLister returns a lister of the union of variable names of the named getters.
+// It returns false if the names of any getter cannot be listed.
+func namedGettersEnvLister(getters []NamedGetter) (EnvLister, bool) {
+	listers := make([]EnvLister, len(getters))
+
+	for i, getter := range getters {
+		lister, ok := getterEnvLister(getter.GetEnv, getter.Lister)
+		if !ok {
+			return nil, false
+		}
+
+		listers[i] = lister
+	}
+
+	return EnvListerFunc(func() []string {
+		names := map[string]bool{}
+
+		for _, lister := range listers {
+			for _, name := range lister.EnvNames() {
+				names[name] = true
+			}
+		}
+
+		return slices.Sorted(maps.Keys(names))
+	}), true
+}
+
+// envListerSetter is implemented by map types, so binders can set their lister on prefix-scanned maps without one.
+type envListerSetter interface {
+	withDefaultEnvLister(lister EnvLister) EnvValue
+}
+
+// prefixScanner is implemented by map types, so watchers can resolve prefix-scanned maps again when variables with the prefix change.
+type prefixScanner interface {
+	// scanPrefix returns the prefix of scanned variable names, or an empty string if the prefix scan is disabled.
+	scanPrefix() string
+}
+
+func scanPrefix(variable *string, options *envOptions) string {
+	if !options.isPrefixScan() || variable == nil || *variable == "" {
+		return ""
+	}
+
+	return *variable + "_"
+}
+
+// isPrefixScan checks if the prefix-scanned map mode is enabled.
+func (eo *envOptions) isPrefixScan() bool {
+	return eo != nil && eo.prefixScan
+}
+
+// scanPrefixedMap assembles a map from environment variables with the prefix of the variable name.
+// Returns nil if no variable matches.
+func scanPrefixedMap[T any](
+	variable *string,
+	getFunc GetEnvFunc,
+	options *envOptions,
+	errorMessage string,
+	parse func(string) (T, error),
+) (map[string]T, error) {
+	if variable == nil || *variable == "" {
+		return nil, nil
+	}
+
+	var names []string
+
+	switch {
+	case options.envLister != nil:
+		names = options.envLister.EnvNames()
+	case isOSGetEnvFunc(getFunc):
+		names = osEnvNames()
+	default:
+		return nil, ErrPrefixScanUnsupported
+	}
+
+	prefix := *variable + "_"
+
+	var result map[string]T
+
+	for _, name := range names {
+		suffix, ok := strings.CutPrefix(name, prefix)
+		if !ok || suffix == "" {
+			continue
+		}
+
+		rawValue, err := getFunc(name)
+		if err != nil {
+			if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		key := suffix
+		if options.normalizeKey != nil {
+			key = options.normalizeKey(suffix)
+		}
+
+		value, err := parse(rawValue)
+		if err != nil {
+			return nil, NewParseEnvFailedError(errorMessage, key)
+		}
+
+		if result == nil {
+			result = map[string]T{}
+		}
+
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// isOSGetEnvFunc checks if the getter reads the process environment, i.e. [GetOSEnv] or a getter of [SnapshotOSEnv].
+func isOSGetEnvFunc(getFunc GetEnvFunc) bool {
+	if getFunc == nil {
+		return false
+	}
+
+	pointer := reflect.ValueOf(getFunc).Pointer()
+
+	return pointer == reflect.ValueOf(GetOSEnv).Pointer() || pointer == reflect.ValueOf(newSnapshotGetEnvFunc(nil)).Pointer()
+}
+
+func osEnvNames() []string {
+	environ := os.Environ()
+	results := make([]string, 0, len(environ))
+
+	for _, item := range environ {
+		name, _, _ := strings.Cut(item, "=")
+		results = append(results, name)
+	}
+
+	return results
+}
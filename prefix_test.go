@@ -0,0 +1,173 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithPrefixScan(t *testing.T) {
+	t.Setenv("SCAN_HEADERS_AUTHORIZATION", "Bearer token")
+	t.Setenv("SCAN_HEADERS_X_TENANT", " acme ")
+	t.Setenv("SCAN_HEADERS", "ignored=1")
+	t.Setenv("SCAN_HEADERS_", "ignored")
+	t.Setenv("SCAN_LIMITS_READ", "10")
+	t.Setenv("SCAN_LIMITS_WRITE", "0x20")
+	t.Setenv("SCAN_RATIOS_A", "0.5")
+	t.Setenv("SCAN_FLAGS_BETA", "on")
+	t.Setenv("SCAN_BAD_A", "x")
+
+	headers, err := NewEnvMapStringVariable("SCAN_HEADERS").WithPrefixScan(KeyToHeader).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]string{"Authorization": "Bearer token", "X-Tenant": " acme "}, headers)
+
+	headers, err = NewEnvMapStringVariable("SCAN_HEADERS").WithPrefixScan(nil).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]string{"AUTHORIZATION": "Bearer token", "X_TENANT": " acme "}, headers)
+
+	headers, err = NewEnvMapString("SCAN_MISSING", map[string]string{"a": "b"}).WithPrefixScan(nil).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]string{"a": "b"}, headers)
+
+	limits, err := NewEnvMapIntVariable("SCAN_LIMITS").WithPrefixScan(KeyToLower).WithAlternateBases().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"read": 10, "write": 32}, limits)
+
+	ratios, err := NewEnvMapFloatVariable("SCAN_RATIOS").WithPrefixScan(nil).Strict().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]float64{"A": 0.5}, ratios)
+
+	flags, err := NewEnvMapBoolVariable("SCAN_FLAGS").WithPrefixScan(KeyToLower).WithLenientBool().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]bool{"beta": true}, flags)
+
+	_, err = NewEnvMapIntVariable("SCAN_BAD").WithPrefixScan(nil).Get()
+	assertErrorContains(t, err, "ParseEnvFailed: invalid integer map syntax. Hint: A")
+
+	errGetter := errors.New("getter failed")
+	_, err = NewEnvMapStringVariable("SCAN_HEADERS").WithPrefixScan(nil).WithEnvLister(EnvListerFunc(osEnvNames)).
+		GetCustom(func(string) (string, error) {
+			return "", errGetter
+		})
+	assertDeepEqual(t, errGetter, err)
+}
+
+func TestWithPrefixScan_customGetter(t *testing.T) {
+	t.Setenv("SCAN_HEADERS_FROM_OS", "ignored")
+
+	getFunc := newBinderGetter(map[string]string{
+		"SCAN_HEADERS_AUTHORIZATION": "Bearer token",
+		"SCAN_HEADERS_X_TENANT":      "acme",
+		"SCAN_OTHER":                 "ignored",
+	})
+	lister := EnvListerFunc(func() []string {
+		return []string{"SCAN_HEADERS_AUTHORIZATION", "SCAN_HEADERS_X_TENANT", "SCAN_HEADERS_MISSING", "SCAN_OTHER"}
+	})
+	expected := map[string]string{"Authorization": "Bearer token", "X-Tenant": "acme"}
+
+	// The variable names of custom getters cannot be listed from the process environment.
+	_, err := NewEnvMapStringVariable("SCAN_HEADERS").WithPrefixScan(KeyToHeader).GetCustom(getFunc)
+	if !errors.Is(err, ErrPrefixScanUnsupported) {
+		t.Fatalf("expected ErrPrefixScanUnsupported, got %v", err)
+	}
+
+	headers, err := NewEnvMapStringVariable("SCAN_HEADERS").WithPrefixScan(KeyToHeader).WithEnvLister(lister).GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, expected, headers)
+
+	type config struct {
+		Headers EnvMapString
+	}
+
+	type target struct {
+		Headers map[string]string
+	}
+
+	source := config{Headers: NewEnvMapStringVariable("SCAN_HEADERS").WithPrefixScan(KeyToHeader)}
+
+	var result target
+
+	err = NewBinder(getFunc).Bind(&result, source)
+	assertErrorContains(t, err, ErrPrefixScanUnsupported.Error())
+
+	assertNilError(t, NewBinder(getFunc).WithEnvLister(lister).Bind(&result, source))
+	assertDeepEqual(t, expected, result.Headers)
+
+	_, err = NewBinder(getFunc).WithEnvLister(lister).BindWithReport(&result, source)
+	assertNilError(t, err)
+	assertDeepEqual(t, expected, result.Headers)
+
+	// Binders with the OS getter list the process environment, even if values are traced.
+	_, err = NewBinder(nil).BindWithReport(&result, source)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]string{"From-Os": "ignored"}, result.Headers)
+}
+
+func TestKeyNormalizers(t *testing.T) {
+	assertDeepEqual(t, "x_tenant", KeyToLower("X_TENANT"))
+	assertDeepEqual(t, "X-Tenant-Id", KeyToHeader("X_TENANT_ID"))
+	assertDeepEqual(t, "Authorization", KeyToHeader("authorization"))
+}
+
+func TestWithPrefixScan_osGetters(t *testing.T) {
+	t.Setenv("SCAN_OS_A", "1")
+	t.Setenv("SCAN_OS_B", "2")
+
+	type config struct {
+		Values EnvMapString
+	}
+
+	type target struct {
+		Values map[string]string
+	}
+
+	source := config{Values: NewEnvMapStringVariable("SCAN_OS").WithPrefixScan(nil)}
+	expected := map[string]string{"A": "1", "B": "2"}
+
+	t.Run("bind_os_env", func(t *testing.T) {
+		var result target
+
+		assertNilError(t, BindOSEnv(&result, source))
+		assertDeepEqual(t, expected, result.Values)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		var result target
+
+		assertNilError(t, NewBinder(SnapshotOSEnv()).Bind(&result, source))
+		assertDeepEqual(t, expected, result.Values)
+
+		values, err := source.Values.GetCustom(SnapshotOSEnv())
+		assertNilError(t, err)
+		assertDeepEqual(t, expected, values)
+	})
+
+	t.Run("named_os_getter", func(t *testing.T) {
+		var result target
+
+		_, err := NewBinder(nil).WithGetters(NamedGetter{Name: "os", GetEnv: GetOSEnv}).BindWithReport(&result, source)
+		assertNilError(t, err)
+		assertDeepEqual(t, expected, result.Values)
+	})
+
+	t.Run("named_getters_union", func(t *testing.T) {
+		var result target
+
+		binder := NewBinder(nil).WithGetters(
+			NamedGetter{
+				Name:   "overrides",
+				GetEnv: newBinderGetter(map[string]string{"SCAN_OS_C": "3"}),
+				Lister: EnvListerFunc(func() []string { return []string{"SCAN_OS_C"} }),
+			},
+			NamedGetter{Name: "os", GetEnv: GetOSEnv},
+		)
+
+		assertNilError(t, binder.Bind(&result, source))
+		assertDeepEqual(t, map[string]string{"A": "1", "B": "2", "C": "3"}, result.Values)
+
+		err := NewBinder(nil).WithGetters(
+			NamedGetter{Name: "vault", GetEnv: newBinderGetter(map[string]string{"SCAN_OS_C": "3"})},
+			NamedGetter{Name: "os", GetEnv: GetOSEnv},
+		).Bind(&result, source)
+		assertErrorContains(t, err, ErrPrefixScanUnsupported.Error())
+	})
+}
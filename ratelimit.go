@@ -0,0 +1,68 @@
+package goenvconf
+
+import (
+	"sync"
+	"time"
+)
+
+// NewRateLimitedGetEnvFunc creates a getter which limits calls of the inner getter with a token bucket,
+// so bursts of resolution, e.g. a hot reload of a large config, do not trip API throttling of the provider.
+// The bucket holds up to burst tokens and refills at requestsPerSecond. Calls wait in the arrival order
+// until a token is available instead of failing. The inner getter is returned as-is if requestsPerSecond is not positive.
+func NewRateLimitedGetEnvFunc(getFunc GetEnvFunc, requestsPerSecond float64, burst int) GetEnvFunc {
+	if requestsPerSecond <= 0 {
+		return getFunc
+	}
+
+	bucket := newTokenBucket(requestsPerSecond, burst, time.Now)
+
+	return func(name string) (string, error) {
+		if wait := bucket.reserve(); wait > 0 {
+			time.Sleep(wait)
+		}
+
+		return getFunc(name)
+	}
+}
+
+// tokenBucket is a token bucket rate limiter. Tokens may go negative, which queues later callers behind earlier ones.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now func() time.Time) *tokenBucket {
+	burst = max(burst, 1)
+
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now(),
+		now:    now,
+	}
+}
+
+// reserve takes a token and returns the duration to wait until the token is available.
+func (tb *tokenBucket) reserve() time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	now := tb.now()
+	if elapsed := now.Sub(tb.last); elapsed > 0 {
+		tb.tokens = min(tb.burst, tb.tokens+elapsed.Seconds()*tb.rate)
+		tb.last = now
+	}
+
+	tb.tokens--
+
+	if tb.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-tb.tokens / tb.rate * float64(time.Second))
+}
@@ -0,0 +1,51 @@
+package goenvconf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bucket := newTokenBucket(10, 2, func() time.Time { return now })
+
+	// the burst is available immediately, then calls queue 100ms apart.
+	assertDeepEqual(t, time.Duration(0), bucket.reserve())
+	assertDeepEqual(t, time.Duration(0), bucket.reserve())
+	assertDeepEqual(t, 100*time.Millisecond, bucket.reserve())
+	assertDeepEqual(t, 200*time.Millisecond, bucket.reserve())
+
+	// tokens refill over time up to the burst size.
+	now = now.Add(time.Second)
+
+	assertDeepEqual(t, time.Duration(0), bucket.reserve())
+	assertDeepEqual(t, time.Duration(0), bucket.reserve())
+	assertDeepEqual(t, 100*time.Millisecond, bucket.reserve())
+}
+
+func TestNewRateLimitedGetEnvFunc(t *testing.T) {
+	getFunc := NewRateLimitedGetEnvFunc(newBinderGetter(map[string]string{"FOO": "bar"}), 100, 1)
+	start := time.Now()
+
+	for range 3 {
+		value, err := getFunc("FOO")
+		assertNilError(t, err)
+		assertDeepEqual(t, "bar", value)
+	}
+
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("expected calls to wait for tokens, got %s", elapsed)
+	}
+
+	unlimited := NewRateLimitedGetEnvFunc(newBinderGetter(map[string]string{"FOO": "bar"}), 0, 1)
+	start = time.Now()
+
+	for range 3 {
+		_, err := unlimited("FOO")
+		assertNilError(t, err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 15*time.Millisecond {
+		t.Errorf("expected calls without a rate limit to not wait, got %s", elapsed)
+	}
+}
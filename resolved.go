@@ -0,0 +1,332 @@
+package goenvconf
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ValueSource describes where the value of a resolved field comes from.
+type ValueSource string
+
+const (
+	// SourceUnset means that neither a variable nor a literal value supplies the field.
+	SourceUnset ValueSource = "unset"
+	// SourceVariable means that an environment variable supplies the field.
+	SourceVariable ValueSource = "env"
+	// SourceLiteral means that the literal value supplies the field.
+	SourceLiteral ValueSource = "value"
+	// SourceError means that the field failed to resolve. It is only reported by [ResolutionReport].
+	SourceError ValueSource = "error"
+)
+
+// ResolvedField is the resolved value of an Env field in a [ResolvedConfig].
+type ResolvedField struct {
+	// Path is the field path in the config struct, e.g. Server.Port.
+	Path string
+	// Value is the resolved value, or nil if the field is unset.
+	Value any
+	// Source describes where the value comes from.
+	Source ValueSource
+	// Variable is the name of the environment variable which supplies the value if the source is a variable,
+	// e.g. a candidate variable if the primary variable is unset.
+	Variable string
+	// Getter is the name of the getter which supplies the variable, if the binder has named getters.
+	Getter string
+	// Err is the resolution error if the source is [SourceError].
+	Err error
+}
+
+// ResolvedConfig is an immutable snapshot of resolved Env fields of a config struct, created by [Binder.Resolve].
+// Values are copied when the snapshot is created and when they are read,
+// so the snapshot can be held safely while the environment or providers change.
+type ResolvedConfig struct {
+	resolvedAt time.Time
+	fields     []ResolvedField
+	indexes    map[string]int
+	// dependencies are the variables which the fields depend on, in the order of fields.
+	dependencies []envDependencies
+}
+
+// envDependencies are the variables which a resolved field depends on, so a [Watcher] only resolves the field again
+// if any of them changes. Names include every variable which is looked up, e.g. candidate variables,
+// placeholders of literal values and numbered variables of indexed slices. Prefixes are the prefixes of prefix-scanned maps,
+// which depend on variables that may not exist yet.
+type envDependencies struct {
+	names    []string
+	prefixes []string
+}
+
+// isAffected checks if any of the changed variables is a dependency.
+func (ed envDependencies) isAffected(changed []string) bool {
+	return slices.ContainsFunc(changed, func(name string) bool {
+		return slices.Contains(ed.names, name) || slices.ContainsFunc(ed.prefixes, func(prefix string) bool {
+			return strings.HasPrefix(name, prefix)
+		})
+	})
+}
+
+// ResolvedAt returns the time when the snapshot was resolved.
+func (rc *ResolvedConfig) ResolvedAt() time.Time {
+	return rc.resolvedAt
+}
+
+// Fields returns all resolved fields in the declaration order of the config struct.
+func (rc *ResolvedConfig) Fields() []ResolvedField {
+	results := make([]ResolvedField, len(rc.fields))
+
+	for i, field := range rc.fields {
+		results[i] = field
+		results[i].Value = cloneResolvedValue(field.Value)
+	}
+
+	return results
+}
+
+// Field returns the resolved field at the path, e.g. Server.Port.
+func (rc *ResolvedConfig) Field(path string) (ResolvedField, bool) {
+	index, ok := rc.indexes[path]
+	if !ok {
+		return ResolvedField{}, false
+	}
+
+	result := rc.fields[index]
+	result.Value = cloneResolvedValue(result.Value)
+
+	return result, true
+}
+
+// Get returns the resolved value of the field at the path. It returns false if the field does not exist or is unset.
+func (rc *ResolvedConfig) Get(path string) (any, bool) {
+	field, ok := rc.Field(path)
+	if !ok || field.Source == SourceUnset {
+		return nil, false
+	}
+
+	return field.Value, true
+}
+
+// Resolve resolves all Env fields of the config into an immutable [ResolvedConfig] snapshot,
+// which records the resolution time and the source of every field. Nil pointer fields are skipped.
+// All resolution errors are returned together as [ConfigErrors].
+func (b Binder) Resolve(config any) (*ResolvedConfig, error) {
+	return b.resolveConfig(config, nil, nil)
+}
+
+// resolveConfig resolves Env fields of the config into a snapshot. If the previous snapshot is not nil,
+// only fields which depend on any of the changed variables are resolved again, and others are copied from the previous snapshot.
+func (b Binder) resolveConfig(config any, previous *ResolvedConfig, changed []string) (*ResolvedConfig, error) {
+	value := reflect.Indirect(reflect.ValueOf(config))
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w, got %T", ErrInvalidBindTarget, config)
+	}
+
+	getFunc, err := b.prefetchGetFunc(value)
+	if err != nil {
+		return nil, err
+	}
+
+	b.getFunc = getFunc
+	result := &ResolvedConfig{
+		resolvedAt: time.Now(),
+		indexes:    map[string]int{},
+	}
+
+	var errs ConfigErrors
+
+	cache := newParseCache()
+	fields := collectEnvFields(value)
+	resolvedFields := make([]ResolvedField, len(fields))
+	dependencies := make([]envDependencies, len(fields))
+	reused := make([]bool, len(fields))
+
+	runConcurrently(len(fields), b.concurrency, func(i int) {
+		if previous != nil {
+			index, ok := previous.indexes[fields[i].path]
+			if ok && !previous.dependencies[index].isAffected(changed) {
+				resolvedFields[i], dependencies[i], reused[i] = previous.fields[index], previous.dependencies[index], true
+
+				return
+			}
+		}
+
+		ev, _ := fields[i].value.Interface().(EnvValue)
+
+		var raw envFieldResult
+
+		raw, resolvedFields[i] = b.traceEnvValue(ev, cache)
+		dependencies[i] = raw.dependencies
+	})
+
+	for i, field := range fields {
+		resolved := resolvedFields[i]
+		resolved.Path = field.path
+
+		if !reused[i] {
+			b.notifyResolve(field.value, resolved)
+		}
+
+		if resolved.Source == SourceError {
+			errs = append(errs, ConfigError{Path: field.path, Variable: envVariableName(field.value), Err: resolved.Err})
+
+			continue
+		}
+
+		result.indexes[field.path] = len(result.fields)
+		result.fields = append(result.fields, resolved)
+		result.dependencies = append(result.dependencies, dependencies[i])
+	}
+
+	if len(errs) > 0 {
+		return nil, errs.toError()
+	}
+
+	return result, nil
+}
+
+// traceEnvValue resolves the Env value by the getter of the binder, and returns the raw result with the resolved field,
+// whose source is detected by recording variables which are set and the named getters which supply them.
+func (b Binder) traceEnvValue(ev EnvValue, cache *parseCache) (envFieldResult, ResolvedField) {
+	ev = b.withEnvLister(ev)
+
+	var (
+		setVariables []string
+		dependencies envDependencies
+	)
+
+	if scanner, ok := ev.(prefixScanner); ok {
+		if prefix := scanner.scanPrefix(); prefix != "" {
+			dependencies.prefixes = []string{prefix}
+		}
+	}
+
+	getterNames := map[string]string{}
+
+	value, err := cache.resolve(ev, func(name string) (string, error) {
+		dependencies.names = append(dependencies.names, name)
+
+		result, getterName, err := b.lookup(name)
+		if err == nil && result != "" {
+			setVariables = append(setVariables, name)
+			getterNames[name] = getterName
+		}
+
+		return result, err
+	})
+
+	err = redactEnvError(ev, err)
+	raw := envFieldResult{value: value, err: err, dependencies: dependencies}
+
+	switch {
+	case errors.Is(err, ErrEnvironmentValueRequired):
+		return raw, ResolvedField{Source: SourceUnset}
+	case err != nil:
+		return raw, ResolvedField{Source: SourceError, Variable: envVariableName(reflect.ValueOf(ev)), Err: err}
+	}
+
+	variables := ev.Variables()
+
+	for _, name := range setVariables {
+		if slices.Contains(variables, name) || isScannedVariable(ev, name) {
+			return raw, ResolvedField{
+				Value:    cloneResolvedValue(value),
+				Source:   SourceVariable,
+				Variable: name,
+				Getter:   getterNames[name],
+			}
+		}
+	}
+
+	if literal, ok := ev.(interface{ HasValue() bool }); ok && !literal.HasValue() && isUnsetResult(value, nil) {
+		return raw, ResolvedField{Source: SourceUnset}
+	}
+
+	return raw, ResolvedField{Value: cloneResolvedValue(value), Source: SourceLiteral}
+}
+
+// isScannedVariable checks if the variable is a numbered variable of an indexed slice,
+// or a variable with the prefix of a prefix-scanned map.
+func isScannedVariable(ev EnvValue, name string) bool {
+	if indexed, ok := ev.(indexedSlice); ok && isIndexedVariableName(name, indexed.indexedVariable()) {
+		return true
+	}
+
+	scanner, ok := ev.(prefixScanner)
+	if !ok {
+		return false
+	}
+
+	prefix := scanner.scanPrefix()
+
+	return prefix != "" && strings.HasPrefix(name, prefix)
+}
+
+// cloneResolvedValue returns a deep copy of slices and maps in the resolved value.
+func cloneResolvedValue(value any) any {
+	switch typedValue := value.(type) {
+	case nil:
+		return nil
+	case []string:
+		return slices.Clone(typedValue)
+	case []int64:
+		return slices.Clone(typedValue)
+	case []float64:
+		return slices.Clone(typedValue)
+	case []bool:
+		return slices.Clone(typedValue)
+	case map[string]string:
+		return maps.Clone(typedValue)
+	case map[string]int64:
+		return maps.Clone(typedValue)
+	case map[string]float64:
+		return maps.Clone(typedValue)
+	case map[string]bool:
+		return maps.Clone(typedValue)
+	default:
+		return cloneReflectValue(reflect.ValueOf(value)).Interface()
+	}
+}
+
+func cloneReflectValue(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+
+		result := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+
+		for i := range value.Len() {
+			result.Index(i).Set(cloneReflectValue(value.Index(i)))
+		}
+
+		return result
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+
+		result := reflect.MakeMapWithSize(value.Type(), value.Len())
+
+		for iter := value.MapRange(); iter.Next(); {
+			result.SetMapIndex(iter.Key(), cloneReflectValue(iter.Value()))
+		}
+
+		return result
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+
+		result := reflect.New(value.Type()).Elem()
+		result.Set(cloneReflectValue(value.Elem()))
+
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,86 @@
+package goenvconf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBinder_Resolve(t *testing.T) {
+	config := binderConfig{
+		Server: binderServerConfig{
+			Host:    NewEnvString("SERVER_HOST", "localhost").WithCandidateVariables("HOST"),
+			Port:    NewEnvInt("SERVER_PORT", 8080),
+			Origins: NewEnvStringSliceVariable("ORIGINS"),
+		},
+		Limits: NewEnvMapIntValue(map[string]int64{"a": 1}),
+		Extra:  NewEnvAnyVariable("EXTRA"),
+	}
+
+	env := map[string]string{"HOST": "example.com", "ORIGINS": "a,b"}
+	startedAt := time.Now()
+
+	snapshot, err := NewBinder(newBinderGetter(env)).Resolve(&config)
+	assertNilError(t, err)
+	assertDeepEqual(t, false, snapshot.ResolvedAt().Before(startedAt))
+
+	assertDeepEqual(t, []ResolvedField{
+		{Path: "Server.Host", Value: "example.com", Source: SourceVariable, Variable: "HOST"},
+		{Path: "Server.Port", Value: int64(8080), Source: SourceLiteral},
+		{Path: "Server.Origins", Value: []string{"a", "b"}, Source: SourceVariable, Variable: "ORIGINS"},
+		{Path: "Ratio", Source: SourceUnset},
+		{Path: "Limits", Value: map[string]int64{"a": 1}, Source: SourceLiteral},
+		{Path: "Extra", Source: SourceUnset},
+		{Path: "Optional", Source: SourceUnset},
+		{Path: "Ignored", Source: SourceUnset},
+	}, snapshot.Fields())
+
+	field, ok := snapshot.Field("Server.Origins")
+	assertDeepEqual(t, true, ok)
+	assertDeepEqual(t, "ORIGINS", field.Variable)
+
+	_, ok = snapshot.Field("Server.Missing")
+	assertDeepEqual(t, false, ok)
+
+	_, ok = snapshot.Get("Ratio")
+	assertDeepEqual(t, false, ok)
+
+	// the snapshot is not affected by changes of the environment, the config, or returned values.
+	env["ORIGINS"] = "c"
+	config.Limits.Value["a"] = 2
+
+	origins, ok := snapshot.Get("Server.Origins")
+	assertDeepEqual(t, true, ok)
+	origins.([]string)[0] = "z"
+
+	origins, _ = snapshot.Get("Server.Origins")
+	assertDeepEqual(t, []string{"a", "b"}, origins)
+
+	limits, _ := snapshot.Get("Limits")
+	assertDeepEqual(t, map[string]int64{"a": 1}, limits)
+}
+
+func TestBinder_ResolveErrors(t *testing.T) {
+	config := binderConfig{
+		Server: binderServerConfig{
+			Host: NewEnvStringVariable("SERVER_HOST"),
+			Port: NewEnvIntVariable("SERVER_PORT"),
+		},
+	}
+
+	_, err := NewBinder(newBinderGetter(map[string]string{"SERVER_PORT": "abc"})).Resolve(config)
+	assertDeepEqual(t, `2 config errors:
+  - Server.Host: SERVER_HOST: EmptyVar: the environment variable value is empty
+  - Server.Port (SERVER_PORT): strconv.ParseInt: parsing "abc": invalid syntax`, err.Error())
+
+	_, err = NewBinder(nil).Resolve("foo")
+	assertErrorContains(t, err, ErrInvalidBindTarget.Error())
+}
+
+func TestCloneResolvedValue(t *testing.T) {
+	source := map[string]any{"a": []any{map[string]any{"b": 1}}}
+	result := cloneResolvedValue(source).(map[string]any)
+	result["a"].([]any)[0].(map[string]any)["b"] = 2
+
+	assertDeepEqual(t, map[string]any{"a": []any{map[string]any{"b": 1}}}, source)
+	assertDeepEqual(t, nil, cloneResolvedValue(nil))
+}
@@ -0,0 +1,223 @@
+package goenvconf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// The JSONSchema methods implement the custom schema interface of [jsonschema.Reflector], so generated schemas
+// express the contract of Env types accurately. An Env value is one of:
+//   - An object with the literal value and the variable name, where at least one of them is required.
+//   - The bare literal value, e.g. 8080.
+//   - A variable reference string, e.g. "${PORT}" or "${PORT:-8080}". JSON documents also accept "$PORT".
+
+const (
+	envVariableNamePattern = `^[A-Za-z_][A-Za-z0-9_]*$`
+	envReferencePattern    = `^\$([A-Za-z_][A-Za-z0-9_]*|\{[A-Za-z_][A-Za-z0-9_]*(:-.*)?\})$`
+)
+
+// envJSONSchema returns the JSON schema of an Env type with the schema of its literal value.
+func envJSONSchema(valueSchema *jsonschema.Schema) *jsonschema.Schema {
+	objectValueSchema := *valueSchema
+	objectValueSchema.Description = "Default literal value if the env is empty"
+
+	properties := jsonschema.NewProperties()
+	properties.Set(envObjectValueKey, &objectValueSchema)
+	properties.Set(envObjectVariableKey, &jsonschema.Schema{
+		Type:        "string",
+		Description: "Environment variable to be evaluated",
+		Pattern:     envVariableNamePattern,
+	})
+
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			{
+				Type:                 "object",
+				Properties:           properties,
+				AdditionalProperties: jsonschema.FalseSchema,
+				AnyOf: []*jsonschema.Schema{
+					{Required: []string{envObjectValueKey}},
+					{Required: []string{envObjectVariableKey}},
+				},
+			},
+			valueSchema,
+			{
+				Type:        "string",
+				Description: "Reference to the environment variable to be evaluated",
+				Pattern:     envReferencePattern,
+			},
+		},
+	}
+}
+
+// arrayJSONSchema returns the JSON schema of an array with items of the type.
+func arrayJSONSchema(itemType string) *jsonschema.Schema {
+	return &jsonschema.Schema{Type: "array", Items: &jsonschema.Schema{Type: itemType}}
+}
+
+// mapJSONSchema returns the JSON schema of an object with values of the type.
+func mapJSONSchema(valueType string) *jsonschema.Schema {
+	return &jsonschema.Schema{Type: "object", AdditionalProperties: &jsonschema.Schema{Type: valueType}}
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvString) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(&jsonschema.Schema{Type: "string"})
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvInt) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(&jsonschema.Schema{Type: "integer"})
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvBool) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(&jsonschema.Schema{Type: "boolean"})
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvFloat) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(&jsonschema.Schema{Type: "number"})
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvAny) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(&jsonschema.Schema{})
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvStringSlice) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(arrayJSONSchema("string"))
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvIntSlice) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(arrayJSONSchema("integer"))
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvFloatSlice) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(arrayJSONSchema("number"))
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvBoolSlice) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(arrayJSONSchema("boolean"))
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvMapString) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(mapJSONSchema("string"))
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvMapInt) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(mapJSONSchema("integer"))
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvMapFloat) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(mapJSONSchema("number"))
+}
+
+// JSONSchema returns the JSON schema of the type, which is used by [jsonschema.Reflector].
+func (EnvMapBool) JSONSchema() *jsonschema.Schema {
+	return envJSONSchema(mapJSONSchema("boolean"))
+}
+
+// GenerateSchema generates a self-contained JSON schema document of the config struct, whose Env fields
+// are expressed by their JSONSchema methods. Descriptions are read from the jsonschema tags of fields,
+// e.g. `jsonschema:"description=Server port"`, and non-zero fields of the config become defaults.
+// Defaults of secret Env fields are omitted.
+func GenerateSchema(config any) (*jsonschema.Schema, error) {
+	value := reflect.Indirect(reflect.ValueOf(config))
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w, got %T", ErrInvalidBindTarget, config)
+	}
+
+	reflector := jsonschema.Reflector{
+		Anonymous:      true,
+		DoNotReference: true,
+	}
+
+	schema := reflector.ReflectFromType(value.Type())
+	setSchemaDefaults(schema, value)
+
+	return schema, nil
+}
+
+// setSchemaDefaults sets non-zero fields of the struct as default values of properties in the schema.
+func setSchemaDefaults(schema *jsonschema.Schema, value reflect.Value) {
+	if schema == nil || schema.Properties == nil {
+		return
+	}
+
+	valueType := value.Type()
+
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldValue := reflect.Indirect(value.Field(i))
+		if !fieldValue.IsValid() {
+			continue
+		}
+
+		name, isSkipped := jsonFieldName(field)
+
+		switch {
+		case isSkipped:
+		case field.Anonymous && name == "" && fieldValue.Kind() == reflect.Struct:
+			setSchemaDefaults(schema, fieldValue)
+		default:
+			if name == "" {
+				name = field.Name
+			}
+
+			property, ok := schema.Properties.Get(name)
+			if ok {
+				setSchemaDefault(property, fieldValue)
+			}
+		}
+	}
+}
+
+// setSchemaDefault sets the value as the default value of the property if the value is not zero.
+func setSchemaDefault(property *jsonschema.Schema, value reflect.Value) {
+	if value.IsZero() {
+		return
+	}
+
+	if ev, ok := value.Interface().(EnvValue); ok {
+		if secret, ok := ev.(interface{ IsSecret() bool }); !ok || !secret.IsSecret() {
+			property.Default = ev
+		}
+
+		return
+	}
+
+	if value.Kind() == reflect.Struct && property.Properties != nil {
+		setSchemaDefaults(property, value)
+
+		return
+	}
+
+	property.Default = value.Interface()
+}
+
+// jsonFieldName returns the name of the json tag of the struct field, or true if the field is skipped.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+
+	return name, false
+}
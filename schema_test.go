@@ -0,0 +1,136 @@
+package goenvconf
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/invopop/jsonschema"
+)
+
+func TestJSONSchema(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    interface{ JSONSchema() *jsonschema.Schema }
+		Expected string
+	}{
+		{Name: "string", Input: EnvString{}, Expected: `{"type":"string"}`},
+		{Name: "int", Input: EnvInt{}, Expected: `{"type":"integer"}`},
+		{Name: "bool", Input: EnvBool{}, Expected: `{"type":"boolean"}`},
+		{Name: "float", Input: EnvFloat{}, Expected: `{"type":"number"}`},
+		{Name: "any", Input: EnvAny{}, Expected: `true`},
+		{Name: "string_slice", Input: EnvStringSlice{}, Expected: `{"items":{"type":"string"},"type":"array"}`},
+		{Name: "int_slice", Input: EnvIntSlice{}, Expected: `{"items":{"type":"integer"},"type":"array"}`},
+		{Name: "float_slice", Input: EnvFloatSlice{}, Expected: `{"items":{"type":"number"},"type":"array"}`},
+		{Name: "bool_slice", Input: EnvBoolSlice{}, Expected: `{"items":{"type":"boolean"},"type":"array"}`},
+		{Name: "map_string", Input: EnvMapString{}, Expected: `{"additionalProperties":{"type":"string"},"type":"object"}`},
+		{Name: "map_int", Input: EnvMapInt{}, Expected: `{"additionalProperties":{"type":"integer"},"type":"object"}`},
+		{Name: "map_float", Input: EnvMapFloat{}, Expected: `{"additionalProperties":{"type":"number"},"type":"object"}`},
+		{Name: "map_bool", Input: EnvMapBool{}, Expected: `{"additionalProperties":{"type":"boolean"},"type":"object"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			schema := tc.Input.JSONSchema()
+			assertDeepEqual(t, 3, len(schema.AnyOf))
+
+			literal, err := json.Marshal(schema.AnyOf[1])
+			assertNilError(t, err)
+			assertDeepEqual(t, tc.Expected, string(literal))
+
+			object := schema.AnyOf[0]
+			assertDeepEqual(t, "object", object.Type)
+			assertDeepEqual(t, jsonschema.FalseSchema, object.AdditionalProperties)
+			assertDeepEqual(t, []string{"value"}, object.AnyOf[0].Required)
+			assertDeepEqual(t, []string{"env"}, object.AnyOf[1].Required)
+
+			value, _ := object.Properties.Get("value")
+			assertDeepEqual(t, "Default literal value if the env is empty", value.Description)
+
+			variable, _ := object.Properties.Get("env")
+			assertDeepEqual(t, envVariableNamePattern, variable.Pattern)
+			assertDeepEqual(t, envReferencePattern, schema.AnyOf[2].Pattern)
+		})
+	}
+}
+
+func TestJSONSchema_Reflect(t *testing.T) {
+	type config struct {
+		Port  EnvInt          `json:"port"            jsonschema:"description=Server port"`
+		Hosts *EnvStringSlice `json:"hosts,omitempty"`
+	}
+
+	schema := jsonschema.Reflect(&config{})
+
+	port, ok := schema.Definitions["EnvInt"]
+	assertDeepEqual(t, true, ok)
+	assertDeepEqual(t, 3, len(port.AnyOf))
+
+	_, ok = schema.Definitions["EnvStringSlice"]
+	assertDeepEqual(t, true, ok)
+
+	portField, _ := schema.Definitions["config"].Properties.Get("port")
+	assertDeepEqual(t, "#/$defs/EnvInt", portField.Ref)
+	assertDeepEqual(t, "Server port", portField.Description)
+	assertDeepEqual(t, []string{"port"}, schema.Definitions["config"].Required)
+}
+
+func TestGenerateSchema(t *testing.T) {
+	type server struct {
+		Host EnvString `json:"host" jsonschema:"description=Host name"`
+		Port EnvInt    `json:"port"`
+	}
+
+	type Logging struct {
+		Level EnvString `json:"level"`
+	}
+
+	type config struct {
+		Logging
+
+		Server  server        `json:"server"`
+		Backup  *server       `json:"backup,omitempty"`
+		Token   EnvString     `json:"token"`
+		Timeout time.Duration `json:"timeout"`
+		Ignored string        `json:"-"`
+	}
+
+	schema, err := GenerateSchema(&config{
+		Logging: Logging{Level: NewEnvString("LOG_LEVEL", "info")},
+		Server:  server{Host: NewEnvStringVariable("HOST"), Port: NewEnvIntValue(8080)},
+		Token:   NewEnvString("TOKEN", "s3cr3t").Secret(),
+		Timeout: time.Second,
+		Ignored: "foo",
+	})
+	assertNilError(t, err)
+	assertDeepEqual(t, "", schema.ID.String())
+	assertDeepEqual(t, 0, len(schema.Definitions))
+	assertDeepEqual(t, []string{"level", "server", "token", "timeout"}, schema.Required)
+
+	level, _ := schema.Properties.Get("level")
+	assertDeepEqual(t, NewEnvString("LOG_LEVEL", "info"), level.Default)
+
+	token, _ := schema.Properties.Get("token")
+	assertDeepEqual(t, nil, token.Default)
+
+	timeout, _ := schema.Properties.Get("timeout")
+	assertDeepEqual(t, time.Second, timeout.Default)
+
+	serverSchema, _ := schema.Properties.Get("server")
+	host, _ := serverSchema.Properties.Get("host")
+	assertDeepEqual(t, "Host name", host.Description)
+	assertDeepEqual(t, 3, len(host.AnyOf))
+
+	rawSchema, err := json.Marshal(serverSchema.Properties)
+	assertNilError(t, err)
+	assertDeepEqual(t, true, strings.Contains(string(rawSchema), `"default":{"env":"HOST"}`))
+	assertDeepEqual(t, true, strings.Contains(string(rawSchema), `"default":{"value":8080}`))
+
+	backup, _ := schema.Properties.Get("backup")
+	backupHost, _ := backup.Properties.Get("host")
+	assertDeepEqual(t, nil, backupHost.Default)
+
+	_, err = GenerateSchema("foo")
+	assertErrorContains(t, err, ErrInvalidBindTarget.Error())
+}
@@ -0,0 +1,277 @@
+package goenvconf
+
+import (
+	"errors"
+	"path"
+	"reflect"
+	"sync"
+)
+
+// The Secret methods return a copy of the instance whose literal value is sensitive, e.g. a password or an API key.
+// Secret literal values are masked in logs. Variable names are never masked.
+//
+// Variables can also be marked as secret globally with [RegisterSecretVariables], using exact names or
+// patterns such as *_KEY and *_TOKEN, so one registration protects every output path, e.g. String and LogValue,
+// without annotating each field.
+
+// redactedValue replaces secret literal values in logs and formatted output.
+const redactedValue = "[REDACTED]"
+
+// redactedError hides the message of an error of a secret field, which may contain the secret value,
+// e.g. the raw value in a parse error. [errors.Is] still matches the original error.
+type redactedError struct {
+	err error
+}
+
+func (re redactedError) Error() string {
+	return redactedValue
+}
+
+func (re redactedError) Is(target error) bool {
+	return errors.Is(re.err, target)
+}
+
+// redactError wraps the error of a secret field with [redactedError] unless it is nil or already redacted.
+// Errors of missing values are kept because they only contain variable names.
+func redactError(err error) error {
+	var redacted redactedError
+
+	if err == nil || errors.As(err, &redacted) ||
+		errors.Is(err, ErrEnvironmentValueRequired) ||
+		errors.Is(err, ErrEnvironmentVariableValueRequired) {
+		return err
+	}
+
+	return redactedError{err: err}
+}
+
+// redactEnvError redacts the resolution error of the Env value if it is secret.
+func redactEnvError(ev EnvValue, err error) error {
+	if err != nil && isSecretField(reflect.ValueOf(ev), "") {
+		return redactError(err)
+	}
+
+	return err
+}
+
+var secretRegistry = struct {
+	mu       sync.RWMutex
+	patterns []string
+}{}
+
+// RegisterSecretVariables marks variables as secret in the package-level registry.
+// Each pattern is an exact variable name or a [path.Match] pattern, e.g. *_KEY, *_TOKEN or DB_*_PASSWORD.
+func RegisterSecretVariables(patterns ...string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return NewParseEnvFailedError("invalid secret variable pattern", pattern)
+		}
+	}
+
+	secretRegistry.mu.Lock()
+	defer secretRegistry.mu.Unlock()
+
+	secretRegistry.patterns = append(secretRegistry.patterns, patterns...)
+
+	return nil
+}
+
+// ResetSecretVariables removes all patterns from the secret registry.
+func ResetSecretVariables() {
+	secretRegistry.mu.Lock()
+	defer secretRegistry.mu.Unlock()
+
+	secretRegistry.patterns = nil
+}
+
+// IsSecretVariable checks if the variable name matches a pattern of the secret registry.
+func IsSecretVariable(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	secretRegistry.mu.RLock()
+	defer secretRegistry.mu.RUnlock()
+
+	for _, pattern := range secretRegistry.patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSecretEnv checks if the instance is marked as secret, or its variable matches the secret registry.
+func isSecretEnv(variable *string, options *envOptions) bool {
+	return options.isSecret() || (variable != nil && IsSecretVariable(*variable))
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvString) Secret() EnvString {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvString) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvInt) Secret() EnvInt {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvInt) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvBool) Secret() EnvBool {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvBool) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvFloat) Secret() EnvFloat {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvFloat) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvAny) Secret() EnvAny {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvAny) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvStringSlice) Secret() EnvStringSlice {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvStringSlice) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvIntSlice) Secret() EnvIntSlice {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvIntSlice) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvFloatSlice) Secret() EnvFloatSlice {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvFloatSlice) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvBoolSlice) Secret() EnvBoolSlice {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvBoolSlice) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvMapString) Secret() EnvMapString {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvMapString) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvMapInt) Secret() EnvMapInt {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvMapInt) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvMapFloat) Secret() EnvMapFloat {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvMapFloat) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
+
+// Secret returns a copy of the instance whose literal value is masked in logs.
+func (ev EnvMapBool) Secret() EnvMapBool {
+	ev.options = ev.options.clone()
+	ev.options.secret = true
+
+	return ev
+}
+
+// IsSecret checks if the literal value is masked in logs, either by the Secret method or the secret registry.
+func (ev EnvMapBool) IsSecret() bool {
+	return isSecretEnv(ev.Variable, ev.options)
+}
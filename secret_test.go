@@ -0,0 +1,120 @@
+package goenvconf
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSecret(t *testing.T) {
+	source := NewEnvString("API_KEY", "s3cr3t")
+	secret := source.Secret()
+
+	assertDeepEqual(t, false, source.IsSecret())
+	assertDeepEqual(t, true, secret.IsSecret())
+	assertDeepEqual(t, true, NewEnvMapBoolVariable("FLAGS").Secret().IsSecret())
+	assertDeepEqual(t, true, source.Equal(secret))
+
+	t.Setenv("API_KEY", "foo")
+
+	result, err := secret.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "foo", result)
+}
+
+func TestRegisterSecretVariables(t *testing.T) {
+	t.Cleanup(ResetSecretVariables)
+
+	assertErrorContains(t, RegisterSecretVariables("[A-"), "invalid secret variable pattern")
+	assertNilError(t, RegisterSecretVariables("*_KEY", "*_TOKEN", "DB_PASSWORD"))
+
+	testCases := []struct {
+		Name     string
+		Expected bool
+	}{
+		{Name: "API_KEY", Expected: true},
+		{Name: "GITHUB_TOKEN", Expected: true},
+		{Name: "DB_PASSWORD", Expected: true},
+		{Name: "DB_PASSWORD_FILE", Expected: false},
+		{Name: "KEY", Expected: false},
+		{Name: "", Expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertDeepEqual(t, tc.Expected, IsSecretVariable(tc.Name))
+		})
+	}
+
+	apiKey := NewEnvString("API_KEY", "s3cr3t")
+	assertDeepEqual(t, true, apiKey.IsSecret())
+	assertDeepEqual(t, false, NewEnvStringValue("s3cr3t").IsSecret())
+	assertDeepEqual(t, "config.env=API_KEY config.value=[REDACTED]", logText(slog.Any("config", apiKey)))
+
+	ResetSecretVariables()
+	assertDeepEqual(t, false, apiKey.IsSecret())
+}
+
+func TestBinder_secretErrors(t *testing.T) {
+	t.Cleanup(ResetResolveHooks)
+
+	type secretConfig struct {
+		Mode  EnvString
+		Debug EnvBool
+	}
+
+	type secretTarget struct {
+		Mode  string
+		Debug bool
+	}
+
+	config := secretConfig{
+		Mode:  NewEnvStringVariable("MODE").WithOneOf("a", "b").Secret(),
+		Debug: NewEnvBoolVariable("DEBUG").Secret(),
+	}
+	getter := newBinderGetter(map[string]string{"MODE": "hunter2", "DEBUG": "hunter2"})
+
+	assertRedacted := func(t *testing.T, err error) {
+		t.Helper()
+
+		assertErrorContains(t, err, redactedValue)
+
+		if strings.Contains(err.Error(), "hunter2") {
+			t.Fatalf("expected the secret value to be redacted, got: %s", err)
+		}
+	}
+
+	var events []ResolveEvent
+
+	binder := NewBinder(getter).OnResolve(func(event ResolveEvent) {
+		events = append(events, event)
+	})
+
+	var target secretTarget
+
+	err := binder.Bind(&target, config)
+	assertRedacted(t, err)
+	assertErrorContains(t, err, "Mode (MODE): [REDACTED]")
+	assertDeepEqual(t, 2, len(events))
+
+	for _, event := range events {
+		assertDeepEqual(t, SourceError, event.Source)
+		assertRedacted(t, event.Err)
+	}
+
+	report, err := NewBinder(getter).BindWithReport(&target, config)
+	assertRedacted(t, err)
+
+	for _, field := range report.Fields {
+		assertRedacted(t, field.Err)
+	}
+
+	_, err = NewBinder(getter).Resolve(config)
+	assertRedacted(t, err)
+
+	assertRedacted(t, NewBinder(getter).WithConcurrency(2).ValidateOnly(config))
+
+	// Missing values are not redacted because the errors only contain variable names.
+	_, err = NewBinder(newBinderGetter(nil)).Resolve(config)
+	assertErrorContains(t, err, "Mode: MODE: EmptyVar")
+}
@@ -9,8 +9,10 @@ import (
 
 // EnvStringSlice represents either a literal string slice or an environment reference.
 type EnvStringSlice struct {
-	Value    []string `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string  `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    []string `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string  `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvStringSlice creates an EnvStringSlice instance.
@@ -54,6 +56,19 @@ func (ev EnvStringSlice) Equal(target EnvStringSlice) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvStringSlice) Get() ([]string, error) {
+	result, err := ev.get()
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvStringSlice) get() ([]string, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.IsZero() {
 		return nil, ErrEnvironmentValueRequired
 	}
@@ -65,12 +80,19 @@ func (ev EnvStringSlice) Get() ([]string, error) {
 	if ev.Variable != nil && *ev.Variable != "" {
 		value, envExisted = os.LookupEnv(*ev.Variable)
 		if value != "" {
-			return ParseStringSliceFromString(value), nil
+			return parseStringSliceWithErrorPrefix(
+				ev.options.sliceInput(value),
+				fmt.Sprintf("failed to parse %s: ", *ev.Variable),
+			)
 		}
 	}
 
 	if ev.Value != nil {
-		return ev.Value, nil
+		if ev.options.isExpansionDisabled() {
+			return ev.Value, nil
+		}
+
+		return expandStrings(ev.Value, GetOSEnv)
 	}
 
 	if envExisted {
@@ -80,8 +102,63 @@ func (ev EnvStringSlice) Get() ([]string, error) {
 	return nil, getEnvVariableValueRequiredError(ev.Variable)
 }
 
+// GetOrDefault returns the default value if the environment value is empty.
+func (ev EnvStringSlice) GetOrDefault(defaultValue []string) ([]string, error) {
+	result, err := ev.Get()
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty.
+func (ev EnvStringSlice) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue []string) ([]string, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvStringSlice) GetCustom(getFunc GetEnvFunc) ([]string, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvStringSlice) getCustom(getFunc GetEnvFunc) ([]string, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	items, err := ev.options.lookupIndexedItems(ev.Variable, getFunc)
+	if err != nil || items != nil {
+		return items, err
+	}
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.IsZero() {
 		return nil, ErrEnvironmentValueRequired
 	}
@@ -93,12 +170,19 @@ func (ev EnvStringSlice) GetCustom(getFunc GetEnvFunc) ([]string, error) {
 		}
 
 		if value != "" {
-			return ParseStringSliceFromString(value), nil
+			return parseStringSliceWithErrorPrefix(
+				ev.options.sliceInput(value),
+				fmt.Sprintf("failed to parse %s: ", *ev.Variable),
+			)
 		}
 	}
 
 	if ev.Value != nil {
-		return ev.Value, nil
+		if ev.options.isExpansionDisabled() {
+			return ev.Value, nil
+		}
+
+		return expandStrings(ev.Value, getFunc)
 	}
 
 	return nil, getEnvVariableValueRequiredError(ev.Variable)
@@ -106,8 +190,10 @@ func (ev EnvStringSlice) GetCustom(getFunc GetEnvFunc) ([]string, error) {
 
 // EnvIntSlice represents either a literal integer slice or an environment reference.
 type EnvIntSlice struct {
-	Value    []int64 `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    []int64 `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvIntSlice creates an EnvIntSlice instance.
@@ -151,6 +237,19 @@ func (ev EnvIntSlice) Equal(target EnvIntSlice) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvIntSlice) Get() ([]int64, error) {
+	result, err := ev.get()
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvIntSlice) get() ([]int64, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.IsZero() {
 		return nil, ErrEnvironmentValueRequired
 	}
@@ -162,9 +261,10 @@ func (ev EnvIntSlice) Get() ([]int64, error) {
 	if ev.Variable != nil && *ev.Variable != "" {
 		value, envExisted = os.LookupEnv(*ev.Variable)
 		if value != "" {
-			return parseIntSliceFromStringWithErrorPrefix[int64](
-				value,
+			return parseIntSliceFromStringWithErrorPrefix(
+				ev.options.sliceInput(value),
 				fmt.Sprintf("failed to parse %s: ", *ev.Variable),
+				ev.options.parseInt,
 			)
 		}
 	}
@@ -180,8 +280,67 @@ func (ev EnvIntSlice) Get() ([]int64, error) {
 	return nil, getEnvVariableValueRequiredError(ev.Variable)
 }
 
+// GetOrDefault returns the default value if the environment value is empty.
+func (ev EnvIntSlice) GetOrDefault(defaultValue []int64) ([]int64, error) {
+	result, err := ev.Get()
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty.
+func (ev EnvIntSlice) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue []int64) ([]int64, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvIntSlice) GetCustom(getFunc GetEnvFunc) ([]int64, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvIntSlice) getCustom(getFunc GetEnvFunc) ([]int64, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	items, err := ev.options.lookupIndexedItems(ev.Variable, getFunc)
+	if err != nil || items != nil {
+		if err != nil {
+			return nil, err
+		}
+
+		return ev.parseIndexedItems(items)
+	}
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.IsZero() {
 		return nil, ErrEnvironmentValueRequired
 	}
@@ -193,9 +352,10 @@ func (ev EnvIntSlice) GetCustom(getFunc GetEnvFunc) ([]int64, error) {
 		}
 
 		if value != "" {
-			return parseIntSliceFromStringWithErrorPrefix[int64](
-				value,
+			return parseIntSliceFromStringWithErrorPrefix(
+				ev.options.sliceInput(value),
 				fmt.Sprintf("failed to parse %s: ", *ev.Variable),
+				ev.options.parseInt,
 			)
 		}
 	}
@@ -209,8 +369,10 @@ func (ev EnvIntSlice) GetCustom(getFunc GetEnvFunc) ([]int64, error) {
 
 // EnvFloatSlice represents either a literal floating-point number slice or an environment reference.
 type EnvFloatSlice struct {
-	Value    []float64 `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string   `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    []float64 `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string   `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvFloatSlice creates an EnvFloatSlice instance.
@@ -254,6 +416,19 @@ func (ev EnvFloatSlice) Equal(target EnvFloatSlice) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvFloatSlice) Get() ([]float64, error) {
+	result, err := ev.get()
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvFloatSlice) get() ([]float64, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.IsZero() {
 		return nil, ErrEnvironmentValueRequired
 	}
@@ -266,7 +441,7 @@ func (ev EnvFloatSlice) Get() ([]float64, error) {
 		value, envExisted = os.LookupEnv(*ev.Variable)
 		if value != "" {
 			return parseFloatSliceFromStringWithErrorPrefix[float64](
-				value,
+				ev.options.sliceInput(value),
 				fmt.Sprintf("failed to parse %s: ", *ev.Variable),
 			)
 		}
@@ -283,8 +458,58 @@ func (ev EnvFloatSlice) Get() ([]float64, error) {
 	return nil, getEnvVariableValueRequiredError(ev.Variable)
 }
 
+// GetOrDefault returns the default value if the environment value is empty.
+func (ev EnvFloatSlice) GetOrDefault(defaultValue []float64) ([]float64, error) {
+	result, err := ev.Get()
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty.
+func (ev EnvFloatSlice) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue []float64) ([]float64, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvFloatSlice) GetCustom(getFunc GetEnvFunc) ([]float64, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvFloatSlice) getCustom(getFunc GetEnvFunc) ([]float64, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.IsZero() {
 		return nil, ErrEnvironmentValueRequired
 	}
@@ -297,7 +522,7 @@ func (ev EnvFloatSlice) GetCustom(getFunc GetEnvFunc) ([]float64, error) {
 
 		if value != "" {
 			return parseFloatSliceFromStringWithErrorPrefix[float64](
-				value,
+				ev.options.sliceInput(value),
 				fmt.Sprintf("failed to parse %s: ", *ev.Variable),
 			)
 		}
@@ -312,8 +537,10 @@ func (ev EnvFloatSlice) GetCustom(getFunc GetEnvFunc) ([]float64, error) {
 
 // EnvBoolSlice represents either a literal boolean slice or an environment reference.
 type EnvBoolSlice struct {
-	Value    []bool  `json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" yaml:"value,omitempty"`
-	Variable *string `json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   yaml:"env,omitempty"`
+	Value    []bool  `bson:"value,omitempty" json:"value,omitempty" jsonschema:"anyof_required=value,description=Default literal value if the env is empty" mapstructure:"value" toml:"value,omitempty" yaml:"value,omitempty"`
+	Variable *string `bson:"env,omitempty"   json:"env,omitempty"   jsonschema:"anyof_required=env,description=Environment variable to be evaluated"        mapstructure:"env"   toml:"env,omitempty"   yaml:"env,omitempty"`
+
+	options *envOptions
 }
 
 // NewEnvBoolSlice creates an EnvBoolSlice instance.
@@ -357,6 +584,19 @@ func (ev EnvBoolSlice) Equal(target EnvBoolSlice) bool {
 
 // Get gets literal value or from system environment.
 func (ev EnvBoolSlice) Get() ([]bool, error) {
+	result, err := ev.get()
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvBoolSlice) get() ([]bool, error) {
+	if ev.options.usesGetFunc() {
+		return ev.getCustom(GetOSEnv)
+	}
+
 	if ev.IsZero() {
 		return nil, ErrEnvironmentValueRequired
 	}
@@ -369,8 +609,9 @@ func (ev EnvBoolSlice) Get() ([]bool, error) {
 		value, envExisted = os.LookupEnv(*ev.Variable)
 		if value != "" {
 			return parseBoolSliceFromStringWithErrorPrefix(
-				value,
+				ev.options.sliceInput(value),
 				fmt.Sprintf("failed to parse %s: ", *ev.Variable),
+				ev.options.parseBool,
 			)
 		}
 	}
@@ -386,8 +627,58 @@ func (ev EnvBoolSlice) Get() ([]bool, error) {
 	return nil, getEnvVariableValueRequiredError(ev.Variable)
 }
 
+// GetOrDefault returns the default value if the environment value is empty.
+func (ev EnvBoolSlice) GetOrDefault(defaultValue []bool) ([]bool, error) {
+	result, err := ev.Get()
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetCustomOrDefault returns the default value if the environment value from the custom function is empty.
+func (ev EnvBoolSlice) GetCustomOrDefault(getFunc GetEnvFunc, defaultValue []bool) ([]bool, error) {
+	result, err := ev.GetCustom(getFunc)
+	if err != nil {
+		if errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return defaultValue, nil
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // GetCustom gets literal value or from system environment by a custom function.
 func (ev EnvBoolSlice) GetCustom(getFunc GetEnvFunc) ([]bool, error) {
+	result, err := ev.getCustom(getFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveResult(ev.options, ev.Variable, result)
+}
+
+func (ev EnvBoolSlice) getCustom(getFunc GetEnvFunc) ([]bool, error) {
+	if ev.options.hasOrElse() {
+		primary := ev
+		primary.options = ev.options.withoutOrElse()
+
+		return resolveOrElse(ev.options, getFunc, primary.getCustom)
+	}
+
+	getFunc = ev.options.wrapGetFunc(ev.Variable, getFunc)
+
+	if ev.options.isStrict() {
+		return ev.getStrict(getFunc)
+	}
+
 	if ev.IsZero() {
 		return nil, ErrEnvironmentValueRequired
 	}
@@ -400,8 +691,9 @@ func (ev EnvBoolSlice) GetCustom(getFunc GetEnvFunc) ([]bool, error) {
 
 		if value != "" {
 			return parseBoolSliceFromStringWithErrorPrefix(
-				value,
+				ev.options.sliceInput(value),
 				fmt.Sprintf("failed to parse %s: ", *ev.Variable),
+				ev.options.parseBool,
 			)
 		}
 	}
@@ -412,3 +704,72 @@ func (ev EnvBoolSlice) GetCustom(getFunc GetEnvFunc) ([]bool, error) {
 
 	return nil, getEnvVariableValueRequiredError(ev.Variable)
 }
+
+// WithSkipEmpty returns a copy of the instance which removes empty items of the comma-separated environment value,
+// so trailing or doubled commas, e.g. "a,b,,c,", do not produce empty items or parse errors.
+func (ev EnvStringSlice) WithSkipEmpty() EnvStringSlice {
+	ev.options = ev.options.clone()
+	ev.options.skipEmpty = true
+
+	return ev
+}
+
+// WithSkipEmpty returns a copy of the instance which removes empty items of the comma-separated environment value,
+// so trailing or doubled commas, e.g. "a,b,,c,", do not produce empty items or parse errors.
+func (ev EnvIntSlice) WithSkipEmpty() EnvIntSlice {
+	ev.options = ev.options.clone()
+	ev.options.skipEmpty = true
+
+	return ev
+}
+
+// WithSkipEmpty returns a copy of the instance which removes empty items of the comma-separated environment value,
+// so trailing or doubled commas, e.g. "a,b,,c,", do not produce empty items or parse errors.
+func (ev EnvFloatSlice) WithSkipEmpty() EnvFloatSlice {
+	ev.options = ev.options.clone()
+	ev.options.skipEmpty = true
+
+	return ev
+}
+
+// WithSkipEmpty returns a copy of the instance which removes empty items of the comma-separated environment value,
+// so trailing or doubled commas, e.g. "a,b,,c,", do not produce empty items or parse errors.
+func (ev EnvBoolSlice) WithSkipEmpty() EnvBoolSlice {
+	ev.options = ev.options.clone()
+	ev.options.skipEmpty = true
+
+	return ev
+}
+
+// WithUnique returns a copy of the instance which removes duplicated items of the resolved slice,
+// keeping the first occurrence. The items are also sorted if sorted is true.
+func (ev EnvStringSlice) WithUnique(sorted bool) EnvStringSlice {
+	ev.options = withTransform(ev.options, func(values []string) ([]string, error) {
+		if values == nil {
+			return nil, nil
+		}
+
+		if sorted {
+			return slices.Compact(slices.Sorted(slices.Values(values))), nil
+		}
+
+		seen := make(map[string]bool, len(values))
+		results := make([]string, 0, len(values))
+
+		for _, value := range values {
+			if !seen[value] {
+				seen[value] = true
+				results = append(results, value)
+			}
+		}
+
+		return results, nil
+	})
+
+	return ev
+}
+
+// GetUnique gets the value like Get, with duplicated items removed and the order of first occurrences kept.
+func (ev EnvStringSlice) GetUnique() ([]string, error) {
+	return ev.WithUnique(false).Get()
+}
@@ -9,12 +9,22 @@ import (
 func TestEnvStringSlice(t *testing.T) {
 	t.Setenv("STRING_SLICE_VAR", "foo,bar,baz")
 	t.Setenv("EMPTY_STRING_SLICE", "")
+	t.Setenv("JSON_STRING_SLICE", ` ["a,b", "", "c"]`)
+	t.Setenv("INVALID_JSON_STRING_SLICE", "[a],b")
 
 	testCases := []struct {
 		Input    EnvStringSlice
 		Expected []string
 		ErrorMsg string
 	}{
+		{
+			Input:    NewEnvStringSliceVariable("JSON_STRING_SLICE"),
+			Expected: []string{"a,b", "", "c"},
+		},
+		{
+			Input:    NewEnvStringSliceVariable("INVALID_JSON_STRING_SLICE"),
+			ErrorMsg: "failed to parse INVALID_JSON_STRING_SLICE: invalid JSON array syntax",
+		},
 		{
 			Input:    NewEnvStringSliceValue([]string{"foo", "bar"}),
 			Expected: []string{"foo", "bar"},
@@ -979,3 +989,102 @@ func TestEnvBoolSlice_Equal(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvSlice_WithSkipEmpty(t *testing.T) {
+	t.Setenv("SKIP_EMPTY_STRINGS", "a,b,,c, ,")
+	t.Setenv("SKIP_EMPTY_INTS", ",1,,2,")
+	t.Setenv("SKIP_EMPTY_FLOATS", "1.5,,")
+	t.Setenv("SKIP_EMPTY_BOOLS", ",,")
+	t.Setenv("SKIP_EMPTY_JSON", `["a", ""]`)
+
+	strs, err := NewEnvStringSliceVariable("SKIP_EMPTY_STRINGS").WithSkipEmpty().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a", "b", "c"}, strs)
+
+	strs, err = NewEnvStringSliceVariable("SKIP_EMPTY_JSON").WithSkipEmpty().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a", ""}, strs)
+
+	ints, err := NewEnvIntSliceVariable("SKIP_EMPTY_INTS").WithSkipEmpty().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{1, 2}, ints)
+
+	ints, err = NewEnvIntSliceVariable("SKIP_EMPTY_INTS").WithSkipEmpty().Strict().GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{1, 2}, ints)
+
+	_, err = NewEnvIntSliceVariable("SKIP_EMPTY_INTS").Get()
+	assertErrorContains(t, err, "failed to parse SKIP_EMPTY_INTS: invalid integer slice syntax")
+
+	floats, err := NewEnvFloatSliceVariable("SKIP_EMPTY_FLOATS").WithSkipEmpty().GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, []float64{1.5}, floats)
+
+	bools, err := NewEnvBoolSliceVariable("SKIP_EMPTY_BOOLS").WithSkipEmpty().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []bool{}, bools)
+}
+
+func TestEnvSlice_GetOrDefault(t *testing.T) {
+	t.Setenv("DEFAULT_SLICE", "1,2")
+	t.Setenv("DEFAULT_EMPTY_SLICE", "")
+	t.Setenv("DEFAULT_INVALID_SLICE", "x")
+
+	strs, err := NewEnvStringSliceVariable("DEFAULT_MISSING").GetOrDefault([]string{"a"})
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a"}, strs)
+
+	strs, err = NewEnvStringSliceVariable("DEFAULT_EMPTY_SLICE").GetOrDefault([]string{"a"})
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{}, strs)
+
+	ints, err := NewEnvIntSliceVariable("DEFAULT_SLICE").GetOrDefault([]int64{3})
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{1, 2}, ints)
+
+	ints, err = NewEnvIntSliceVariable("DEFAULT_MISSING").GetOrDefault([]int64{3})
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{3}, ints)
+
+	_, err = NewEnvIntSliceVariable("DEFAULT_INVALID_SLICE").GetOrDefault([]int64{3})
+	assertErrorContains(t, err, "invalid integer slice syntax")
+
+	floats, err := NewEnvFloatSliceVariable("DEFAULT_MISSING").GetOrDefault([]float64{1.5})
+	assertNilError(t, err)
+	assertDeepEqual(t, []float64{1.5}, floats)
+
+	bools, err := NewEnvBoolSliceVariable("DEFAULT_MISSING").GetOrDefault(nil)
+	assertNilError(t, err)
+	assertDeepEqual(t, []bool(nil), bools)
+
+	_, err = EnvBoolSlice{}.GetOrDefault([]bool{true})
+	assertErrorContains(t, err, ErrEnvironmentValueRequired.Error())
+}
+
+func TestEnvStringSlice_WithUnique(t *testing.T) {
+	t.Setenv("TEST_UNIQUE_ORIGINS", "b,a,b,c,a")
+
+	origins := NewEnvStringSliceVariable("TEST_UNIQUE_ORIGINS")
+
+	result, err := origins.GetUnique()
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"b", "a", "c"}, result)
+
+	result, err = origins.WithUnique(true).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a", "b", "c"}, result)
+
+	result, err = origins.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"b", "a", "b", "c", "a"}, result)
+
+	result, err = NewEnvStringSliceValue([]string{"x", "x"}).WithUnique(false).WithMaxItems(1).GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"x"}, result)
+
+	result, err = EnvStringSlice{Variable: toPtr("TEST_UNIQUE_MISSING")}.WithSkipEmpty().WithUnique(true).GetCustom(
+		newBinderGetter(map[string]string{"TEST_UNIQUE_MISSING": "c,,a,c"}),
+	)
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a", "c"}, result)
+}
@@ -0,0 +1,137 @@
+package goenvconf
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// The Scan methods implement the [database/sql.Scanner] interface, so configuration templates stored
+// in the JSON form, e.g. {"value": 8080, "env": "PORT"}, can be scanned from a settings table into Env fields.
+// A NULL value resets the instance to zero.
+
+// SQLValuer wraps an Env instance to implement the [driver.Valuer] interface, which Env types cannot
+// implement themselves because of the conflicting Value field. The instance is written in the JSON form,
+// or NULL if it is zero, e.g. db.Exec(query, SQLValuer{Env: config.Port}).
+type SQLValuer struct {
+	Env EnvValue
+}
+
+// Value implements the driver.Valuer interface.
+func (sv SQLValuer) Value() (driver.Value, error) {
+	if sv.Env == nil || sv.Env.IsZero() {
+		return nil, nil
+	}
+
+	result, err := json.Marshal(sv.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(result), nil
+}
+
+// scanEnv decodes the SQL value with the JSON unmarshaler of an Env instance.
+func scanEnv(src any, unmarshal func([]byte) error) error {
+	switch value := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return unmarshal(value)
+	case string:
+		return unmarshal([]byte(value))
+	default:
+		return NewParseEnvFailedError("unsupported SQL value, expected a JSON string", fmt.Sprintf("%T", src))
+	}
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvString) Scan(src any) error {
+	*ev = EnvString{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvInt) Scan(src any) error {
+	*ev = EnvInt{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvBool) Scan(src any) error {
+	*ev = EnvBool{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvFloat) Scan(src any) error {
+	*ev = EnvFloat{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvAny) Scan(src any) error {
+	*ev = EnvAny{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvStringSlice) Scan(src any) error {
+	*ev = EnvStringSlice{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvIntSlice) Scan(src any) error {
+	*ev = EnvIntSlice{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvFloatSlice) Scan(src any) error {
+	*ev = EnvFloatSlice{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvBoolSlice) Scan(src any) error {
+	*ev = EnvBoolSlice{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvMapString) Scan(src any) error {
+	*ev = EnvMapString{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvMapInt) Scan(src any) error {
+	*ev = EnvMapInt{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvMapFloat) Scan(src any) error {
+	*ev = EnvMapFloat{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
+
+// Scan implements the sql.Scanner interface.
+func (ev *EnvMapBool) Scan(src any) error {
+	*ev = EnvMapBool{options: ev.options}
+
+	return scanEnv(src, ev.UnmarshalJSON)
+}
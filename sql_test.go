@@ -0,0 +1,64 @@
+package goenvconf
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*EnvString)(nil)
+	_ driver.Valuer = SQLValuer{}
+)
+
+func TestScan(t *testing.T) {
+	var port EnvInt
+
+	assertNilError(t, port.Scan([]byte(`{"value": 8080, "env": "PORT"}`)))
+	assertDeepEqual(t, NewEnvInt("PORT", 8080), port)
+
+	assertNilError(t, port.Scan(`{"env": "SERVER_PORT"}`))
+	assertDeepEqual(t, NewEnvIntVariable("SERVER_PORT"), port)
+
+	assertNilError(t, port.Scan(nil))
+	assertDeepEqual(t, EnvInt{}, port)
+
+	var headers EnvMapString
+
+	assertNilError(t, headers.Scan(`"${HEADERS}"`))
+	assertDeepEqual(t, NewEnvMapStringVariable("HEADERS"), headers)
+
+	var hosts EnvStringSlice
+
+	assertErrorContains(t, hosts.Scan(1), "unsupported SQL value, expected a JSON string. Hint: int")
+	assertErrorContains(t, hosts.Scan(`{"value": 1`), "unexpected end of JSON input")
+}
+
+func TestSQLValuer(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    EnvValue
+		Expected driver.Value
+	}{
+		{Name: "both", Input: NewEnvInt("PORT", 8080), Expected: `{"value":8080,"env":"PORT"}`},
+		{Name: "map", Input: NewEnvMapBoolValue(map[string]bool{"a": true}), Expected: `{"value":{"a":true}}`},
+		{Name: "zero", Input: EnvString{}, Expected: nil},
+		{Name: "nil", Input: nil, Expected: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := SQLValuer{Env: tc.Input}.Value()
+			assertNilError(t, err)
+			assertDeepEqual(t, tc.Expected, result)
+		})
+	}
+
+	value, err := SQLValuer{Env: NewEnvStringSlice("HOSTS", []string{"a"})}.Value()
+	assertNilError(t, err)
+
+	var hosts EnvStringSlice
+
+	assertNilError(t, hosts.Scan(value))
+	assertDeepEqual(t, NewEnvStringSlice("HOSTS", []string{"a"}), hosts)
+}
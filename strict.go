@@ -0,0 +1,357 @@
+package goenvconf
+
+import (
+	"errors"
+	"strconv"
+)
+
+// The Strict methods return a copy of the instance which resolves the value in strict mode.
+// The strict mode never falls back silently, and treats unset and empty variables distinctly:
+//   - An unset variable falls back to the literal value, or returns the EmptyVar error if the value is not set.
+//     The getter reports an unset variable by returning [ErrEnvironmentVariableValueRequired].
+//   - A variable which is set to an empty string never falls back to the literal value.
+//     It resolves to an empty string, slice or map, and returns an error for other types.
+//   - A variable which is set but fails to parse always returns an error naming the variable.
+//   - A zero instance returns the EmptyEnv error for all types, including maps.
+//
+// In the default mode, a variable which is set to an empty string is treated as unset.
+
+// getStrict resolves the value in strict mode. The fallback function is nil if the literal value is not set.
+func getStrict[T any](
+	variable *string,
+	fallback func() (T, error),
+	getFunc GetEnvFunc,
+	parse func(string) (T, error),
+) (T, error) {
+	var zero T
+
+	if variable == nil || *variable == "" {
+		if fallback == nil {
+			return zero, ErrEnvironmentValueRequired
+		}
+
+		return fallback()
+	}
+
+	rawValue, err := getFunc(*variable)
+	if err != nil {
+		if !errors.Is(err, ErrEnvironmentVariableValueRequired) {
+			return zero, err
+		}
+
+		if fallback == nil {
+			return zero, getEnvVariableValueRequiredError(variable)
+		}
+
+		return fallback()
+	}
+
+	result, err := parse(rawValue)
+	if err != nil {
+		if rawValue == "" {
+			return zero, withVariableName(variable, NewParseEnvFailedError("the environment variable is set to an empty string", ""))
+		}
+
+		return zero, withVariableName(variable, err)
+	}
+
+	return result, nil
+}
+
+// expandLiteral expands placeholders in the literal value unless the expansion is disabled,
+// or renders it if it is a value template.
+func (ev EnvString) expandLiteral(value string, getFunc GetEnvFunc) (string, error) {
+	if ev.options.isExpansionDisabled() {
+		return value, nil
+	}
+
+	if ev.options.isTemplate() {
+		return ExecuteTemplate(value, getFunc)
+	}
+
+	return ExpandString(value, getFunc)
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvString) Strict() EnvString {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvString) getStrict(getFunc GetEnvFunc) (string, error) {
+	var fallback func() (string, error)
+
+	if ev.Value != nil {
+		fallback = func() (string, error) {
+			return ev.expandLiteral(*ev.Value, getFunc)
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, func(value string) (string, error) {
+		return value, nil
+	})
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvInt) Strict() EnvInt {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvInt) getStrict(getFunc GetEnvFunc) (int64, error) {
+	var fallback func() (int64, error)
+
+	if ev.Value != nil {
+		fallback = func() (int64, error) {
+			return *ev.Value, nil
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, ev.options.parseInt)
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvBool) Strict() EnvBool {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvBool) getStrict(getFunc GetEnvFunc) (bool, error) {
+	var fallback func() (bool, error)
+
+	if ev.Value != nil {
+		fallback = func() (bool, error) {
+			return *ev.Value, nil
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, ev.options.parseBool)
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvFloat) Strict() EnvFloat {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvFloat) getStrict(getFunc GetEnvFunc) (float64, error) {
+	var fallback func() (float64, error)
+
+	if ev.Value != nil {
+		fallback = func() (float64, error) {
+			return *ev.Value, nil
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, func(value string) (float64, error) {
+		return strconv.ParseFloat(value, 64)
+	})
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvAny) Strict() EnvAny {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvAny) getStrict(getFunc GetEnvFunc) (any, error) {
+	var fallback func() (any, error)
+
+	if ev.Value != nil {
+		fallback = func() (any, error) {
+			return ev.Value, nil
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, ev.parseRaw)
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvStringSlice) Strict() EnvStringSlice {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvStringSlice) getStrict(getFunc GetEnvFunc) ([]string, error) {
+	var fallback func() ([]string, error)
+
+	if ev.Value != nil {
+		fallback = func() ([]string, error) {
+			if ev.options.isExpansionDisabled() {
+				return ev.Value, nil
+			}
+
+			return expandStrings(ev.Value, getFunc)
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, func(value string) ([]string, error) {
+		return parseStringSliceWithErrorPrefix(ev.options.sliceInput(value), "")
+	})
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvIntSlice) Strict() EnvIntSlice {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvIntSlice) getStrict(getFunc GetEnvFunc) ([]int64, error) {
+	var fallback func() ([]int64, error)
+
+	if ev.Value != nil {
+		fallback = func() ([]int64, error) {
+			return ev.Value, nil
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, func(value string) ([]int64, error) {
+		return parseIntSliceFromStringWithErrorPrefix(ev.options.sliceInput(value), "", ev.options.parseInt)
+	})
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvFloatSlice) Strict() EnvFloatSlice {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvFloatSlice) getStrict(getFunc GetEnvFunc) ([]float64, error) {
+	var fallback func() ([]float64, error)
+
+	if ev.Value != nil {
+		fallback = func() ([]float64, error) {
+			return ev.Value, nil
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, func(value string) ([]float64, error) {
+		return ParseFloatSliceFromString[float64](ev.options.sliceInput(value))
+	})
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvBoolSlice) Strict() EnvBoolSlice {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvBoolSlice) getStrict(getFunc GetEnvFunc) ([]bool, error) {
+	var fallback func() ([]bool, error)
+
+	if ev.Value != nil {
+		fallback = func() ([]bool, error) {
+			return ev.Value, nil
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, func(value string) ([]bool, error) {
+		return parseBoolSliceFromStringWithErrorPrefix(ev.options.sliceInput(value), "", ev.options.parseBool)
+	})
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvMapString) Strict() EnvMapString {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvMapString) getStrict(getFunc GetEnvFunc) (map[string]string, error) {
+	var fallback func() (map[string]string, error)
+
+	if ev.Value != nil {
+		fallback = func() (map[string]string, error) {
+			if ev.options.isExpansionDisabled() {
+				return ev.Value, nil
+			}
+
+			return expandStringMap(ev.Value, getFunc)
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, ParseStringMapFromString)
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvMapInt) Strict() EnvMapInt {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvMapInt) getStrict(getFunc GetEnvFunc) (map[string]int64, error) {
+	var fallback func() (map[string]int64, error)
+
+	if ev.Value != nil {
+		fallback = func() (map[string]int64, error) {
+			return ev.Value, nil
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, func(value string) (map[string]int64, error) {
+		return parseIntegerMapFromString(value, ev.options.parseInt)
+	})
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvMapFloat) Strict() EnvMapFloat {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvMapFloat) getStrict(getFunc GetEnvFunc) (map[string]float64, error) {
+	var fallback func() (map[string]float64, error)
+
+	if ev.Value != nil {
+		fallback = func() (map[string]float64, error) {
+			return ev.Value, nil
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, ParseFloatMapFromString[float64])
+}
+
+// Strict returns a copy of the instance which resolves the value in strict mode.
+func (ev EnvMapBool) Strict() EnvMapBool {
+	ev.options = ev.options.clone()
+	ev.options.strict = true
+
+	return ev
+}
+
+func (ev EnvMapBool) getStrict(getFunc GetEnvFunc) (map[string]bool, error) {
+	var fallback func() (map[string]bool, error)
+
+	if ev.Value != nil {
+		fallback = func() (map[string]bool, error) {
+			return ev.Value, nil
+		}
+	}
+
+	return getStrict(ev.Variable, fallback, getFunc, func(value string) (map[string]bool, error) {
+		return parseBoolMapFromString(value, ev.options.parseBool)
+	})
+}
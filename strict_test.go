@@ -0,0 +1,177 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	t.Setenv("TEST_STRICT_EMPTY", "")
+	t.Setenv("TEST_STRICT_INVALID", "abc")
+	t.Setenv("TEST_STRICT_PORT", "9090")
+
+	testCases := []struct {
+		Name     string
+		Get      func() (any, error)
+		Expected any
+		ErrorMsg string
+	}{
+		{
+			Name: "string_empty",
+			Get: func() (any, error) {
+				return NewEnvString("TEST_STRICT_EMPTY", "fallback").Strict().Get()
+			},
+			Expected: "",
+		},
+		{
+			Name: "string_unset",
+			Get: func() (any, error) {
+				return NewEnvString("TEST_STRICT_UNSET", "fallback").Strict().Get()
+			},
+			Expected: "fallback",
+		},
+		{
+			Name: "string_unset_without_value",
+			Get: func() (any, error) {
+				return NewEnvStringVariable("TEST_STRICT_UNSET").Strict().Get()
+			},
+			ErrorMsg: "TEST_STRICT_UNSET: EmptyVar",
+		},
+		{
+			Name: "string_zero",
+			Get: func() (any, error) {
+				return EnvString{}.Strict().Get()
+			},
+			ErrorMsg: "EmptyEnv",
+		},
+		{
+			Name: "int_empty",
+			Get: func() (any, error) {
+				return NewEnvInt("TEST_STRICT_EMPTY", 8080).Strict().Get()
+			},
+			ErrorMsg: "TEST_STRICT_EMPTY: ParseEnvFailed: the environment variable is set to an empty string",
+		},
+		{
+			Name: "int_invalid",
+			Get: func() (any, error) {
+				return NewEnvInt("TEST_STRICT_INVALID", 8080).Strict().GetCustom(GetOSEnv)
+			},
+			ErrorMsg: `TEST_STRICT_INVALID: strconv.ParseInt: parsing "abc": invalid syntax`,
+		},
+		{
+			Name: "int_value",
+			Get: func() (any, error) {
+				return NewEnvInt("TEST_STRICT_PORT", 8080).Strict().WithMax(9000).Get()
+			},
+			ErrorMsg: "TEST_STRICT_PORT: ValidationFailed: the value must be less than or equal to 9000",
+		},
+		{
+			Name: "bool_invalid",
+			Get: func() (any, error) {
+				return NewEnvBool("TEST_STRICT_INVALID", true).Strict().Get()
+			},
+			ErrorMsg: "TEST_STRICT_INVALID: strconv.ParseBool",
+		},
+		{
+			Name: "float_unset",
+			Get: func() (any, error) {
+				return NewEnvFloat("TEST_STRICT_UNSET", 0.5).Strict().Get()
+			},
+			Expected: 0.5,
+		},
+		{
+			Name: "any_empty",
+			Get: func() (any, error) {
+				return NewEnvAny("TEST_STRICT_EMPTY", "fallback").Strict().Get()
+			},
+			ErrorMsg: "the environment variable is set to an empty string",
+		},
+		{
+			Name: "string_slice_empty",
+			Get: func() (any, error) {
+				return NewEnvStringSlice("TEST_STRICT_EMPTY", []string{"a"}).Strict().Get()
+			},
+			Expected: []string{},
+		},
+		{
+			Name: "int_slice_invalid",
+			Get: func() (any, error) {
+				return NewEnvIntSlice("TEST_STRICT_INVALID", []int64{1}).Strict().Get()
+			},
+			ErrorMsg: "TEST_STRICT_INVALID: ParseEnvFailed: invalid integer slice syntax",
+		},
+		{
+			Name: "float_slice_unset",
+			Get: func() (any, error) {
+				return NewEnvFloatSlice("TEST_STRICT_UNSET", []float64{1}).Strict().Get()
+			},
+			Expected: []float64{1},
+		},
+		{
+			Name: "bool_slice_empty",
+			Get: func() (any, error) {
+				return NewEnvBoolSlice("TEST_STRICT_EMPTY", []bool{true}).Strict().Get()
+			},
+			Expected: []bool{},
+		},
+		{
+			Name: "map_string_empty",
+			Get: func() (any, error) {
+				return NewEnvMapString("TEST_STRICT_EMPTY", map[string]string{"a": "b"}).Strict().Get()
+			},
+			Expected: map[string]string{},
+		},
+		{
+			Name: "map_int_invalid",
+			Get: func() (any, error) {
+				return NewEnvMapInt("TEST_STRICT_INVALID", map[string]int64{"a": 1}).Strict().Get()
+			},
+			ErrorMsg: "TEST_STRICT_INVALID: ParseEnvFailed",
+		},
+		{
+			Name: "map_float_zero",
+			Get: func() (any, error) {
+				return EnvMapFloat{}.Strict().Get()
+			},
+			ErrorMsg: "EmptyEnv",
+		},
+		{
+			Name: "map_bool_unset",
+			Get: func() (any, error) {
+				return NewEnvMapBool("TEST_STRICT_UNSET", map[string]bool{"a": true}).Strict().Get()
+			},
+			Expected: map[string]bool{"a": true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := tc.Get()
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestStrict_GetterError(t *testing.T) {
+	errGetter := errors.New("getter failed")
+
+	_, err := NewEnvString("FOO", "bar").Strict().GetCustom(func(string) (string, error) {
+		return "", errGetter
+	})
+	assertDeepEqual(t, true, errors.Is(err, errGetter))
+
+	result, err := NewEnvString("FOO", "${BAR}").Strict().GetCustom(func(name string) (string, error) {
+		if name == "BAR" {
+			return "bar", nil
+		}
+
+		return "", ErrEnvironmentVariableValueRequired
+	})
+	assertNilError(t, err)
+	assertDeepEqual(t, "bar", result)
+}
@@ -0,0 +1,93 @@
+package goenvconf
+
+import "strconv"
+
+// The String methods implement the [fmt.Stringer] interface with a compact, human-readable form,
+// which is safe to print in logs and error messages:
+//   - env:VAR if only the variable is set.
+//   - env:VAR (default set) if both are set. The default value is never printed.
+//   - value:"literal" if only the value is set, or value:[REDACTED] if the instance or its variable is secret.
+//   - An empty string if the instance is zero.
+
+// formatEnvString formats an Env instance in the compact, human-readable form.
+func formatEnvString(variable *string, hasValue bool, options *envOptions, formatValue func() string) string {
+	hasVariable := variable != nil && *variable != ""
+
+	switch {
+	case hasVariable && hasValue:
+		return flagVariablePrefix + *variable + " (default set)"
+	case hasVariable:
+		return flagVariablePrefix + *variable
+	case !hasValue:
+		return ""
+	case isSecretEnv(variable, options):
+		return "value:" + redactedValue
+	default:
+		return "value:" + strconv.Quote(formatValue())
+	}
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvString) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvInt) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvBool) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvFloat) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvAny) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvStringSlice) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvIntSlice) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvFloatSlice) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvBoolSlice) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvMapString) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvMapInt) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvMapFloat) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
+
+// String implements the fmt.Stringer interface.
+func (ev EnvMapBool) String() string {
+	return formatEnvString(ev.Variable, ev.Value != nil, ev.options, ev.formatLiteral)
+}
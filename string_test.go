@@ -0,0 +1,46 @@
+package goenvconf
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    fmt.Stringer
+		Expected string
+	}{
+		{Name: "zero", Input: EnvString{}, Expected: ""},
+		{Name: "variable", Input: NewEnvIntVariable("PORT"), Expected: "env:PORT"},
+		{Name: "both", Input: NewEnvString("API_KEY", "s3cr3t"), Expected: "env:API_KEY (default set)"},
+		{Name: "value", Input: NewEnvIntValue(8080), Expected: `value:"8080"`},
+		{Name: "bool", Input: NewEnvBoolValue(true), Expected: `value:"true"`},
+		{Name: "float", Input: NewEnvFloatValue(0.5), Expected: `value:"0.5"`},
+		{Name: "string", Input: NewEnvStringValue(`say "hi"`), Expected: `value:"say \"hi\""`},
+		{Name: "any", Input: NewEnvAnyValue([]any{1}), Expected: `value:"[1]"`},
+		{Name: "slice", Input: NewEnvStringSliceValue([]string{"a", "b"}), Expected: `value:"a,b"`},
+		{Name: "int_slice", Input: NewEnvIntSliceValue([]int64{1, 2}), Expected: `value:"1,2"`},
+		{Name: "map", Input: NewEnvMapBoolValue(map[string]bool{"b": false, "a": true}), Expected: `value:"a=true;b=false"`},
+		{Name: "secret", Input: NewEnvStringValue("s3cr3t").Secret(), Expected: "value:[REDACTED]"},
+		{Name: "secret_variable", Input: NewEnvString("API_KEY", "s3cr3t").Secret(), Expected: "env:API_KEY (default set)"},
+		{Name: "secret_map", Input: NewEnvMapStringValue(map[string]string{"a": "b"}).Secret(), Expected: "value:[REDACTED]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertDeepEqual(t, tc.Expected, tc.Input.String())
+			assertDeepEqual(t, tc.Expected, fmt.Sprintf("%v", tc.Input))
+		})
+	}
+}
+
+func TestString_Pointer(t *testing.T) {
+	port := NewEnvIntValue(8080)
+
+	assertDeepEqual(t, `port: value:"8080"`, fmt.Sprintf("port: %v", &port))
+	assertDeepEqual(t, `{env:HOST value:"8080"}`, fmt.Sprintf("%v", struct {
+		Host EnvString
+		Port EnvInt
+	}{NewEnvStringVariable("HOST"), port}))
+}
@@ -0,0 +1,92 @@
+package goenvconf
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"text/template"
+)
+
+// TemplateFuncs returns the function set for value templates, which can be used with [text/template]:
+//   - env NAME: the value of the environment variable from the getter, or an empty string if unset.
+//   - default DEFAULT VALUE: DEFAULT if VALUE is empty, e.g. {{ env "HOST" | default "localhost" }}.
+//   - required MESSAGE VALUE: returns an error with MESSAGE if VALUE is empty.
+//   - upper, lower, trim: convert the case or trim spaces of a string.
+//   - b64enc, b64dec: encode or decode a standard base64 string.
+//
+// The getter defaults to [GetOSEnv] if nil.
+func TemplateFuncs(getFunc GetEnvFunc) template.FuncMap {
+	if getFunc == nil {
+		getFunc = GetOSEnv
+	}
+
+	return template.FuncMap{
+		"env": func(name string) (string, error) {
+			value, err := getFunc(name)
+			if err != nil && !errors.Is(err, ErrEnvironmentVariableValueRequired) {
+				return "", err
+			}
+
+			return value, nil
+		},
+		"default": func(defaultValue string, value string) string {
+			if value == "" {
+				return defaultValue
+			}
+
+			return value
+		},
+		"required": func(message string, value string) (string, error) {
+			if value == "" {
+				return "", NewParseEnvFailedError(message, "")
+			}
+
+			return value, nil
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+		"b64enc": func(value string) string {
+			return base64.StdEncoding.EncodeToString([]byte(value))
+		},
+		"b64dec": func(value string) (string, error) {
+			result, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return "", NewParseEnvFailedError("invalid base64 value", err.Error())
+			}
+
+			return string(result), nil
+		},
+	}
+}
+
+// ExecuteTemplate renders a value template with [TemplateFuncs], e.g. {{ env "HOST" | default "localhost" | upper }}.
+func ExecuteTemplate(text string, getFunc GetEnvFunc) (string, error) {
+	tmpl, err := template.New("").Funcs(TemplateFuncs(getFunc)).Parse(text)
+	if err != nil {
+		return "", NewParseEnvFailedError("invalid template", err.Error())
+	}
+
+	var sb strings.Builder
+
+	if err := tmpl.Execute(&sb, nil); err != nil {
+		var parseErr ParseEnvError
+		if errors.As(err, &parseErr) {
+			return "", parseErr
+		}
+
+		return "", NewParseEnvFailedError("failed to execute the template", err.Error())
+	}
+
+	return sb.String(), nil
+}
+
+// WithTemplate returns a copy of the instance which renders the literal value as a value template
+// with [TemplateFuncs] and the getter of Get or GetCustom, instead of expanding placeholders,
+// e.g. {{ env "HOST" | default "localhost" }}. Environment values are never rendered.
+func (ev EnvString) WithTemplate() EnvString {
+	ev.options = ev.options.clone()
+	ev.options.template = true
+
+	return ev
+}
@@ -0,0 +1,87 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExecuteTemplate(t *testing.T) {
+	getFunc := func(name string) (string, error) {
+		switch name {
+		case "HOST":
+			return " Example.com ", nil
+		case "SECRET":
+			return "c2VjcmV0", nil
+		case "BROKEN":
+			return "", errors.New("broken")
+		default:
+			return "", ErrEnvironmentVariableValueRequired
+		}
+	}
+
+	testCases := []struct {
+		Input    string
+		Expected string
+		ErrorMsg string
+	}{
+		{Input: "plain", Expected: "plain"},
+		{Input: `{{ env "HOST" | trim | lower }}`, Expected: "example.com"},
+		{Input: `{{ env "HOST" | trim | upper }}`, Expected: "EXAMPLE.COM"},
+		{Input: `{{ env "MISSING" | default "localhost" }}`, Expected: "localhost"},
+		{Input: `{{ env "HOST" | default "localhost" | trim }}`, Expected: "Example.com"},
+		{Input: `{{ env "SECRET" | b64dec }}`, Expected: "secret"},
+		{Input: `{{ "secret" | b64enc }}`, Expected: "c2VjcmV0"},
+		{Input: `https://{{ env "HOST" | required "HOST is required" | trim }}/api`, Expected: "https://Example.com/api"},
+		{Input: `{{ env "MISSING" | required "MISSING is required" }}`, ErrorMsg: "ParseEnvFailed: MISSING is required"},
+		{Input: `{{ env "HOST" | b64dec }}`, ErrorMsg: "invalid base64 value"},
+		{Input: `{{ env "BROKEN" }}`, ErrorMsg: "broken"},
+		{Input: `{{ env "HOST" `, ErrorMsg: "invalid template"},
+		{Input: `{{ unknown }}`, ErrorMsg: "invalid template"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			result, err := ExecuteTemplate(tc.Input, getFunc)
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestEnvString_WithTemplate(t *testing.T) {
+	getFunc := newBinderGetter(map[string]string{"HOST": "Example.com", "TEMPLATE_URL": `{{ env "HOST" }}`})
+
+	url := NewEnvString("TEMPLATE_URL", `https://{{ env "HOST" | lower }}/{{ env "PATH_PREFIX" | default "api" }}`).
+		WithTemplate()
+
+	// The environment value is returned as-is.
+	result, err := url.GetCustom(getFunc)
+	assertNilError(t, err)
+	assertDeepEqual(t, `{{ env "HOST" }}`, result)
+
+	result, err = url.GetCustom(newBinderGetter(map[string]string{"HOST": "Example.com"}))
+	assertNilError(t, err)
+	assertDeepEqual(t, "https://example.com/api", result)
+
+	t.Setenv("TEMPLATE_HOST", "localhost")
+
+	result, err = NewEnvStringValue(`{{ env "TEMPLATE_HOST" | upper }}`).WithTemplate().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "LOCALHOST", result)
+
+	// Placeholders are not expanded in templates, and expansion can be disabled entirely.
+	result, err = NewEnvStringValue(`${TEMPLATE_HOST}`).WithTemplate().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "${TEMPLATE_HOST}", result)
+
+	result, err = NewEnvStringValue(`{{ "a" }}`).WithTemplate().WithoutExpansion().Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, `{{ "a" }}`, result)
+
+	_, err = NewEnvStringValue(`{{ env "MISSING" | required "MISSING is required" }}`).WithTemplate().GetCustom(getFunc)
+	assertErrorContains(t, err, "ParseEnvFailed: MISSING is required")
+}
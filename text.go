@@ -0,0 +1,371 @@
+package goenvconf
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The MarshalText and UnmarshalText methods encode Env types to the compact text form:
+//   - "${VAR}" if only the variable is set.
+//   - The literal value if only the value is set. Slices use the comma-separated format,
+//     or a JSON array if string items contain commas or are empty. Maps use the <key1>=<value1>;<key2>=<value2> format and EnvAny uses JSON.
+//   - "${VAR:-literal}" if both are set.
+//   - An empty string if the instance is zero.
+
+// marshalEnvText encodes an Env instance to the compact text form.
+func marshalEnvText(variable *string, hasValue bool, formatValue func() (string, error)) ([]byte, error) {
+	hasVariable := variable != nil && *variable != ""
+
+	if !hasValue {
+		if hasVariable {
+			return []byte("${" + *variable + "}"), nil
+		}
+
+		return []byte{}, nil
+	}
+
+	literal, err := formatValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if hasVariable {
+		return []byte("${" + *variable + ":-" + literal + "}"), nil
+	}
+
+	if _, ok := parseEnvReference(literal); ok {
+		return nil, NewParseEnvFailedError(
+			"the literal value is a variable reference and cannot be encoded in the compact text form",
+			literal,
+		)
+	}
+
+	return []byte(literal), nil
+}
+
+// textInput returns the raw input of the text to be decoded, or nil if the text is empty.
+func textInput(text []byte) any {
+	if len(text) == 0 {
+		return nil
+	}
+
+	return string(text)
+}
+
+func formatIntText(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
+
+func formatFloatText(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func formatSliceText[T any](values []T, format func(T) string) string {
+	items := make([]string, len(values))
+
+	for i, value := range values {
+		items[i] = format(value)
+	}
+
+	return strings.Join(items, ",")
+}
+
+// formatStringSliceText joins items with commas, or encodes them as a JSON array
+// if the comma-separated form would be ambiguous.
+func formatStringSliceText(values []string) string {
+	result := strings.Join(values, ",")
+
+	if slices.ContainsFunc(values, func(value string) bool {
+		return value == "" || strings.Contains(value, ",")
+	}) || strings.HasPrefix(strings.TrimSpace(result), "[") {
+		bytes, _ := json.Marshal(values)
+
+		return string(bytes)
+	}
+
+	return result
+}
+
+func formatMapText[T any](values map[string]T, format func(T) string) string {
+	items := make([]string, 0, len(values))
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		items = append(items, quoteMapToken(key)+"="+quoteMapToken(format(values[key])))
+	}
+
+	return strings.Join(items, ";")
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvString) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return *ev.Value, nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvString) UnmarshalText(text []byte) error {
+	result, err := decodeEnvString(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvInt) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return strconv.FormatInt(*ev.Value, 10), nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvInt) UnmarshalText(text []byte) error {
+	result, err := decodeEnvInt(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvBool) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return strconv.FormatBool(*ev.Value), nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvBool) UnmarshalText(text []byte) error {
+	result, err := decodeEnvBool(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvFloat) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return formatFloatText(*ev.Value), nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvFloat) UnmarshalText(text []byte) error {
+	result, err := decodeEnvFloat(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvAny) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		result, err := json.Marshal(ev.Value)
+
+		return string(result), err
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The literal value is decoded as JSON.
+func (ev *EnvAny) UnmarshalText(text []byte) error {
+	result, err := decodeEnvAny(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	if literal, ok := result.Value.(string); ok {
+		result.Value = nil
+
+		if err := json.Unmarshal([]byte(literal), &result.Value); err != nil {
+			return NewParseEnvFailedError("invalid JSON value", err.Error())
+		}
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvStringSlice) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return formatStringSliceText(ev.Value), nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvStringSlice) UnmarshalText(text []byte) error {
+	result, err := decodeEnvStringSlice(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvIntSlice) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return formatSliceText(ev.Value, formatIntText), nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvIntSlice) UnmarshalText(text []byte) error {
+	result, err := decodeEnvIntSlice(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvFloatSlice) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return formatSliceText(ev.Value, formatFloatText), nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvFloatSlice) UnmarshalText(text []byte) error {
+	result, err := decodeEnvFloatSlice(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvBoolSlice) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return formatSliceText(ev.Value, strconv.FormatBool), nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvBoolSlice) UnmarshalText(text []byte) error {
+	result, err := decodeEnvBoolSlice(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvMapString) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return formatMapText(ev.Value, func(s string) string { return s }), nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvMapString) UnmarshalText(text []byte) error {
+	result, err := decodeEnvMapString(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvMapInt) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return formatMapText(ev.Value, formatIntText), nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvMapInt) UnmarshalText(text []byte) error {
+	result, err := decodeEnvMapInt(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvMapFloat) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return formatMapText(ev.Value, formatFloatText), nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvMapFloat) UnmarshalText(text []byte) error {
+	result, err := decodeEnvMapFloat(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ev EnvMapBool) MarshalText() ([]byte, error) {
+	return marshalEnvText(ev.Variable, ev.Value != nil, func() (string, error) {
+		return formatMapText(ev.Value, strconv.FormatBool), nil
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ev *EnvMapBool) UnmarshalText(text []byte) error {
+	result, err := decodeEnvMapBool(textInput(text))
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package goenvconf
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestMarshalText(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    encoding.TextMarshaler
+		Expected string
+		ErrorMsg string
+	}{
+		{Name: "zero", Input: EnvString{}, Expected: ""},
+		{Name: "string_variable", Input: NewEnvStringVariable("FOO"), Expected: "${FOO}"},
+		{Name: "string_value", Input: NewEnvStringValue("bar"), Expected: "bar"},
+		{Name: "string_both", Input: NewEnvString("FOO", "bar"), Expected: "${FOO:-bar}"},
+		{
+			Name:     "string_reference_value",
+			Input:    NewEnvStringValue("${FOO}"),
+			ErrorMsg: "the literal value is a variable reference and cannot be encoded in the compact text form",
+		},
+		{Name: "int", Input: NewEnvInt("PORT", 8080), Expected: "${PORT:-8080}"},
+		{Name: "bool", Input: NewEnvBoolValue(true), Expected: "true"},
+		{Name: "float", Input: NewEnvFloatValue(0.5), Expected: "0.5"},
+		{Name: "any", Input: NewEnvAnyValue(map[string]any{"foo": "bar"}), Expected: `{"foo":"bar"}`},
+		{Name: "string_slice", Input: NewEnvStringSliceValue([]string{"a", "b"}), Expected: "a,b"},
+		{Name: "string_slice_json", Input: NewEnvStringSliceValue([]string{"a,b", ""}), Expected: `["a,b",""]`},
+		{Name: "string_slice_bracket", Input: NewEnvStringSliceValue([]string{"[a]"}), Expected: `["[a]"]`},
+		{Name: "int_slice", Input: NewEnvIntSlice("PORTS", []int64{1, 2}), Expected: "${PORTS:-1,2}"},
+		{Name: "float_slice", Input: NewEnvFloatSliceValue([]float64{1.5, 2}), Expected: "1.5,2"},
+		{Name: "bool_slice", Input: NewEnvBoolSliceVariable("FLAGS"), Expected: "${FLAGS}"},
+		{Name: "map_string", Input: NewEnvMapStringValue(map[string]string{"b": "2", "a": "1"}), Expected: "a=1;b=2"},
+		{
+			Name:     "map_string_quoted",
+			Input:    NewEnvMapStringValue(map[string]string{"dsn": "host=a;port=1", "q": `"x"`}),
+			Expected: `dsn="host=a;port=1";q="\"x\""`,
+		},
+		{Name: "map_int", Input: NewEnvMapIntValue(map[string]int64{"a": 1}), Expected: "a=1"},
+		{Name: "map_float", Input: NewEnvMapFloatValue(map[string]float64{"a": 1.5}), Expected: "a=1.5"},
+		{Name: "map_bool", Input: NewEnvMapBool("FEATURES", map[string]bool{"x": true}), Expected: "${FEATURES:-x=true}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := tc.Input.MarshalText()
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, string(result))
+			}
+		})
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		var result EnvString
+
+		assertNilError(t, result.UnmarshalText([]byte("${FOO:-bar}")))
+		assertDeepEqual(t, NewEnvString("FOO", "bar"), result)
+
+		assertNilError(t, result.UnmarshalText([]byte("")))
+		assertDeepEqual(t, EnvString{}, result)
+	})
+
+	t.Run("int", func(t *testing.T) {
+		var result EnvInt
+
+		assertNilError(t, result.UnmarshalText([]byte("8080")))
+		assertDeepEqual(t, NewEnvIntValue(8080), result)
+
+		assertErrorContains(t, result.UnmarshalText([]byte("foo")), "invalid integer value")
+	})
+
+	t.Run("any", func(t *testing.T) {
+		var result EnvAny
+
+		assertNilError(t, result.UnmarshalText([]byte(`{"foo":"bar"}`)))
+		assertDeepEqual(t, NewEnvAnyValue(map[string]any{"foo": "bar"}), result)
+
+		assertNilError(t, result.UnmarshalText([]byte("${EXTRA}")))
+		assertDeepEqual(t, NewEnvAnyVariable("EXTRA"), result)
+
+		assertErrorContains(t, result.UnmarshalText([]byte("{")), "invalid JSON value")
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		var result EnvIntSlice
+
+		assertNilError(t, result.UnmarshalText([]byte("${PORTS:-1,2}")))
+		assertDeepEqual(t, NewEnvIntSlice("PORTS", []int64{1, 2}), result)
+
+		var strs EnvStringSlice
+
+		assertNilError(t, strs.UnmarshalText([]byte(`${TAGS:-["a,b",""]}`)))
+		assertDeepEqual(t, NewEnvStringSlice("TAGS", []string{"a,b", ""}), strs)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		var result EnvMapString
+
+		assertNilError(t, result.UnmarshalText([]byte("a=1;b=2")))
+		assertDeepEqual(t, NewEnvMapStringValue(map[string]string{"a": "1", "b": "2"}), result)
+
+		assertNilError(t, result.UnmarshalText([]byte(`dsn="host=a;port=1"`)))
+		assertDeepEqual(t, NewEnvMapStringValue(map[string]string{"dsn": "host=a;port=1"}), result)
+	})
+
+	t.Run("preserve_options", func(t *testing.T) {
+		result := NewEnvStringValue("foo").WithCompactEncoding()
+
+		assertNilError(t, result.UnmarshalText([]byte("${FOO}")))
+		assertDeepEqual(t, true, result.options.isCompact())
+	})
+}
@@ -0,0 +1,47 @@
+package goenvconf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NewTimeoutGetEnvFunc creates a getter which calls the inner getter with a context that is cancelled after the timeout,
+// so a hanging secret backend cannot stall the service startup indefinitely.
+// Timeouts return an error wrapping [ErrGetEnvTimeout], which is distinct from [ErrEnvironmentVariableValueRequired].
+// The getter returns on timeout even if the inner getter ignores the context, but the inner call keeps running
+// in the background until it returns.
+func NewTimeoutGetEnvFunc(inner GetEnvFuncContext, timeout time.Duration) GetEnvFunc {
+	type getEnvResult struct {
+		value string
+		err   error
+	}
+
+	return func(name string) (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		done := make(chan getEnvResult, 1)
+
+		go func() {
+			value, err := inner(ctx, name)
+			done <- getEnvResult{value: value, err: err}
+		}()
+
+		select {
+		case result := <-done:
+			if result.err != nil && errors.Is(result.err, context.DeadlineExceeded) {
+				return "", newGetEnvTimeoutError(name, result.err)
+			}
+
+			return result.value, result.err
+		case <-ctx.Done():
+			return "", newGetEnvTimeoutError(name, ctx.Err())
+		}
+	}
+}
+
+func newGetEnvTimeoutError(name string, err error) error {
+	return fmt.Errorf("%w: %s: %w", ErrGetEnvTimeout, name, err)
+}
@@ -0,0 +1,49 @@
+package goenvconf
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewTimeoutGetEnvFunc(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	getFunc := NewTimeoutGetEnvFunc(func(ctx context.Context, name string) (string, error) {
+		switch name {
+		case "FOO":
+			return "bar", nil
+		case "HANG":
+			<-release
+
+			return "late", nil
+		case "SLOW":
+			<-ctx.Done()
+
+			return "", ctx.Err()
+		default:
+			return "", ErrEnvironmentVariableValueRequired
+		}
+	}, 20*time.Millisecond)
+
+	value, err := getFunc("FOO")
+	assertNilError(t, err)
+	assertDeepEqual(t, "bar", value)
+
+	_, err = getFunc("MISSING")
+	assertDeepEqual(t, true, errors.Is(err, ErrEnvironmentVariableValueRequired))
+	assertDeepEqual(t, false, errors.Is(err, ErrGetEnvTimeout))
+
+	for _, name := range []string{"HANG", "SLOW"} {
+		_, err = getFunc(name)
+		assertDeepEqual(t, true, errors.Is(err, ErrGetEnvTimeout))
+		assertDeepEqual(t, true, errors.Is(err, context.DeadlineExceeded))
+		assertDeepEqual(t, false, errors.Is(err, ErrEnvironmentVariableValueRequired))
+		assertErrorContains(t, err, "GetEnvTimeout: timed out getting the environment variable: "+name)
+	}
+
+	_, err = NewEnvStringVariable("SLOW").GetCustom(getFunc)
+	assertDeepEqual(t, true, errors.Is(err, ErrGetEnvTimeout))
+}
@@ -0,0 +1,371 @@
+package goenvconf
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The UnmarshalTOML methods implement the Unmarshaler interface of [BurntSushi/toml].
+// In addition to tables with value and env keys, they accept the same shorthand forms as [DecodeHook].
+//
+// The MarshalTOML methods always encode the inline table form, e.g. {value = 8080, env = "PORT"}.
+//
+// [BurntSushi/toml]: https://github.com/BurntSushi/toml
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvString) UnmarshalTOML(data any) error {
+	result, err := decodeEnvString(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvInt) UnmarshalTOML(data any) error {
+	result, err := decodeEnvInt(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvBool) UnmarshalTOML(data any) error {
+	result, err := decodeEnvBool(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvFloat) UnmarshalTOML(data any) error {
+	result, err := decodeEnvFloat(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvAny) UnmarshalTOML(data any) error {
+	result, err := decodeEnvAny(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvStringSlice) UnmarshalTOML(data any) error {
+	result, err := decodeEnvStringSlice(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvIntSlice) UnmarshalTOML(data any) error {
+	result, err := decodeEnvIntSlice(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvFloatSlice) UnmarshalTOML(data any) error {
+	result, err := decodeEnvFloatSlice(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvBoolSlice) UnmarshalTOML(data any) error {
+	result, err := decodeEnvBoolSlice(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvMapString) UnmarshalTOML(data any) error {
+	result, err := decodeEnvMapString(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvMapInt) UnmarshalTOML(data any) error {
+	result, err := decodeEnvMapInt(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvMapFloat) UnmarshalTOML(data any) error {
+	result, err := decodeEnvMapFloat(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface.
+func (ev *EnvMapBool) UnmarshalTOML(data any) error {
+	result, err := decodeEnvMapBool(data)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvString) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvInt) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvBool) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvFloat) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvAny) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvStringSlice) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvIntSlice) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvFloatSlice) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvBoolSlice) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvMapString) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvMapInt) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvMapFloat) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// MarshalTOML implements the toml.Marshaler interface.
+func (ev EnvMapBool) MarshalTOML() ([]byte, error) {
+	return marshalTOMLInlineTable(ev.Value != nil, ev.Value, ev.Variable)
+}
+
+// marshalTOMLInlineTable encodes the value and env fields to a TOML inline table.
+func marshalTOMLInlineTable(hasValue bool, value any, variable *string) ([]byte, error) {
+	fields := make([]string, 0, 2)
+
+	if hasValue {
+		literal, err := encodeTOMLValue(reflect.ValueOf(value))
+		if err != nil {
+			return nil, err
+		}
+
+		if literal != "" {
+			fields = append(fields, "value = "+literal)
+		}
+	}
+
+	if variable != nil {
+		fields = append(fields, "env = "+encodeTOMLString(*variable))
+	}
+
+	return []byte("{" + strings.Join(fields, ", ") + "}"), nil
+}
+
+// encodeTOMLValue encodes an inline TOML value. Returns an empty string if the value is null.
+func encodeTOMLValue(value reflect.Value) (string, error) { //nolint:cyclop
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", nil
+		}
+
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return encodeTOMLString(value.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return encodeTOMLFloat(value.Float()), nil
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, value.Len())
+
+		for i := range value.Len() {
+			item, err := encodeTOMLValue(value.Index(i))
+			if err != nil {
+				return "", err
+			}
+
+			if item == "" {
+				return "", NewParseEnvFailedError("TOML arrays do not support null items", strconv.Itoa(i))
+			}
+
+			items = append(items, item)
+		}
+
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case reflect.Map:
+		keys := make([]string, 0, value.Len())
+		entries := make(map[string]string, value.Len())
+
+		for iter := value.MapRange(); iter.Next(); {
+			item, err := encodeTOMLValue(iter.Value())
+			if err != nil {
+				return "", err
+			}
+
+			if item == "" {
+				continue
+			}
+
+			key := fmt.Sprint(iter.Key().Interface())
+			keys = append(keys, key)
+			entries[key] = item
+		}
+
+		slices.Sort(keys)
+
+		items := make([]string, len(keys))
+
+		for i, key := range keys {
+			items[i] = encodeTOMLString(key) + " = " + entries[key]
+		}
+
+		return "{" + strings.Join(items, ", ") + "}", nil
+	default:
+		return "", NewParseEnvFailedError("unsupported TOML value", value.Type().String())
+	}
+}
+
+// encodeTOMLString encodes a TOML basic string. JSON string escapes are valid in TOML.
+func encodeTOMLString(value string) string {
+	result, _ := json.Marshal(value)
+
+	return string(result)
+}
+
+// encodeTOMLFloat encodes a TOML float, which always contains a decimal point or an exponent.
+func encodeTOMLFloat(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "nan"
+	case math.IsInf(value, 1):
+		return "inf"
+	case math.IsInf(value, -1):
+		return "-inf"
+	}
+
+	result := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(result, ".eE") {
+		result += ".0"
+	}
+
+	return result
+}
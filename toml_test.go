@@ -0,0 +1,80 @@
+package goenvconf
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+type tomlTestConfig struct {
+	Name     EnvString      `toml:"name"`
+	Port     EnvInt         `toml:"port"`
+	Debug    EnvBool        `toml:"debug"`
+	Ratio    EnvFloat       `toml:"ratio"`
+	Extra    EnvAny         `toml:"extra"`
+	Hosts    EnvStringSlice `toml:"hosts"`
+	Ports    EnvIntSlice    `toml:"ports"`
+	Weights  EnvFloatSlice  `toml:"weights"`
+	Flags    EnvBoolSlice   `toml:"flags"`
+	Headers  EnvMapString   `toml:"headers"`
+	Limits   EnvMapInt      `toml:"limits"`
+	Scores   EnvMapFloat    `toml:"scores"`
+	Features EnvMapBool     `toml:"features"`
+	Pointer  *EnvString     `toml:"pointer"`
+}
+
+func TestTOML_RoundTrip(t *testing.T) {
+	input := tomlTestConfig{
+		Name:     NewEnvString("APP_NAME", "app"),
+		Port:     NewEnvIntValue(8080),
+		Debug:    NewEnvBoolVariable("DEBUG"),
+		Ratio:    NewEnvFloat("RATIO", 0.5),
+		Extra:    NewEnvAnyValue(map[string]any{"foo": "bar"}),
+		Hosts:    NewEnvStringSlice("HOSTS", []string{"a", "b"}),
+		Ports:    NewEnvIntSliceValue([]int64{1, 2}),
+		Weights:  NewEnvFloatSliceVariable("WEIGHTS"),
+		Flags:    NewEnvBoolSliceValue([]bool{true}),
+		Headers:  NewEnvMapString("HEADERS", map[string]string{"foo": "bar"}),
+		Limits:   NewEnvMapIntValue(map[string]int64{"a": 1}),
+		Scores:   NewEnvMapFloatVariable("SCORES"),
+		Features: NewEnvMapBoolValue(map[string]bool{"x": true}),
+		Pointer:  toPtr(NewEnvStringVariable("POINTER")),
+	}
+
+	var buf bytes.Buffer
+
+	assertNilError(t, toml.NewEncoder(&buf).Encode(input))
+
+	var output tomlTestConfig
+
+	_, err := toml.Decode(buf.String(), &output)
+	assertNilError(t, err)
+	assertDeepEqual(t, input, output)
+}
+
+func TestTOML_Shorthand(t *testing.T) {
+	var output tomlTestConfig
+
+	_, err := toml.Decode(`
+name = "${APP_NAME}"
+port = 8080
+hosts = ["a", "b"]
+limits = "a=1;b=2"
+
+[debug]
+env = "DEBUG"
+value = true
+`, &output)
+	assertNilError(t, err)
+	assertDeepEqual(t, tomlTestConfig{
+		Name:   NewEnvStringVariable("APP_NAME"),
+		Port:   NewEnvIntValue(8080),
+		Debug:  NewEnvBool("DEBUG", true),
+		Hosts:  NewEnvStringSliceValue([]string{"a", "b"}),
+		Limits: NewEnvMapIntValue(map[string]int64{"a": 1, "b": 2}),
+	}, output)
+
+	_, err = toml.Decode(`port = "eighty"`, &output)
+	assertErrorContains(t, err, "invalid integer value")
+}
@@ -0,0 +1,123 @@
+package goenvconf
+
+// The WithTransform methods return a copy of the instance with transforms attached, e.g. to lowercase
+// or strip the trailing slash, so small massaging logic lives with the field definition.
+// Transforms run in order on the resolved value after parsing, and before constraints and validators.
+// The first error is returned by Get and GetCustom, prefixed with the variable name if set.
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvString) WithTransform(transforms ...func(string) (string, error)) EnvString {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvInt) WithTransform(transforms ...func(int64) (int64, error)) EnvInt {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvBool) WithTransform(transforms ...func(bool) (bool, error)) EnvBool {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvFloat) WithTransform(transforms ...func(float64) (float64, error)) EnvFloat {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvAny) WithTransform(transforms ...func(any) (any, error)) EnvAny {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvStringSlice) WithTransform(transforms ...func([]string) ([]string, error)) EnvStringSlice {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvIntSlice) WithTransform(transforms ...func([]int64) ([]int64, error)) EnvIntSlice {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvFloatSlice) WithTransform(transforms ...func([]float64) ([]float64, error)) EnvFloatSlice {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvBoolSlice) WithTransform(transforms ...func([]bool) ([]bool, error)) EnvBoolSlice {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvMapString) WithTransform(transforms ...func(map[string]string) (map[string]string, error)) EnvMapString {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvMapInt) WithTransform(transforms ...func(map[string]int64) (map[string]int64, error)) EnvMapInt {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvMapFloat) WithTransform(transforms ...func(map[string]float64) (map[string]float64, error)) EnvMapFloat {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
+
+// WithTransform returns a copy of the instance with transforms attached.
+func (ev EnvMapBool) WithTransform(transforms ...func(map[string]bool) (map[string]bool, error)) EnvMapBool {
+	for _, transform := range transforms {
+		ev.options = withTransform(ev.options, transform)
+	}
+
+	return ev
+}
@@ -0,0 +1,60 @@
+package goenvconf
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWithTransform(t *testing.T) {
+	t.Setenv("TEST_TRANSFORM_URL", "HTTPS://Example.com/")
+	t.Setenv("TEST_TRANSFORM_PORT", "8080")
+
+	toLower := func(value string) (string, error) {
+		return strings.ToLower(value), nil
+	}
+	trimSlash := func(value string) (string, error) {
+		return strings.TrimSuffix(value, "/"), nil
+	}
+
+	source := NewEnvStringVariable("TEST_TRANSFORM_URL")
+
+	result, err := source.WithTransform(toLower, trimSlash).WithPattern(regexp.MustCompile(`^https://[a-z.]+$`)).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "https://example.com", result)
+
+	result, err = source.Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, "HTTPS://Example.com/", result)
+
+	errTooLarge := errors.New("too large")
+
+	_, err = NewEnvIntVariable("TEST_TRANSFORM_PORT").WithTransform(func(value int64) (int64, error) {
+		if value > 1024 {
+			return 0, errTooLarge
+		}
+
+		return value, nil
+	}).Get()
+	assertDeepEqual(t, true, errors.Is(err, errTooLarge))
+	assertErrorContains(t, err, "TEST_TRANSFORM_PORT: too large")
+
+	origins, err := NewEnvStringSliceValue([]string{"b", "a"}).WithTransform(func(value []string) ([]string, error) {
+		return slices.Sorted(slices.Values(value)), nil
+	}).GetCustom(GetOSEnv)
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a", "b"}, origins)
+
+	headers, err := NewEnvMapStringValue(map[string]string{"A": "1"}).WithTransform(func(value map[string]string) (map[string]string, error) {
+		result := make(map[string]string, len(value))
+		for key, item := range value {
+			result[strings.ToLower(key)] = item
+		}
+
+		return result, nil
+	}).Get()
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]string{"a": "1"}, headers)
+}
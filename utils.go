@@ -2,139 +2,518 @@ package goenvconf
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
-)
-
-const (
-	keyValueLength = 2
+	"time"
+	"unicode/utf8"
 )
 
 // ParseStringMapFromString parses a string map from a string with format:
 //
 //	<key1>=<value1>;<key2>=<value2>
+//
+// Each item is split on the first '=' character, so values can contain '=' characters, e.g. token=abc==.
+// Keys and values can be wrapped in double quotes to contain ';' and '=' characters, e.g. dsn="host=a;port=5432".
+// Use \" and \\ to escape quotes and backslashes inside quotes.
+// Outside quotes, a backslash escapes the following ';', '=', '"' or '\' character, e.g. token=abc\=\=.
+// Other backslashes are kept as-is.
+//
+// The input is parsed as a JSON object if it starts with '{', e.g. {"dsn": "host=a;port=5432"}.
 func ParseStringMapFromString(input string) (map[string]string, error) {
-	result := make(map[string]string)
 	if input == "" {
-		return result, nil
+		return map[string]string{}, nil
+	}
+
+	if results, ok, err := parseJSONObject[string](input); ok {
+		return results, err
+	}
+
+	return parseMapValues(input, func(_ string, value string) (string, error) {
+		return value, nil
+	})
+}
+
+// parseMapValues scans items of the <key1>=<value1>;<key2>=<value2> syntax and converts values by the parse function,
+// without building an intermediate string map. The input must not be a JSON object.
+func parseMapValues[T any](input string, parse func(key string, value string) (T, error)) (map[string]T, error) {
+	if input == "" {
+		return map[string]T{}, nil
 	}
 
-	rawItems := strings.SplitSeq(input, ";")
+	var err error
+
+	result := make(map[string]T, strings.Count(input, ";")+1)
 
-	for rawItem := range rawItems {
-		keyValue := strings.Split(rawItem, "=")
+	for rest, more := input, true; more; {
+		var rawItem string
 
-		if len(keyValue) != keyValueLength || keyValue[0] == "" {
+		rawItem, rest, more, err = cutMapToken(rest, ';')
+		if err != nil {
+			return nil, err
+		}
+
+		rawKey, rawValue, found, err := cutMapToken(rawItem, '=')
+		if err != nil {
+			return nil, err
+		}
+
+		key := unquoteMapToken(rawKey)
+
+		if !found || key == "" {
 			return nil, NewParseEnvFailedError(
 				"invalid string map syntax, expected: <key1>=<value1>;<key2>=<value2>",
-				keyValue[0],
+				key,
 			)
 		}
 
-		result[keyValue[0]] = keyValue[1]
+		value, err := parse(key, unquoteMapToken(rawValue))
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = value
 	}
 
 	return result, nil
 }
 
+// parseJSONObject parses the input as a JSON object if it starts with '{'.
+// Returns false if the input is not a JSON object.
+func parseJSONObject[T any](input string) (map[string]T, bool, error) {
+	trimmed := strings.TrimSpace(input)
+	if !strings.HasPrefix(trimmed, "{") {
+		return nil, false, nil
+	}
+
+	results := make(map[string]T, jsonItemsHint[T](trimmed))
+
+	if err := json.Unmarshal([]byte(trimmed), &results); err != nil {
+		return nil, true, NewParseEnvFailedError("invalid JSON object syntax", err.Error())
+	}
+
+	return results, true, nil
+}
+
+// cutMapToken slices the input around the first separator that is not inside quotes
+// or escaped by backslashes, like [strings.Cut].
+// A double quote only starts a quoted token at the beginning of the input or after a ';' or '=' character.
+func cutMapToken(input string, separator byte) (before string, after string, found bool, err error) {
+	// Fast path: no quotes or escapes can affect the first separator.
+	before, after, found = strings.Cut(input, string(separator))
+	if !hasQuoteOrEscape(before) {
+		return before, after, found, nil
+	}
+
+	tokenStart := true
+
+	for i := 0; i < len(input); i++ {
+		char := input[i]
+
+		switch {
+		case char == '"' && tokenStart:
+			end := findClosingQuote(input, i+1)
+			if end < 0 {
+				return "", "", false, NewParseEnvFailedError("unterminated quoted string in map syntax", input[i:])
+			}
+
+			i = end
+		case char == '\\' && i+1 < len(input) && isMapEscapable(input[i+1]):
+			i++
+		case char == separator:
+			return input[:i], input[i+1:], true, nil
+		}
+
+		tokenStart = char == ';' || char == '='
+	}
+
+	return input, "", false, nil
+}
+
+// findClosingQuote returns the index of the closing double quote from the start index, or -1 if not found.
+func findClosingQuote(input string, start int) int {
+	for i := start; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// unquoteMapToken removes quotes and escape characters of a map key or value.
+func unquoteMapToken(token string) string {
+	if !hasQuoteOrEscape(token) {
+		return token
+	}
+
+	quoted := len(token) >= 2 && token[0] == '"' && token[len(token)-1] == '"'
+	if quoted {
+		token = token[1 : len(token)-1]
+	}
+
+	var sb strings.Builder
+
+	sb.Grow(len(token))
+
+	for i := 0; i < len(token); i++ {
+		if token[i] == '\\' && i+1 < len(token) &&
+			(token[i+1] == '"' || token[i+1] == '\\' || (!quoted && isMapEscapable(token[i+1]))) {
+			i++
+		}
+
+		sb.WriteByte(token[i])
+	}
+
+	return sb.String()
+}
+
+// quoteMapToken wraps the map key or value in double quotes if it contains special characters
+// or starts with '{', which would be detected as a JSON object.
+func quoteMapToken(token string) string {
+	if !strings.ContainsAny(token, `;="\`) && !strings.HasPrefix(strings.TrimSpace(token), "{") {
+		return token
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(token) + `"`
+}
+
+// trimSpace returns the input without leading and trailing white space like [strings.TrimSpace],
+// but returns early if both ends are printable ASCII characters, which is the common case of environment values.
+func trimSpace(input string) string {
+	if input == "" || (isPrintableASCII(input[0]) && isPrintableASCII(input[len(input)-1])) {
+		return input
+	}
+
+	return strings.TrimSpace(input)
+}
+
+func isPrintableASCII(char byte) bool {
+	return char > ' ' && char < utf8.RuneSelf
+}
+
+// hasQuoteOrEscape checks if the input contains double quotes or backslashes.
+// It is a byte loop because strings.ContainsAny is several times slower for short map tokens.
+func hasQuoteOrEscape(input string) bool {
+	for i := range len(input) {
+		if input[i] == '"' || input[i] == '\\' {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isMapEscapable(char byte) bool {
+	return char == ';' || char == '=' || char == '"' || char == '\\'
+}
+
 // ParseIntegerMapFromString parses an integer map from a string with format:
 //
 //	<key1>=<value1>;<key2>=<value2>
+//
+// The input is parsed as a JSON object if it starts with '{'.
 func ParseIntegerMapFromString[T int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64](
 	input string,
 ) (map[string]T, error) {
-	rawValues, err := ParseStringMapFromString(input)
-	if err != nil {
-		return nil, err
-	}
+	return parseIntegerMapFromString(input, parseInt[T])
+}
 
-	result := make(map[string]T)
+func parseIntegerMapFromString[T int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64](
+	input string,
+	parse func(string) (T, error),
+) (map[string]T, error) {
+	if results, ok, err := parseJSONObject[T](input); ok {
+		return results, err
+	}
 
-	for key, value := range rawValues {
-		intValue, err := parseInt[T](value)
+	return parseMapValues(input, func(key string, value string) (T, error) {
+		intValue, err := parse(trimSpace(value))
 		if err != nil {
-			return nil, NewParseEnvFailedError("invalid integer map syntax", key)
+			return intValue, NewParseEnvFailedError("invalid integer map syntax", key)
 		}
 
-		result[key] = intValue
-	}
-
-	return result, nil
+		return intValue, nil
+	})
 }
 
 // ParseFloatMapFromString parses a float map from a string with format:
 //
 //	<key1>=<value1>;<key2>=<value2>
+//
+// The input is parsed as a JSON object if it starts with '{'.
 func ParseFloatMapFromString[T float32 | float64](input string) (map[string]T, error) {
-	rawValues, err := ParseStringMapFromString(input)
-	if err != nil {
-		return nil, err
+	if results, ok, err := parseJSONObject[T](input); ok {
+		return results, err
 	}
 
-	result := make(map[string]T)
-
-	for key, value := range rawValues {
+	return parseMapValues(input, func(key string, value string) (T, error) {
 		floatValue, err := parseFloat[T](value)
 		if err != nil {
-			return nil, NewParseEnvFailedError("invalid float map syntax", key)
+			return floatValue, NewParseEnvFailedError("invalid float map syntax", key)
 		}
 
-		result[key] = floatValue
-	}
-
-	return result, nil
+		return floatValue, nil
+	})
 }
 
 // ParseBoolMapFromString parses a bool map from a string with format:
 //
 //	<key1>=<value1>;<key2>=<value2>
+//
+// The input is parsed as a JSON object if it starts with '{'.
 func ParseBoolMapFromString(input string) (map[string]bool, error) {
-	rawValues, err := ParseStringMapFromString(input)
+	return parseBoolMapFromString(input, strconv.ParseBool)
+}
+
+func parseBoolMapFromString(input string, parseBool func(string) (bool, error)) (map[string]bool, error) {
+	if results, ok, err := parseJSONObject[bool](input); ok {
+		return results, err
+	}
+
+	return parseMapValues(input, func(key string, value string) (bool, error) {
+		boolValue, err := parseBool(value)
+		if err != nil {
+			return false, NewParseEnvFailedError("invalid boolean map syntax", key)
+		}
+
+		return boolValue, nil
+	})
+}
+
+// ParseDurationMapFromString parses a duration map from a string with format:
+//
+//	<key1>=<value1>;<key2>=<value2>
+//
+// Values use the [time.ParseDuration] format, e.g. read=5s;write=1m30s.
+// The input is parsed as a JSON object of duration strings if it starts with '{'.
+func ParseDurationMapFromString(input string) (map[string]time.Duration, error) {
+	return parseMapItems(input, "invalid duration map syntax", time.ParseDuration)
+}
+
+// ParseMapAs parses a map from a string with format <key1>=<value1>;<key2>=<value2>,
+// or a JSON object of strings if the input starts with '{'. Values are trimmed and parsed by the parse function.
+// The error reports the key of the invalid value.
+func ParseMapAs[T any](input string, parse func(string) (T, error)) (map[string]T, error) {
+	return parseMapItems(input, "invalid map syntax", parse)
+}
+
+func parseMapItems[T any](input string, errorMessage string, parse func(string) (T, error)) (map[string]T, error) {
+	parseItem := func(key string, value string) (T, error) {
+		item, err := parse(trimSpace(value))
+		if err != nil {
+			return item, NewParseEnvFailedError(errorMessage+": "+err.Error(), key)
+		}
+
+		return item, nil
+	}
+
+	rawValues, ok, err := parseJSONObject[string](input)
+	if !ok {
+		return parseMapValues(input, parseItem)
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	result := make(map[string]bool)
+	result := make(map[string]T, len(rawValues))
 
 	for key, value := range rawValues {
-		boolValue, err := strconv.ParseBool(value)
+		result[key], err = parseItem(key, value)
 		if err != nil {
-			return nil, NewParseEnvFailedError("invalid boolean map syntax", key)
+			return nil, err
 		}
-
-		result[key] = boolValue
 	}
 
 	return result, nil
 }
 
 // ParseStringSliceFromString parses a string slice from a comma-separated string.
+// It does not detect JSON arrays because it cannot report invalid ones, see [ParseSliceAs] for the JSON array format.
 func ParseStringSliceFromString(input string) []string {
+	results := make([]string, 0, countSliceItems(input))
+
+	for _, item := range sliceItems(input) {
+		results = append(results, item)
+	}
+
+	return results
+}
+
+// parseStringSliceWithErrorPrefix parses a string slice from a comma-separated string, or a JSON array.
+// See [ParseIntSliceFromString] for the JSON array detection.
+func parseStringSliceWithErrorPrefix(input string, errorPrefix string) ([]string, error) {
+	items, ok, err := jsonArrayItems(input, errorPrefix)
+	if ok {
+		return items, err
+	}
+
+	return ParseStringSliceFromString(input), nil
+}
+
+// countSliceItems returns the number of items of the comma-separated input.
+func countSliceItems(input string) int {
 	if input == "" {
-		return []string{}
+		return 0
 	}
 
-	return strings.Split(input, ",")
+	return strings.Count(input, ",") + 1
 }
 
-// ParseIntSliceFromString parses an integer slice from a comma-separated string.
+// sliceItems iterates the raw items of the comma-separated input with their indexes
+// without allocating an intermediate slice. An empty input has no items.
+func sliceItems(input string) iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		if input == "" {
+			return
+		}
+
+		for index := 0; ; index++ {
+			end := strings.IndexByte(input, ',')
+			if end < 0 {
+				yield(index, input)
+
+				return
+			}
+
+			if !yield(index, input[:end]) {
+				return
+			}
+
+			input = input[end+1:]
+		}
+	}
+}
+
+// sliceInputItems returns the raw items of the input with the number of items.
+// The input is parsed as a JSON array if it starts with '[', see [jsonArrayItems], or as a comma-separated string otherwise.
+func sliceInputItems(input string, errorPrefix string) (iter.Seq2[int, string], int, error) {
+	items, ok, err := jsonArrayItems(input, errorPrefix)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if ok {
+		return slices.All(items), len(items), nil
+	}
+
+	return sliceItems(input), countSliceItems(input), nil
+}
+
+// skipEmptySliceItems removes empty and whitespace-only items of the comma-separated input,
+// e.g. "a,b,,c," becomes "a,b,c". JSON arrays are returned as-is.
+func skipEmptySliceItems(input string) string {
+	if isJSONArrayInput(input) {
+		return input
+	}
+
+	var builder strings.Builder
+
+	builder.Grow(len(input))
+
+	for _, item := range sliceItems(input) {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+
+		if builder.Len() > 0 {
+			builder.WriteByte(',')
+		}
+
+		builder.WriteString(item)
+	}
+
+	return builder.String()
+}
+
+// jsonItemsHint returns the capacity hint of a JSON array or object of numbers or booleans by counting commas,
+// so the decoder does not regrow large collections. Strings may contain commas, so other types have no hint.
+func jsonItemsHint[T any](input string) int {
+	var zero T
+
+	switch any(zero).(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, bool:
+		return strings.Count(input, ",") + 1
+	default:
+		return 0
+	}
+}
+
+// isJSONArrayInput checks if the slice input is in the JSON array format, i.e. it starts with '['.
+func isJSONArrayInput(input string) bool {
+	return strings.HasPrefix(strings.TrimSpace(input), "[")
+}
+
+// jsonArrayItems returns the items of the input if it starts with '[', which must be a JSON array
+// of strings, numbers or booleans. It allows items with embedded commas or explicit empty strings, e.g. ["a,b", ""].
+// Strings are unquoted, and numbers and booleans keep their literal text, so items are parsed by the same
+// item parsers and options as comma-separated items. Returns false if the input is not in the JSON array format,
+// or an invalid JSON array syntax error if it cannot be decoded, e.g. ["a","b" or [a],b.
+func jsonArrayItems(input string, errorPrefix string) ([]string, bool, error) {
+	if !isJSONArrayInput(input) {
+		return nil, false, nil
+	}
+
+	var rawItems []json.RawMessage
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(input)), &rawItems); err != nil {
+		return nil, true, NewParseEnvFailedError(errorPrefix+"invalid JSON array syntax", err.Error())
+	}
+
+	results := make([]string, len(rawItems))
+
+	for i, rawItem := range rawItems {
+		switch rawItem[0] {
+		case '"':
+			if err := json.Unmarshal(rawItem, &results[i]); err != nil {
+				return nil, true, NewParseEnvFailedError(errorPrefix+"invalid JSON array syntax", err.Error())
+			}
+		case '[', '{', 'n':
+			return nil, true, NewParseEnvFailedError(
+				errorPrefix+"invalid JSON array syntax",
+				"item "+strconv.Itoa(i)+" is not a string, number or boolean",
+			)
+		default:
+			results[i] = string(rawItem)
+		}
+	}
+
+	return results, true, nil
+}
+
+// ParseIntSliceFromString parses an integer slice from a comma-separated string, or a JSON array.
+// The input is parsed as a JSON array of strings, numbers or booleans if it starts with '[', e.g. [1, 2] or ["a,b", ""],
+// and returns an invalid JSON array syntax error if it is not one, e.g. [a],b. Otherwise, it is split on commas.
+// Items of JSON arrays are parsed in the same way as comma-separated items, so element options such as
+// [EnvIntSlice.WithAlternateBases] and [EnvBoolSlice.WithLenientBool] apply to them as well.
 func ParseIntSliceFromString[T int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64](
 	input string,
 ) ([]T, error) {
-	return parseIntSliceFromStringWithErrorPrefix[T](input, "")
+	return parseIntSliceFromStringWithErrorPrefix(input, "", parseInt[T])
 }
 
 func parseIntSliceFromStringWithErrorPrefix[T int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64](
 	input string,
 	errorPrefix string,
+	parse func(string) (T, error),
 ) ([]T, error) {
-	rawValues := ParseStringSliceFromString(input)
-	results := make([]T, len(rawValues))
+	items, size, err := sliceInputItems(input, errorPrefix)
+	if err != nil {
+		return nil, err
+	}
 
-	for index, val := range rawValues {
-		intVal, err := parseInt[T](val)
+	results := make([]T, size)
+
+	for index, val := range items {
+		intVal, err := parse(trimSpace(val))
 		if err != nil {
 			return nil, NewParseEnvFailedError(
 				errorPrefix+"invalid integer slice syntax",
@@ -148,7 +527,8 @@ func parseIntSliceFromStringWithErrorPrefix[T int | int8 | int16 | int32 | int64
 	return results, nil
 }
 
-// ParseFloatSliceFromString parses a floating-point number slice from a comma-separated string.
+// ParseFloatSliceFromString parses a floating-point number slice from a comma-separated string, or a JSON array.
+// See [ParseIntSliceFromString] for the JSON array detection.
 func ParseFloatSliceFromString[T float32 | float64](input string) ([]T, error) {
 	return parseFloatSliceFromStringWithErrorPrefix[T](input, "")
 }
@@ -157,10 +537,14 @@ func parseFloatSliceFromStringWithErrorPrefix[T float32 | float64](
 	input string,
 	errorPrefix string,
 ) ([]T, error) {
-	rawValues := ParseStringSliceFromString(input)
-	results := make([]T, len(rawValues))
+	items, size, err := sliceInputItems(input, errorPrefix)
+	if err != nil {
+		return nil, err
+	}
 
-	for index, val := range rawValues {
+	results := make([]T, size)
+
+	for index, val := range items {
 		floatVal, err := parseFloat[T](val)
 		if err != nil {
 			return nil, NewParseEnvFailedError(
@@ -175,17 +559,26 @@ func parseFloatSliceFromStringWithErrorPrefix[T float32 | float64](
 	return results, nil
 }
 
-// ParseBoolSliceFromString parses a boolean slice from a comma-separated string.
+// ParseBoolSliceFromString parses a boolean slice from a comma-separated string, or a JSON array.
+// See [ParseIntSliceFromString] for the JSON array detection.
 func ParseBoolSliceFromString(input string) ([]bool, error) {
-	return parseBoolSliceFromStringWithErrorPrefix(input, "")
+	return parseBoolSliceFromStringWithErrorPrefix(input, "", strconv.ParseBool)
 }
 
-func parseBoolSliceFromStringWithErrorPrefix(input string, errorPrefix string) ([]bool, error) {
-	rawValues := ParseStringSliceFromString(input)
-	results := make([]bool, len(rawValues))
+func parseBoolSliceFromStringWithErrorPrefix(
+	input string,
+	errorPrefix string,
+	parseBool func(string) (bool, error),
+) ([]bool, error) {
+	items, size, err := sliceInputItems(input, errorPrefix)
+	if err != nil {
+		return nil, err
+	}
 
-	for index, val := range rawValues {
-		boolVal, err := strconv.ParseBool(strings.TrimSpace(val))
+	results := make([]bool, size)
+
+	for index, val := range items {
+		boolVal, err := parseBool(trimSpace(val))
 		if err != nil {
 			return nil, NewParseEnvFailedError(
 				errorPrefix+"invalid boolean slice syntax",
@@ -199,6 +592,49 @@ func parseBoolSliceFromStringWithErrorPrefix(input string, errorPrefix string) (
 	return results, nil
 }
 
+// ParseDurationSliceFromString parses a duration slice from a comma-separated string, e.g. 1s,5s,30s,
+// or a JSON array of duration strings. Items use the [time.ParseDuration] format.
+// See [ParseIntSliceFromString] for the JSON array detection.
+func ParseDurationSliceFromString(input string) ([]time.Duration, error) {
+	return parseSliceItems(input, "invalid duration slice syntax", time.ParseDuration)
+}
+
+// ParseSliceAs parses a slice from a comma-separated string, or a JSON array.
+// See [ParseIntSliceFromString] for the JSON array detection.
+// Items are trimmed and parsed by the parse function. The error reports the index of the invalid item.
+func ParseSliceAs[T any](input string, parse func(string) (T, error)) ([]T, error) {
+	return parseSliceItems(input, "invalid slice syntax", parse)
+}
+
+func parseSliceItems[T any](input string, errorMessage string, parse func(string) (T, error)) ([]T, error) {
+	items, size, err := sliceInputItems(input, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSliceValues(items, size, errorMessage, parse)
+}
+
+func parseSliceValues[T any](
+	rawValues iter.Seq2[int, string],
+	size int,
+	errorMessage string,
+	parse func(string) (T, error),
+) ([]T, error) {
+	results := make([]T, size)
+
+	for index, val := range rawValues {
+		item, err := parse(trimSpace(val))
+		if err != nil {
+			return nil, NewParseEnvFailedError(errorMessage+": "+err.Error(), strconv.Itoa(index))
+		}
+
+		results[index] = item
+	}
+
+	return results, nil
+}
+
 // OSEnvGetter wraps the GetOSEnv function with context.
 func OSEnvGetter(_ context.Context) GetEnvFunc {
 	return GetOSEnv
@@ -227,7 +663,7 @@ func parseInt[T int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uin
 ) (T, error) {
 	var zero T
 
-	trimmed := strings.TrimSpace(val)
+	trimmed := trimSpace(val)
 
 	switch any(zero).(type) {
 	case int, int8, int16, int32:
@@ -285,7 +721,7 @@ func parseInt[T int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uin
 func parseFloat[T float32 | float64](val string) (T, error) { //nolint:ireturn
 	var zero T
 
-	trimmed := strings.TrimSpace(val)
+	trimmed := trimSpace(val)
 
 	switch any(zero).(type) {
 	case float32:
@@ -304,3 +740,62 @@ func parseFloat[T float32 | float64](val string) (T, error) { //nolint:ireturn
 		return T(result), err
 	}
 }
+
+// envReference represents a parsed variable reference with an optional default literal value.
+type envReference struct {
+	Name    string
+	Default *string
+}
+
+// parseEnvReference parses a variable reference with format ${VAR} or ${VAR:-default}.
+// The bare $VAR form is only accepted in JSON, see [decodeJSONShorthand].
+func parseEnvReference(input string) (envReference, bool) {
+	braced, ok := strings.CutPrefix(input, "${")
+	if !ok {
+		return envReference{}, false
+	}
+
+	name, ok := strings.CutSuffix(braced, "}")
+	if !ok {
+		return envReference{}, false
+	}
+
+	var result envReference
+
+	if variable, defaultValue, hasDefault := strings.Cut(name, ":-"); hasDefault {
+		name = variable
+		result.Default = &defaultValue
+	}
+
+	if !isValidVariableName(name) {
+		return result, false
+	}
+
+	result.Name = name
+
+	return result, true
+}
+
+// isBareEnvReference checks if the input is a variable reference with format $VAR.
+func isBareEnvReference(input string) bool {
+	name, ok := strings.CutPrefix(input, "$")
+
+	return ok && isValidVariableName(name)
+}
+
+// isValidVariableName checks if the input is a valid environment variable name.
+func isValidVariableName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, c := range name {
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
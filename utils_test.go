@@ -1,7 +1,10 @@
 package goenvconf
 
 import (
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseIntMapFromString(t *testing.T) {
@@ -43,3 +46,388 @@ func TestParseIntMapFromString(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStringMapFromString(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected map[string]string
+		ErrorMsg string
+	}{
+		{Input: "a=1;b=", Expected: map[string]string{"a": "1", "b": ""}},
+		{Input: `dsn="host=a;port=5432";b=2`, Expected: map[string]string{"dsn": "host=a;port=5432", "b": "2"}},
+		{Input: `token=abc\=\=;x=a\;b`, Expected: map[string]string{"token": "abc==", "x": "a;b"}},
+		{Input: `"a=b"="say \"hi\" \\ bye"`, Expected: map[string]string{"a=b": `say "hi" \ bye`}},
+		{Input: `path=C:\dir;q=a"b"c`, Expected: map[string]string{"path": `C:\dir`, "q": `a"b"c`}},
+		{Input: `a=""`, Expected: map[string]string{"a": ""}},
+		{Input: `a="b;c`, ErrorMsg: `ParseEnvFailed: unterminated quoted string in map syntax. Hint: "b;c`},
+		{Input: `a="b"c=d`, Expected: map[string]string{"a": `"b"c=d`}},
+		{Input: "token=abc==;key=a=b", Expected: map[string]string{"token": "abc==", "key": "a=b"}},
+		{Input: "=b", ErrorMsg: "invalid string map syntax"},
+		{Input: `""=b`, ErrorMsg: "invalid string map syntax"},
+		{Input: "a=1;", ErrorMsg: "invalid string map syntax"},
+		{Input: "a=1;b", ErrorMsg: "invalid string map syntax, expected: <key1>=<value1>;<key2>=<value2>. Hint: b"},
+		{Input: `a=1;b="x;y";c=3`, Expected: map[string]string{"a": "1", "b": "x;y", "c": "3"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			result, err := ParseStringMapFromString(tc.Input)
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestParseMapFromJSONObject(t *testing.T) {
+	strs, err := ParseStringMapFromString(` {"dsn": "host=a;port=5432", "b": ""}`)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]string{"dsn": "host=a;port=5432", "b": ""}, strs)
+
+	ints, err := ParseIntegerMapFromString[uint16](`{"a": 1}`)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]uint16{"a": 1}, ints)
+
+	floats, err := ParseFloatMapFromString[float32](`{}`)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]float32{}, floats)
+
+	bools, err := ParseBoolMapFromString(`{"x": true}`)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]bool{"x": true}, bools)
+
+	_, err = ParseStringMapFromString(`{"a": {"b": 1}}`)
+	assertErrorContains(t, err, "ParseEnvFailed: invalid JSON object syntax")
+
+	_, err = ParseIntegerMapFromString[int8](`{"a": 1000}`)
+	assertErrorContains(t, err, "invalid JSON object syntax")
+}
+
+func TestQuoteMapToken(t *testing.T) {
+	for _, input := range []string{"", "abc", "a=b", "a;b", `say "hi"`, `C:\dir`, `"`, `\`, " {a}"} {
+		t.Run(input, func(t *testing.T) {
+			result, err := ParseStringMapFromString(quoteMapToken(input) + "=" + quoteMapToken(input))
+			if input == "" {
+				assertErrorContains(t, err, "invalid string map syntax")
+
+				return
+			}
+
+			assertNilError(t, err)
+			assertDeepEqual(t, map[string]string{input: input}, result)
+		})
+	}
+}
+
+func TestParseSliceFromJSONArray(t *testing.T) {
+	ints, err := ParseIntSliceFromString[int32]("[1, -2]")
+	assertNilError(t, err)
+	assertDeepEqual(t, []int32{1, -2}, ints)
+
+	floats, err := ParseFloatSliceFromString[float64](" [1.5] ")
+	assertNilError(t, err)
+	assertDeepEqual(t, []float64{1.5}, floats)
+
+	bools, err := ParseBoolSliceFromString("[]")
+	assertNilError(t, err)
+	assertDeepEqual(t, []bool{}, bools)
+
+	bools, err = ParseBoolSliceFromString(`["true", false]`)
+	assertNilError(t, err)
+	assertDeepEqual(t, []bool{true, false}, bools)
+
+	strs, err := parseStringSliceWithErrorPrefix(` ["a,b", "", 1]`, "")
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"a,b", "", "1"}, strs)
+
+	// The exported string slice parser only splits on commas.
+	assertDeepEqual(t, []string{`["a`, `b"]`}, ParseStringSliceFromString(`["a,b"]`))
+
+	for _, input := range []string{"[a],b", `["a","b"`, "[a,b", `[["a"]]`, "[null]"} {
+		_, err = parseStringSliceWithErrorPrefix(input, "")
+		assertErrorContains(t, err, "ParseEnvFailed: invalid JSON array syntax")
+
+		_, err = ParseIntSliceFromString[int](input)
+		assertErrorContains(t, err, "ParseEnvFailed: invalid JSON array syntax")
+	}
+
+	_, err = ParseBoolSliceFromString(" [true")
+	assertErrorContains(t, err, "ParseEnvFailed: invalid JSON array syntax")
+
+	_, err = parseIntSliceFromStringWithErrorPrefix("[1, 256]", "failed to parse FOO: ", parseInt[uint8])
+	assertErrorContains(t, err, "ParseEnvFailed: failed to parse FOO: invalid integer slice syntax")
+}
+
+func TestParseEnvReference(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected envReference
+		OK       bool
+	}{
+		{Input: "${FOO}", Expected: envReference{Name: "FOO"}, OK: true},
+		{Input: "${foo_BAR1}", Expected: envReference{Name: "foo_BAR1"}, OK: true},
+		{Input: "${FOO:-bar}", Expected: envReference{Name: "FOO", Default: toPtr("bar")}, OK: true},
+		{Input: "${FOO:-}", Expected: envReference{Name: "FOO", Default: toPtr("")}, OK: true},
+		{Input: "${FOO:-a}b}", Expected: envReference{Name: "FOO", Default: toPtr("a}b")}, OK: true},
+		{Input: "$FOO"},
+		{Input: "$FOO:-bar"},
+		{Input: "$"},
+		{Input: "$$FOO"},
+		{Input: "${}"},
+		{Input: "${1FOO}"},
+		{Input: "${FOO"},
+		{Input: "${FOO}bar"},
+		{Input: "prefix ${FOO}"},
+		{Input: "${FOO:?bar}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			result, ok := parseEnvReference(tc.Input)
+			assertDeepEqual(t, tc.OK, ok)
+
+			if ok {
+				assertDeepEqual(t, tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestParseDurationSliceFromString(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected []time.Duration
+		ErrorMsg string
+	}{
+		{Expected: []time.Duration{}},
+		{Input: "1s, 5s,1m30s", Expected: []time.Duration{time.Second, 5 * time.Second, 90 * time.Second}},
+		{Input: `["100ms", "2h"]`, Expected: []time.Duration{100 * time.Millisecond, 2 * time.Hour}},
+		{Input: "1s,foo", ErrorMsg: `ParseEnvFailed: invalid duration slice syntax: time: invalid duration "foo". Hint: 1`},
+		{Input: "[1]", ErrorMsg: `ParseEnvFailed: invalid duration slice syntax: time: missing unit in duration "1". Hint: 0`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			result, err := ParseDurationSliceFromString(tc.Input)
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestParseDurationMapFromString(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected map[string]time.Duration
+		ErrorMsg string
+	}{
+		{Expected: map[string]time.Duration{}},
+		{Input: "read=5s; write = 1m", Expected: map[string]time.Duration{"read": 5 * time.Second, " write ": time.Minute}},
+		{Input: `{"read": "10ms"}`, Expected: map[string]time.Duration{"read": 10 * time.Millisecond}},
+		{Input: "read=5", ErrorMsg: `ParseEnvFailed: invalid duration map syntax: time: missing unit in duration "5". Hint: read`},
+		{Input: "read", ErrorMsg: "invalid string map syntax"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			result, err := ParseDurationMapFromString(tc.Input)
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+				assertDeepEqual(t, tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestParseSliceAs(t *testing.T) {
+	result, err := ParseSliceAs(" a , b", func(s string) (rune, error) {
+		return rune(s[0]), nil
+	})
+	assertNilError(t, err)
+	assertDeepEqual(t, []rune{'a', 'b'}, result)
+
+	ints, err := ParseSliceAs(`["1", " 2"]`, ParseIntWithSizeSuffix)
+	assertNilError(t, err)
+	assertDeepEqual(t, []int64{1, 2}, ints)
+
+	_, err = ParseSliceAs("1,2k,x", ParseIntWithSizeSuffix)
+	assertErrorContains(t, err, `ParseEnvFailed: invalid slice syntax: strconv.ParseInt: parsing "x": invalid syntax. Hint: 2`)
+
+	_, err = ParseSliceAs("[1", ParseIntWithSizeSuffix)
+	assertErrorContains(t, err, `ParseEnvFailed: invalid JSON array syntax. Hint: unexpected end of JSON input`)
+}
+
+func TestParseMapAs(t *testing.T) {
+	result, err := ParseMapAs("a=1k;b= 2 ", ParseIntWithSizeSuffix)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"a": 1000, "b": 2}, result)
+
+	result, err = ParseMapAs(`{"a": "1Ki"}`, ParseIntWithSizeSuffix)
+	assertNilError(t, err)
+	assertDeepEqual(t, map[string]int64{"a": 1024}, result)
+
+	_, err = ParseMapAs("a=x", ParseIntWithSizeSuffix)
+	assertErrorContains(t, err, `ParseEnvFailed: invalid map syntax: strconv.ParseInt: parsing "x": invalid syntax. Hint: a`)
+
+	_, err = ParseMapAs("a", ParseIntWithSizeSuffix)
+	assertErrorContains(t, err, "invalid string map syntax")
+}
+
+func TestSliceItems(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected []string
+	}{
+		{Input: "", Expected: []string{}},
+		{Input: "a", Expected: []string{"a"}},
+		{Input: "a, b,,c,", Expected: []string{"a", " b", "", "c", ""}},
+		{Input: ",", Expected: []string{"", ""}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			items := []string{}
+
+			for index, item := range sliceItems(tc.Input) {
+				assertDeepEqual(t, len(items), index)
+
+				items = append(items, item)
+			}
+
+			assertDeepEqual(t, tc.Expected, items)
+			assertDeepEqual(t, len(tc.Expected), countSliceItems(tc.Input))
+			assertDeepEqual(t, tc.Expected, ParseStringSliceFromString(tc.Input))
+		})
+	}
+
+	for index := range sliceItems("a,b,c") {
+		if index > 0 {
+			t.Fatal("expected the iteration to stop")
+		}
+
+		break
+	}
+
+	assertDeepEqual(t, "a, b,c", skipEmptySliceItems("a, b,, ,c,"))
+	assertDeepEqual(t, "", skipEmptySliceItems(" ,,"))
+}
+
+func newBenchmarkIDList(size int) string {
+	ids := make([]string, size)
+
+	for i := range ids {
+		ids[i] = strconv.Itoa(100000 + i)
+	}
+
+	return strings.Join(ids, ",")
+}
+
+func BenchmarkParseIntSliceFromString(b *testing.B) {
+	input := newBenchmarkIDList(10000)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := ParseIntSliceFromString[int64](input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkParseIntSliceFromStringSplit is the strings.Split baseline of BenchmarkParseIntSliceFromString.
+func BenchmarkParseIntSliceFromStringSplit(b *testing.B) {
+	input := newBenchmarkIDList(10000)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		rawValues := strings.Split(input, ",")
+		results := make([]int64, len(rawValues))
+
+		for index, val := range rawValues {
+			intVal, err := strconv.ParseInt(strings.TrimSpace(val), 10, 64)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			results[index] = intVal
+		}
+	}
+}
+
+func BenchmarkParseStringSliceFromString(b *testing.B) {
+	input := newBenchmarkIDList(10000)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_ = ParseStringSliceFromString(input)
+	}
+}
+
+func newBenchmarkMapInput(size int) string {
+	items := make([]string, size)
+
+	for i := range items {
+		items[i] = "key" + strconv.Itoa(i) + "=value" + strconv.Itoa(i)
+	}
+
+	return strings.Join(items, ";")
+}
+
+func BenchmarkParseStringMapFromString(b *testing.B) {
+	input := newBenchmarkMapInput(1000)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := ParseStringMapFromString(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkParseStringMapFromStringSplit is the strings.Split baseline of BenchmarkParseStringMapFromString.
+func BenchmarkParseStringMapFromStringSplit(b *testing.B) {
+	input := newBenchmarkMapInput(1000)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		result := make(map[string]string)
+
+		for _, rawItem := range strings.Split(input, ";") {
+			keyValue := strings.SplitN(rawItem, "=", 2)
+			if len(keyValue) != 2 {
+				b.Fatal("invalid map item")
+			}
+
+			result[keyValue[0]] = keyValue[1]
+		}
+	}
+}
+
+func TestTrimSpace(t *testing.T) {
+	for _, input := range []string{"", " ", "a", " a ", "\ta\n", "a b", " a ", "é", " é "} {
+		assertDeepEqual(t, strings.TrimSpace(input), trimSpace(input))
+	}
+
+	assertDeepEqual(t, false, hasQuoteOrEscape("key=value"))
+	assertDeepEqual(t, true, hasQuoteOrEscape(`"key"`))
+	assertDeepEqual(t, true, hasQuoteOrEscape(`a\;b`))
+}
+
+func TestJSONItemsHint(t *testing.T) {
+	assertDeepEqual(t, 3, jsonItemsHint[int64]("[1, 2, 3]"))
+	assertDeepEqual(t, 2, jsonItemsHint[bool](`{"a": true, "b": false}`))
+	assertDeepEqual(t, 0, jsonItemsHint[string](`["a,b", "c"]`))
+}
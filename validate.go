@@ -0,0 +1,154 @@
+package goenvconf
+
+import (
+	"errors"
+	"reflect"
+)
+
+// The Validate methods check the structural correctness of the instance without touching the environment:
+//   - Either the value or the variable name must be set.
+//   - The variable name, candidate variables, deprecated aliases and default variables must be valid environment variable names.
+//   - Options must not conflict, e.g. the minimum value of a range is greater than the maximum value.
+//
+// Constraints and validators of the resolved value are not evaluated.
+
+// validateEnv checks the structural correctness of an Env instance.
+func validateEnv(variable *string, hasValue bool, options *envOptions) error {
+	hasVariable := variable != nil && *variable != ""
+	if !hasVariable && !hasValue {
+		return ErrEnvironmentValueRequired
+	}
+
+	var errs []error
+
+	if hasVariable && !isValidVariableName(*variable) {
+		errs = append(errs, NewParseEnvFailedError("invalid environment variable name", *variable))
+	}
+
+	if options != nil {
+		for _, candidate := range options.candidates {
+			if !isValidVariableName(candidate) {
+				errs = append(errs, NewParseEnvFailedError("invalid candidate variable name", candidate))
+			}
+		}
+
+		if len(options.candidates) > 0 && !hasVariable {
+			errs = append(errs, NewParseEnvFailedError("candidate variables require a variable name", ""))
+		}
+
+		for _, alias := range options.aliases {
+			if !isValidVariableName(alias) {
+				errs = append(errs, NewParseEnvFailedError("invalid deprecated alias name", alias))
+			}
+		}
+
+		if len(options.aliases) > 0 && !hasVariable {
+			errs = append(errs, NewParseEnvFailedError("deprecated aliases require a variable name", ""))
+		}
+
+		for _, name := range options.defaultVariables {
+			if !isValidVariableName(name) {
+				errs = append(errs, NewParseEnvFailedError("invalid default variable name", name))
+			}
+		}
+
+		if len(options.defaultVariables) > 0 && !hasVariable {
+			errs = append(errs, NewParseEnvFailedError("default variables require a variable name", ""))
+		}
+
+		errs = append(errs, options.errs...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateStruct checks the structural correctness of all Env fields in the config struct
+// without touching the environment, and returns all errors together as [ConfigErrors].
+// Zero Env fields are reported, while nil pointer fields are skipped, so optional fields should be pointers.
+// It is designed for config-file linting pipelines.
+func ValidateStruct(config any) error {
+	value := reflect.Indirect(reflect.ValueOf(config))
+	if value.Kind() != reflect.Struct {
+		return ErrInvalidBindTarget
+	}
+
+	var errs ConfigErrors
+
+	walkEnvFields(value, "", func(path string, field reflect.Value) {
+		validator, ok := field.Interface().(interface{ Validate() error })
+		if !ok {
+			return
+		}
+
+		if err := validator.Validate(); err != nil {
+			errs = append(errs, ConfigError{Path: path, Variable: envVariableName(field), Err: err})
+		}
+	})
+
+	return errs.toError()
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvString) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvInt) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvBool) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvFloat) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvAny) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvStringSlice) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvIntSlice) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvFloatSlice) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvBoolSlice) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvMapString) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvMapInt) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvMapFloat) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
+
+// Validate checks the structural correctness of the instance without touching the environment.
+func (ev EnvMapBool) Validate() error {
+	return validateEnv(ev.Variable, ev.Value != nil, ev.options)
+}
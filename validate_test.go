@@ -0,0 +1,112 @@
+package goenvconf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    interface{ Validate() error }
+		ErrorMsg string
+	}{
+		{Name: "value", Input: NewEnvStringValue("foo")},
+		{Name: "variable", Input: NewEnvIntVariable("PORT")},
+		{Name: "empty_variable_with_value", Input: EnvBool{Variable: toPtr(""), Value: toPtr(true)}},
+		{Name: "alias", Input: NewEnvFloatVariable("RATIO").WithDeprecatedAlias("OLD_RATIO")},
+		{Name: "slice", Input: NewEnvStringSliceValue([]string{"a"})},
+		{Name: "map", Input: NewEnvMapBoolVariable("FLAGS")},
+		{Name: "any", Input: NewEnvAnyValue(nil), ErrorMsg: ErrEnvironmentValueRequired.Error()},
+		{Name: "empty", Input: EnvString{}, ErrorMsg: ErrEnvironmentValueRequired.Error()},
+		{Name: "empty_variable", Input: EnvIntSlice{Variable: toPtr("")}, ErrorMsg: ErrEnvironmentValueRequired.Error()},
+		{
+			Name:     "invalid_variable",
+			Input:    NewEnvMapIntVariable("1FOO"),
+			ErrorMsg: "ParseEnvFailed: invalid environment variable name. Hint: 1FOO",
+		},
+		{
+			Name:     "invalid_alias",
+			Input:    NewEnvBoolSliceVariable("FOO").WithDeprecatedAlias("OLD-FOO"),
+			ErrorMsg: "ParseEnvFailed: invalid deprecated alias name. Hint: OLD-FOO",
+		},
+		{
+			Name:     "alias_without_variable",
+			Input:    NewEnvFloatSliceValue([]float64{1}).WithDeprecatedAlias("OLD_FOO"),
+			ErrorMsg: "ParseEnvFailed: deprecated aliases require a variable name",
+		},
+		{
+			Name:     "invalid_range",
+			Input:    NewEnvIntInRange("PORT", 80, 100, 1),
+			ErrorMsg: "ParseEnvFailed: the minimum value must be less than or equal to the maximum value. Hint: 100 > 1",
+		},
+		{
+			Name:     "invalid_clamp",
+			Input:    NewEnvIntValue(1).WithClamp(10, 0),
+			ErrorMsg: "Hint: 10 > 0",
+		},
+		{
+			Name:     "empty_one_of",
+			Input:    NewEnvStringVariable("MODE").WithOneOf(),
+			ErrorMsg: "ParseEnvFailed: WithOneOf requires at least one value",
+		},
+		{
+			Name:     "multiple",
+			Input:    NewEnvMapStringVariable("1FOO").WithDeprecatedAlias("2FOO"),
+			ErrorMsg: "invalid environment variable name. Hint: 1FOO\nParseEnvFailed: invalid deprecated alias name. Hint: 2FOO",
+		},
+		{Name: "map_float", Input: NewEnvMapFloatValue(map[string]float64{})},
+		{Name: "float_one_of", Input: NewEnvFloatValue(1).WithOneOf(), ErrorMsg: "WithOneOf requires at least one value"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Input.Validate()
+			if tc.ErrorMsg != "" {
+				assertErrorContains(t, err, tc.ErrorMsg)
+			} else {
+				assertNilError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateStruct(t *testing.T) {
+	t.Setenv("SERVER_PORT", "abc")
+
+	config := binderConfig{
+		Server: binderServerConfig{
+			Host: NewEnvStringVariable("SERVER-HOST"),
+			Port: NewEnvIntVariable("SERVER_PORT"),
+		},
+		Limits: NewEnvMapIntValue(map[string]int64{"a": 1 << 40}),
+	}
+
+	err := ValidateStruct(&config)
+	assertDeepEqual(t, `6 config errors:
+  - Server.Host (SERVER-HOST): ParseEnvFailed: invalid environment variable name. Hint: SERVER-HOST
+  - Server.Origins: EmptyEnv: require either value or env
+  - Ratio: EmptyEnv: require either value or env
+  - Extra: EmptyEnv: require either value or env
+  - Optional: EmptyEnv: require either value or env
+  - Ignored: EmptyEnv: require either value or env`, err.Error())
+
+	var configErrs ConfigErrors
+	assertDeepEqual(t, true, errors.As(err, &configErrs))
+	assertDeepEqual(t, "Server.Host", configErrs[0].Path)
+
+	config = binderConfig{
+		Server: binderServerConfig{
+			Host:    NewEnvStringValue("localhost"),
+			Port:    NewEnvIntVariable("SERVER_PORT"),
+			Origins: NewEnvStringSliceVariable("ORIGINS"),
+		},
+		Ratio:    NewEnvFloatValue(1),
+		Limits:   NewEnvMapIntVariable("LIMITS"),
+		Extra:    NewEnvAnyVariable("EXTRA"),
+		Optional: NewEnvStringVariable("OPTIONAL"),
+		Ignored:  NewEnvStringVariable("IGNORED"),
+	}
+	assertNilError(t, ValidateStruct(config))
+	assertDeepEqual(t, ErrInvalidBindTarget, ValidateStruct("foo"))
+}
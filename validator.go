@@ -0,0 +1,135 @@
+package goenvconf
+
+// Validator validates the resolved value of an Env instance.
+type Validator[T any] interface {
+	Validate(value T) error
+}
+
+// ValidatorFunc is a function adapter of the [Validator] interface.
+type ValidatorFunc[T any] func(value T) error
+
+// Validate calls the validator function.
+func (fn ValidatorFunc[T]) Validate(value T) error {
+	return fn(value)
+}
+
+// The WithValidator methods return a copy of the instance with validators attached.
+// Validators run in order on the resolved value after parsing and constraints added before.
+// The first error is returned by Get and GetCustom, prefixed with the variable name if set.
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvString) WithValidator(validators ...Validator[string]) EnvString {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvInt) WithValidator(validators ...Validator[int64]) EnvInt {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvBool) WithValidator(validators ...Validator[bool]) EnvBool {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvFloat) WithValidator(validators ...Validator[float64]) EnvFloat {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvAny) WithValidator(validators ...Validator[any]) EnvAny {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvStringSlice) WithValidator(validators ...Validator[[]string]) EnvStringSlice {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvIntSlice) WithValidator(validators ...Validator[[]int64]) EnvIntSlice {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvFloatSlice) WithValidator(validators ...Validator[[]float64]) EnvFloatSlice {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvBoolSlice) WithValidator(validators ...Validator[[]bool]) EnvBoolSlice {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvMapString) WithValidator(validators ...Validator[map[string]string]) EnvMapString {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvMapInt) WithValidator(validators ...Validator[map[string]int64]) EnvMapInt {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvMapFloat) WithValidator(validators ...Validator[map[string]float64]) EnvMapFloat {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
+
+// WithValidator returns a copy of the instance with validators attached.
+func (ev EnvMapBool) WithValidator(validators ...Validator[map[string]bool]) EnvMapBool {
+	for _, validator := range validators {
+		ev.options = withValidator(ev.options, validator.Validate)
+	}
+
+	return ev
+}
@@ -0,0 +1,89 @@
+package goenvconf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type bucketNameValidator struct{}
+
+func (bucketNameValidator) Validate(value string) error {
+	if strings.ToLower(value) != value {
+		return NewValidationFailedError("invalid S3 bucket name", value)
+	}
+
+	return nil
+}
+
+func TestWithValidator(t *testing.T) {
+	t.Setenv("TEST_VALIDATOR_BUCKET", "My-Bucket")
+
+	errEmpty := errors.New("empty")
+	notEmpty := ValidatorFunc[[]string](func(value []string) error {
+		if len(value) == 0 {
+			return errEmpty
+		}
+
+		return nil
+	})
+
+	t.Run("struct_validator", func(t *testing.T) {
+		ev := NewEnvString("TEST_VALIDATOR_BUCKET", "bucket").WithValidator(bucketNameValidator{})
+
+		_, err := ev.Get()
+		assertErrorContains(t, err, "TEST_VALIDATOR_BUCKET: ValidationFailed: invalid S3 bucket name. Hint: My-Bucket")
+
+		result, err := ev.GetCustom(func(string) (string, error) {
+			return "", ErrEnvironmentVariableValueRequired
+		})
+		assertNilError(t, err)
+		assertDeepEqual(t, "bucket", result)
+	})
+
+	t.Run("func_validator", func(t *testing.T) {
+		ev := NewEnvStringSliceValue([]string{}).WithValidator(notEmpty)
+
+		_, err := ev.Get()
+		assertDeepEqual(t, true, errors.Is(err, errEmpty))
+
+		result, err := NewEnvStringSliceValue([]string{"a"}).WithValidator(notEmpty).Get()
+		assertNilError(t, err)
+		assertDeepEqual(t, []string{"a"}, result)
+	})
+
+	t.Run("order", func(t *testing.T) {
+		var calls []string
+
+		ev := NewEnvMapIntValue(map[string]int64{"a": 1}).WithValidator(
+			ValidatorFunc[map[string]int64](func(map[string]int64) error {
+				calls = append(calls, "first")
+
+				return nil
+			}),
+			ValidatorFunc[map[string]int64](func(map[string]int64) error {
+				calls = append(calls, "second")
+
+				return errEmpty
+			}),
+			ValidatorFunc[map[string]int64](func(map[string]int64) error {
+				calls = append(calls, "third")
+
+				return nil
+			}),
+		)
+
+		_, err := ev.GetCustom(GetOSEnv)
+		assertDeepEqual(t, true, errors.Is(err, errEmpty))
+		assertDeepEqual(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("constraints_and_validators", func(t *testing.T) {
+		ev := NewEnvIntValue(0).WithMin(1).WithValidator(ValidatorFunc[int64](func(int64) error {
+			return errEmpty
+		}))
+
+		_, err := ev.Get()
+		assertErrorContains(t, err, "the value must be greater than or equal to 1")
+	})
+}
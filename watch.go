@@ -0,0 +1,459 @@
+package goenvconf
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultWatchDebounce    = 100 * time.Millisecond
+	defaultWatchEventBuffer = 16
+	defaultWatchInterval    = time.Second
+)
+
+// ErrWatcherRunning occurs when [Watcher.Run] is called while the watcher is running or after it stopped.
+var ErrWatcherRunning = errors.New("the watcher can only run once")
+
+// WatchSource notifies a [Watcher] when environment variables change, e.g. by polling the process environment,
+// watching a dotenv file or subscribing to the watch API of a secret provider.
+type WatchSource interface {
+	// Watch sends the names of changed variables to the channel until the context is canceled.
+	// A nil slice means that any variable may have changed. Sources must stop sending when the context is canceled,
+	// and return nil. Other errors stop the watcher.
+	Watch(ctx context.Context, changes chan<- []string) error
+}
+
+// WatchSourceFunc adapts a function to the [WatchSource] interface, e.g. a wrapper of the watch API of a provider.
+type WatchSourceFunc func(ctx context.Context, changes chan<- []string) error
+
+// Watch calls the function.
+func (fn WatchSourceFunc) Watch(ctx context.Context, changes chan<- []string) error {
+	return fn(ctx, changes)
+}
+
+// NewOSEnvWatchSource creates a [WatchSource] which polls the process environment at the interval,
+// and reports variables which are added, changed or removed since the previous poll.
+// The interval defaults to 1s if it is not positive.
+func NewOSEnvWatchSource(interval time.Duration) WatchSource {
+	return environWatchSource{interval: watchInterval(interval), environ: os.Environ}
+}
+
+// watchInterval returns the polling interval, or the default interval if it is not positive.
+func watchInterval(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return defaultWatchInterval
+	}
+
+	return interval
+}
+
+type environWatchSource struct {
+	interval time.Duration
+	environ  func() []string
+}
+
+func (s environWatchSource) Watch(ctx context.Context, changes chan<- []string) error {
+	previous := parseEnviron(s.environ())
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current := parseEnviron(s.environ())
+		changed := diffValues(previous, current)
+		previous = current
+
+		if len(changed) > 0 && !sendChanges(ctx, changes, changed) {
+			return nil
+		}
+	}
+}
+
+// DotEnvFile holds the variables of a dotenv file which can be reloaded when the file changes.
+// It is safe for concurrent use. Use the GetEnv method as the [GetEnvFunc] of Env types and binders,
+// and the source of [DotEnvFile.WatchSource] to reload the file in a [Watcher].
+type DotEnvFile struct {
+	path   string
+	mu     sync.RWMutex
+	values map[string]string
+}
+
+// NewDotEnvFile reads the dotenv file at the path. See [ParseDotEnv] for the grammar.
+func NewDotEnvFile(path string) (*DotEnvFile, error) {
+	file := &DotEnvFile{path: path}
+
+	if _, err := file.Reload(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Path returns the path of the dotenv file.
+func (f *DotEnvFile) Path() string {
+	return f.path
+}
+
+// Reload reads the dotenv file again and returns the sorted names of variables which are added, changed or removed.
+// The previous variables are kept if the file fails to read or parse.
+func (f *DotEnvFile) Reload() ([]string, error) {
+	reader, err := os.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	values, err := ParseDotEnv(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	changed := diffValues(f.values, values)
+	f.values = values
+
+	return changed, nil
+}
+
+// EnvNames returns the sorted names of variables in the dotenv file. It implements [EnvLister] for prefix scans.
+func (f *DotEnvFile) EnvNames() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(f.values))
+}
+
+// GetEnv returns the value of the variable in the dotenv file.
+// It returns [ErrEnvironmentVariableValueRequired] if the variable does not exist.
+func (f *DotEnvFile) GetEnv(name string) (string, error) {
+	f.mu.RLock()
+	value, ok := f.values[name]
+	f.mu.RUnlock()
+
+	if !ok {
+		return "", ErrEnvironmentVariableValueRequired
+	}
+
+	return value, nil
+}
+
+// WatchSource creates a [WatchSource] which polls the modification time and size of the dotenv file at the interval,
+// and reloads the file when they change. If the file fails to reload, e.g. while it is being written,
+// it is reloaded again at the next poll. The interval defaults to 1s if it is not positive.
+// See the envfsnotify package for a source driven by file system events.
+func (f *DotEnvFile) WatchSource(interval time.Duration) WatchSource {
+	interval = watchInterval(interval)
+
+	return WatchSourceFunc(func(ctx context.Context, changes chan<- []string) error {
+		// The file is reloaded at the first poll, in case it changed after it was read.
+		var modTime time.Time
+
+		size := int64(-1)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			info, err := os.Stat(f.path)
+			if err != nil || (info.ModTime().Equal(modTime) && info.Size() == size) {
+				continue
+			}
+
+			changed, err := f.Reload()
+			if err != nil {
+				continue
+			}
+
+			modTime, size = info.ModTime(), info.Size()
+
+			if len(changed) > 0 && !sendChanges(ctx, changes, changed) {
+				return nil
+			}
+		}
+	})
+}
+
+// WatcherOptions hold options of a [Watcher].
+type WatcherOptions struct {
+	// Debounce is the quiet period after the last change before the config is resolved again,
+	// so a burst of changes, e.g. rotating a username and a password, is applied at once. Defaults to 100ms.
+	Debounce time.Duration
+	// EventBuffer is the capacity of the event channel. Defaults to 16.
+	EventBuffer int
+}
+
+// FieldChange is a field whose resolved value, source or variable changed.
+type FieldChange struct {
+	// Path is the field path in the config struct, e.g. Server.Port.
+	Path string
+	// Old is the field in the previous snapshot.
+	Old ResolvedField
+	// New is the field in the current snapshot.
+	New ResolvedField
+}
+
+// WatchEvent is published by a [Watcher] when the config is resolved again after variables change.
+type WatchEvent struct {
+	// Changes are the changed fields in the declaration order of the config struct.
+	Changes []FieldChange
+	// Config is the current snapshot of the watcher.
+	Config *ResolvedConfig
+	// Err is the resolution error. The previous snapshot is kept if the config fails to resolve,
+	// so consumers keep running with the last valid config.
+	Err error
+}
+
+// Watcher resolves the Env fields of a config again when its sources report changed variables,
+// swaps the current [ResolvedConfig] snapshot atomically, and publishes a [WatchEvent] for every update,
+// so consumers can react to rotated credentials without restarting.
+// Only fields which depend on the changed variables are resolved again, unless a source reports that any variable may have changed.
+// Dependencies include placeholders of literal values, numbered variables of indexed slices and prefixes of prefix-scanned maps.
+type Watcher struct {
+	binder  Binder
+	config  any
+	options WatcherOptions
+	sources []WatchSource
+	current atomic.Pointer[ResolvedConfig]
+	events  chan WatchEvent
+	running atomic.Bool
+}
+
+// NewWatcher resolves the config with the getter, and creates a [Watcher] of the sources. The getter defaults to [GetOSEnv] if nil.
+// The getter must read the latest values of the sources, e.g. the GetEnv method of a [DotEnvFile].
+// See [Binder.NewWatcher].
+func NewWatcher(config any, getFunc GetEnvFunc, options WatcherOptions, sources ...WatchSource) (*Watcher, error) {
+	return NewBinder(getFunc).NewWatcher(config, options, sources...)
+}
+
+// NewWatcher resolves the config and creates a [Watcher] of the sources which resolves the config with the binder.
+// It returns the error of [Binder.Resolve] if the initial resolution fails. Call [Watcher.Run] to start watching.
+func (b Binder) NewWatcher(config any, options WatcherOptions, sources ...WatchSource) (*Watcher, error) {
+	resolved, err := b.Resolve(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Debounce <= 0 {
+		options.Debounce = defaultWatchDebounce
+	}
+
+	if options.EventBuffer <= 0 {
+		options.EventBuffer = defaultWatchEventBuffer
+	}
+
+	w := &Watcher{
+		binder:  b,
+		config:  config,
+		options: options,
+		sources: sources,
+		events:  make(chan WatchEvent, options.EventBuffer),
+	}
+
+	w.current.Store(resolved)
+
+	return w, nil
+}
+
+// Config returns the current snapshot. It is safe to call concurrently with [Watcher.Run].
+func (w *Watcher) Config() *ResolvedConfig {
+	return w.current.Load()
+}
+
+// Events returns the channel of watch events, which is closed when [Watcher.Run] returns.
+// The snapshot is swapped before its event is published. Consumers must drain the channel,
+// otherwise the watcher blocks when the buffer is full.
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// Run starts the sources and resolves the config again when they report changes, until the context is canceled
+// or a source fails. It returns nil when the context is canceled, or the first source error.
+// A watcher can only run once.
+func (w *Watcher) Run(ctx context.Context) error {
+	if !w.running.CompareAndSwap(false, true) {
+		return ErrWatcherRunning
+	}
+
+	defer close(w.events)
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	changes := make(chan []string)
+	errs := make(chan error, len(w.sources))
+
+	for _, source := range w.sources {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := source.Watch(ctx, changes); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	timer := time.NewTimer(w.options.Debounce)
+	timer.Stop()
+
+	defer timer.Stop()
+
+	var (
+		pending    []string
+		hasPending bool
+		anyChanged bool
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case names := <-changes:
+			hasPending = true
+			anyChanged = anyChanged || names == nil
+			pending = append(pending, names...)
+
+			timer.Reset(w.options.Debounce)
+		case <-timer.C:
+			if !hasPending {
+				continue
+			}
+
+			changed := pending
+			if anyChanged {
+				changed = nil
+			}
+
+			pending, hasPending, anyChanged = nil, false, false
+
+			if event, ok := w.reload(changed); ok {
+				select {
+				case w.events <- event:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// reload resolves the config again and swaps the snapshot. If the changed names are nil, every field is resolved again.
+// It returns false if no field changes.
+func (w *Watcher) reload(changed []string) (WatchEvent, bool) {
+	previous := w.current.Load()
+
+	var (
+		resolved *ResolvedConfig
+		err      error
+	)
+
+	if changed == nil {
+		resolved, err = w.binder.Resolve(w.config)
+	} else {
+		resolved, err = w.binder.resolveConfig(w.config, previous, changed)
+	}
+
+	if err != nil {
+		return WatchEvent{Config: previous, Err: err}, true
+	}
+
+	changes := diffResolvedConfigs(previous, resolved)
+	if len(changes) == 0 {
+		return WatchEvent{}, false
+	}
+
+	w.current.Store(resolved)
+
+	return WatchEvent{Changes: changes, Config: resolved}, true
+}
+
+// diffResolvedConfigs returns the fields whose values, sources, variables or getters differ between the snapshots.
+func diffResolvedConfigs(previous *ResolvedConfig, current *ResolvedConfig) []FieldChange {
+	var results []FieldChange
+
+	for _, field := range current.fields {
+		var old ResolvedField
+
+		if index, ok := previous.indexes[field.Path]; ok {
+			old = previous.fields[index]
+		}
+
+		if old.Source == field.Source && old.Variable == field.Variable && old.Getter == field.Getter &&
+			reflect.DeepEqual(old.Value, field.Value) {
+			continue
+		}
+
+		old.Value = cloneResolvedValue(old.Value)
+		field.Value = cloneResolvedValue(field.Value)
+
+		results = append(results, FieldChange{
+			Path: field.Path,
+			Old:  old,
+			New:  field,
+		})
+	}
+
+	return results
+}
+
+// diffValues returns the sorted keys which are added, changed or removed between the maps.
+func diffValues(previous map[string]string, current map[string]string) []string {
+	var results []string
+
+	for key, value := range current {
+		if oldValue, ok := previous[key]; !ok || oldValue != value {
+			results = append(results, key)
+		}
+	}
+
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			results = append(results, key)
+		}
+	}
+
+	slices.Sort(results)
+
+	return results
+}
+
+// sendChanges sends the changed names to the channel. It returns false if the context is canceled.
+func sendChanges(ctx context.Context, changes chan<- []string, names []string) bool {
+	select {
+	case changes <- names:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,390 @@
+package goenvconf
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type watchTestConfig struct {
+	Username EnvString
+	Password EnvString
+	Port     EnvInt
+}
+
+func newWatchTestConfig() watchTestConfig {
+	return watchTestConfig{
+		Username: NewEnvStringVariable("DB_USER"),
+		Password: NewEnvStringVariable("DB_PASSWORD"),
+		Port:     NewEnvInt("DB_PORT", 5432),
+	}
+}
+
+// startWatcher runs the watcher in the background and returns a function which stops it and returns the result of Run.
+func startWatcher(t *testing.T, watcher *Watcher) func() error {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+
+	go func() {
+		result <- watcher.Run(ctx)
+	}()
+
+	return func() error {
+		cancel()
+
+		return <-result
+	}
+}
+
+func receiveWatchEvent(t *testing.T, watcher *Watcher) WatchEvent {
+	t.Helper()
+
+	select {
+	case event := <-watcher.Events():
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a watch event")
+
+		return WatchEvent{}
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	store := NewOverrideStore(newBinderGetter(map[string]string{
+		"DB_USER":     "admin",
+		"DB_PASSWORD": "secret",
+	}))
+	changes := make(chan []string)
+
+	var resolveCount atomic.Int32
+
+	binder := NewBinder(store.GetEnv).OnResolve(func(event ResolveEvent) {
+		resolveCount.Add(1)
+	})
+
+	watcher, err := binder.NewWatcher(newWatchTestConfig(), WatcherOptions{Debounce: 20 * time.Millisecond},
+		WatchSourceFunc(func(ctx context.Context, out chan<- []string) error {
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case names := <-changes:
+					if !sendChanges(ctx, out, names) {
+						return nil
+					}
+				}
+			}
+		}))
+	assertNilError(t, err)
+	assertDeepEqual(t, int32(3), resolveCount.Load())
+
+	initial := watcher.Config()
+	stop := startWatcher(t, watcher)
+
+	// A burst of changes is resolved once, and only the fields which use the changed variables are resolved again.
+	store.Set("DB_USER", "rotated")
+	store.Set("DB_PASSWORD", "rotated-secret")
+	changes <- []string{"DB_USER"}
+	changes <- []string{"DB_PASSWORD"}
+
+	event := receiveWatchEvent(t, watcher)
+	assertNilError(t, event.Err)
+	assertDeepEqual(t, int32(5), resolveCount.Load())
+	assertDeepEqual(t, []string{"Username", "Password"}, watchChangePaths(event.Changes))
+	assertDeepEqual(t, "admin", event.Changes[0].Old.Value)
+	assertDeepEqual(t, "rotated", event.Changes[0].New.Value)
+	assertDeepEqual(t, event.Config, watcher.Config())
+
+	value, _ := watcher.Config().Get("Password")
+	assertDeepEqual(t, "rotated-secret", value)
+
+	// The previous snapshot is immutable.
+	value, _ = initial.Get("Password")
+	assertDeepEqual(t, "secret", value)
+
+	// Changes which do not affect any field are not published.
+	changes <- []string{"OTHER"}
+
+	// A nil change resolves every field again.
+	store.Set("DB_PORT", "6543")
+	changes <- nil
+
+	event = receiveWatchEvent(t, watcher)
+	assertNilError(t, event.Err)
+	assertDeepEqual(t, []string{"Port"}, watchChangePaths(event.Changes))
+	assertDeepEqual(t, SourceLiteral, event.Changes[0].Old.Source)
+	assertDeepEqual(t, SourceVariable, event.Changes[0].New.Source)
+	assertDeepEqual(t, int64(6543), event.Changes[0].New.Value)
+
+	// The previous snapshot is kept if the config fails to resolve.
+	previous := watcher.Config()
+
+	store.Set("DB_PORT", "invalid")
+	changes <- []string{"DB_PORT"}
+
+	event = receiveWatchEvent(t, watcher)
+	assertErrorContains(t, event.Err, "invalid syntax")
+	assertDeepEqual(t, 0, len(event.Changes))
+	assertDeepEqual(t, previous, event.Config)
+	assertDeepEqual(t, previous, watcher.Config())
+
+	assertNilError(t, stop())
+
+	if _, ok := <-watcher.Events(); ok {
+		t.Fatal("expected the event channel to be closed")
+	}
+
+	if err := watcher.Run(context.Background()); !errors.Is(err, ErrWatcherRunning) {
+		t.Fatalf("expected ErrWatcherRunning, got %v", err)
+	}
+}
+
+func TestWatcher_dependencies(t *testing.T) {
+	type dependencyConfig struct {
+		URL     EnvString
+		Origins EnvStringSlice
+		Headers EnvMapString
+		Port    EnvInt
+	}
+
+	store := NewOverrideStore(newBinderGetter(map[string]string{}))
+	store.Set("HOST", "localhost")
+	store.Set("ORIGINS_0", "a")
+	store.Set("HEADERS_A", "1")
+
+	lister := EnvListerFunc(func() []string {
+		return slices.Sorted(maps.Keys(store.Overrides()))
+	})
+	config := dependencyConfig{
+		URL:     NewEnvStringValue("http://${HOST}:8080"),
+		Origins: NewEnvStringSliceVariable("ORIGINS").WithIndexedVariables(),
+		Headers: NewEnvMapStringVariable("HEADERS").WithPrefixScan(nil),
+		Port:    NewEnvInt("PORT", 80),
+	}
+	changes := make(chan []string)
+
+	watcher, err := NewBinder(store.GetEnv).WithEnvLister(lister).NewWatcher(config, WatcherOptions{Debounce: 10 * time.Millisecond},
+		WatchSourceFunc(func(ctx context.Context, out chan<- []string) error {
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case names := <-changes:
+					if !sendChanges(ctx, out, names) {
+						return nil
+					}
+				}
+			}
+		}))
+	assertNilError(t, err)
+
+	stop := startWatcher(t, watcher)
+
+	testCases := []struct {
+		Name     string
+		Variable string
+		Value    string
+		Path     string
+		Expected any
+	}{
+		{Name: "literal_placeholder", Variable: "HOST", Value: "example.com", Path: "URL", Expected: "http://example.com:8080"},
+		{Name: "indexed_slice", Variable: "ORIGINS_1", Value: "b", Path: "Origins", Expected: []string{"a", "b"}},
+		{Name: "prefix_scan", Variable: "HEADERS_B", Value: "2", Path: "Headers", Expected: map[string]string{"A": "1", "B": "2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			store.Set(tc.Variable, tc.Value)
+			changes <- []string{tc.Variable}
+
+			event := receiveWatchEvent(t, watcher)
+			assertNilError(t, event.Err)
+			assertDeepEqual(t, []string{tc.Path}, watchChangePaths(event.Changes))
+			assertDeepEqual(t, tc.Expected, event.Changes[0].New.Value)
+		})
+	}
+
+	assertNilError(t, stop())
+}
+
+func TestWatcher_sourceError(t *testing.T) {
+	errSource := errors.New("source failed")
+
+	var stopped atomic.Bool
+
+	watcher, err := NewWatcher(newWatchTestConfig(), newBinderGetter(map[string]string{
+		"DB_USER":     "admin",
+		"DB_PASSWORD": "secret",
+	}), WatcherOptions{},
+		WatchSourceFunc(func(ctx context.Context, changes chan<- []string) error {
+			return errSource
+		}),
+		WatchSourceFunc(func(ctx context.Context, changes chan<- []string) error {
+			<-ctx.Done()
+			stopped.Store(true)
+
+			return nil
+		}))
+	assertNilError(t, err)
+
+	if err := watcher.Run(context.Background()); !errors.Is(err, errSource) {
+		t.Fatalf("expected the source error, got %v", err)
+	}
+
+	assertDeepEqual(t, true, stopped.Load())
+
+	t.Run("initial_error", func(t *testing.T) {
+		_, err := NewWatcher(newWatchTestConfig(), newBinderGetter(map[string]string{}), WatcherOptions{})
+		assertErrorContains(t, err, "DB_USER")
+	})
+}
+
+func TestEnvironWatchSource(t *testing.T) {
+	var environ atomic.Pointer[[]string]
+
+	environ.Store(&[]string{"DB_USER=admin", "DB_PASSWORD=secret"})
+
+	source := environWatchSource{
+		interval: 5 * time.Millisecond,
+		environ: func() []string {
+			return *environ.Load()
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan []string)
+	result := make(chan error, 1)
+
+	go func() {
+		result <- source.Watch(ctx, changes)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	environ.Store(&[]string{"DB_USER=rotated", "DB_PORT=6543"})
+
+	select {
+	case names := <-changes:
+		assertDeepEqual(t, []string{"DB_PASSWORD", "DB_PORT", "DB_USER"}, names)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for changes")
+	}
+
+	cancel()
+	assertNilError(t, <-result)
+}
+
+func TestDotEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	writeDotEnvFile(t, path, "DB_USER=admin\nDB_PASSWORD=secret\n")
+
+	file, err := NewDotEnvFile(path)
+	assertNilError(t, err)
+	assertDeepEqual(t, path, file.Path())
+
+	value, err := file.GetEnv("DB_USER")
+	assertNilError(t, err)
+	assertDeepEqual(t, "admin", value)
+
+	writeDotEnvFile(t, path, "DB_USER=admin\nDB_PORT=6543\n")
+
+	changed, err := file.Reload()
+	assertNilError(t, err)
+	assertDeepEqual(t, []string{"DB_PASSWORD", "DB_PORT"}, changed)
+	assertDeepEqual(t, []string{"DB_PORT", "DB_USER"}, file.EnvNames())
+
+	_, err = file.GetEnv("DB_PASSWORD")
+	assertErrorContains(t, err, "EmptyVar")
+
+	// The previous values are kept if the file is invalid.
+	writeDotEnvFile(t, path, "1INVALID\n")
+
+	_, err = file.Reload()
+	if err == nil {
+		t.Fatal("expected a parse error")
+	}
+
+	value, err = file.GetEnv("DB_PORT")
+	assertNilError(t, err)
+	assertDeepEqual(t, "6543", value)
+
+	t.Run("not_found", func(t *testing.T) {
+		_, err := NewDotEnvFile(filepath.Join(t.TempDir(), ".env"))
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected os.ErrNotExist, got %v", err)
+		}
+	})
+}
+
+func TestDotEnvFile_WatchSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	writeDotEnvFile(t, path, "DB_USER=admin\nDB_PASSWORD=secret\n")
+
+	file, err := NewDotEnvFile(path)
+	assertNilError(t, err)
+
+	watcher, err := NewWatcher(newWatchTestConfig(), file.GetEnv, WatcherOptions{Debounce: 10 * time.Millisecond},
+		file.WatchSource(5*time.Millisecond))
+	assertNilError(t, err)
+
+	stop := startWatcher(t, watcher)
+
+	writeDotEnvFile(t, path, "DB_USER=admin\nDB_PASSWORD=rotated-secret-value\n")
+
+	event := receiveWatchEvent(t, watcher)
+	assertNilError(t, event.Err)
+	assertDeepEqual(t, []string{"Password"}, watchChangePaths(event.Changes))
+	assertDeepEqual(t, "rotated-secret-value", event.Changes[0].New.Value)
+	assertNilError(t, stop())
+}
+
+func TestWatchSource_defaultInterval(t *testing.T) {
+	assertDeepEqual(t, defaultWatchInterval, NewOSEnvWatchSource(0).(environWatchSource).interval)
+	assertDeepEqual(t, defaultWatchInterval, watchInterval(-time.Second))
+	assertDeepEqual(t, time.Millisecond, watchInterval(time.Millisecond))
+
+	path := filepath.Join(t.TempDir(), ".env")
+	writeDotEnvFile(t, path, "DB_USER=admin\n")
+
+	file, err := NewDotEnvFile(path)
+	assertNilError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assertNilError(t, file.WatchSource(0).Watch(ctx, make(chan []string)))
+	assertNilError(t, NewOSEnvWatchSource(-1).Watch(ctx, make(chan []string)))
+}
+
+func TestDiffValues(t *testing.T) {
+	assertDeepEqual(t, []string{"A", "B", "D"}, diffValues(
+		map[string]string{"A": "1", "B": "2", "C": "3"},
+		map[string]string{"A": "0", "C": "3", "D": "4"},
+	))
+	assertDeepEqual(t, []string(nil), diffValues(nil, map[string]string{}))
+}
+
+func watchChangePaths(changes []FieldChange) []string {
+	results := make([]string, 0, len(changes))
+
+	for _, change := range changes {
+		results = append(results, change.Path)
+	}
+
+	return results
+}
+
+func writeDotEnvFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,480 @@
+package goenvconf
+
+import "reflect"
+
+// The UnmarshalYAML methods use the function-based Unmarshaler interface which is supported by
+// both yaml.v2 and yaml.v3 libraries. In addition to mappings with value and env keys,
+// they accept the same shorthand forms as [DecodeHook], e.g. a scalar (port: 8080) or a variable reference (port: ${PORT}).
+//
+// The MarshalYAML methods encode the mapping form by default. If the compact encoding form is enabled
+// by WithCompactEncoding, they encode the shortest form which can be decoded back faithfully:
+// ${VAR} if only the variable is set, the bare literal if only the value is set, and the mapping form otherwise.
+// Literal strings which look like variable references and maps with only value and env keys keep the mapping form.
+
+// compactYAML returns the compact YAML form of an Env instance if the form can be decoded back faithfully.
+// Mappings are used as the bare literal only if they cannot be mistaken for the mapping form.
+func compactYAML(value any, variable *string) (any, bool) {
+	literal := reflect.ValueOf(value)
+	if literal.Kind() == reflect.Pointer {
+		literal = literal.Elem()
+	}
+
+	hasValue := literal.IsValid() &&
+		((literal.Kind() != reflect.Slice && literal.Kind() != reflect.Map) || !literal.IsNil())
+	hasVariable := variable != nil && *variable != ""
+
+	switch {
+	case hasVariable && !hasValue:
+		return "${" + *variable + "}", true
+	case hasValue && variable == nil:
+		switch literal.Kind() {
+		case reflect.Map:
+			if !isLiteralMapping(literal) {
+				return nil, false
+			}
+		case reflect.Struct:
+			return nil, false
+		case reflect.String:
+			if _, isVariable := parseEnvReference(literal.String()); isVariable {
+				return nil, false
+			}
+		default:
+		}
+
+		return literal.Interface(), true
+	default:
+		return nil, false
+	}
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvString) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvString(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvInt) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvInt(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvBool) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvBool(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvFloat) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvFloat(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvAny) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvAny(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvStringSlice) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvStringSlice(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvIntSlice) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvIntSlice(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvFloatSlice) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvFloatSlice(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvBoolSlice) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvBoolSlice(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvMapString) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvMapString(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvMapInt) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvMapInt(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvMapFloat) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvMapFloat(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ev *EnvMapBool) UnmarshalYAML(unmarshal func(any) error) error {
+	var rawValue any
+
+	if err := unmarshal(&rawValue); err != nil {
+		return err
+	}
+
+	result, err := decodeEnvMapBool(rawValue)
+	if err != nil {
+		return err
+	}
+
+	result.options = ev.options
+	*ev = result
+
+	return nil
+}
+
+// isLiteralMapping checks if the map is decoded as a literal value, i.e. it is not empty
+// and has keys other than value and env.
+func isLiteralMapping(value reflect.Value) bool {
+	for _, key := range value.MapKeys() {
+		if key.Kind() != reflect.String {
+			return true
+		}
+
+		if name := key.String(); name != envObjectValueKey && name != envObjectVariableKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvString) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvString
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvInt) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvInt
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvBool) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvBool
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvFloat) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvFloat
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvAny) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvAny
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvStringSlice) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvStringSlice
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvIntSlice) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvIntSlice
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvFloatSlice) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvFloatSlice
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvBoolSlice) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvBoolSlice
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvMapString) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvMapString
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvMapInt) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvMapInt
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvMapFloat) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvMapFloat
+
+	return plain(ev), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ev EnvMapBool) MarshalYAML() (any, error) {
+	if ev.options.isCompact() {
+		if result, ok := compactYAML(ev.Value, ev.Variable); ok {
+			return result, nil
+		}
+	}
+
+	type plain EnvMapBool
+
+	return plain(ev), nil
+}
@@ -0,0 +1,175 @@
+package goenvconf
+
+import (
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+type yamlTestConfig struct {
+	Name     EnvString      `yaml:"name"`
+	Port     EnvInt         `yaml:"port"`
+	Debug    EnvBool        `yaml:"debug"`
+	Ratio    EnvFloat       `yaml:"ratio"`
+	Extra    EnvAny         `yaml:"extra"`
+	Hosts    EnvStringSlice `yaml:"hosts"`
+	Ports    EnvIntSlice    `yaml:"ports"`
+	Weights  EnvFloatSlice  `yaml:"weights"`
+	Flags    EnvBoolSlice   `yaml:"flags"`
+	Headers  EnvMapString   `yaml:"headers"`
+	Limits   EnvMapInt      `yaml:"limits"`
+	Scores   EnvMapFloat    `yaml:"scores"`
+	Features EnvMapBool     `yaml:"features"`
+	Pointer  *EnvString     `yaml:"pointer"`
+}
+
+func TestYAML_Unmarshal(t *testing.T) {
+	var output yamlTestConfig
+
+	assertNilError(t, yaml.Unmarshal([]byte(`
+name: ${APP_NAME}
+port: 8080
+debug:
+  env: DEBUG
+  value: true
+ratio: "0.5"
+extra:
+  foo: bar
+hosts: a,b
+ports: [1, 2]
+weights:
+  env: WEIGHTS
+flags: ${FLAGS}
+headers:
+  foo: bar
+limits: a=1;b=2
+scores:
+  value:
+    a: 1.5
+features:
+  x: true
+pointer: literal
+`), &output))
+
+	assertDeepEqual(t, yamlTestConfig{
+		Name:     NewEnvStringVariable("APP_NAME"),
+		Port:     NewEnvIntValue(8080),
+		Debug:    NewEnvBool("DEBUG", true),
+		Ratio:    NewEnvFloatValue(0.5),
+		Extra:    NewEnvAnyValue(map[string]any{"foo": "bar"}),
+		Hosts:    NewEnvStringSliceValue([]string{"a", "b"}),
+		Ports:    NewEnvIntSliceValue([]int64{1, 2}),
+		Weights:  NewEnvFloatSliceVariable("WEIGHTS"),
+		Flags:    NewEnvBoolSliceVariable("FLAGS"),
+		Headers:  NewEnvMapStringValue(map[string]string{"foo": "bar"}),
+		Limits:   NewEnvMapIntValue(map[string]int64{"a": 1, "b": 2}),
+		Scores:   NewEnvMapFloatValue(map[string]float64{"a": 1.5}),
+		Features: NewEnvMapBoolValue(map[string]bool{"x": true}),
+		Pointer:  toPtr(NewEnvStringValue("literal")),
+	}, output)
+}
+
+func TestYAML_RoundTrip(t *testing.T) {
+	input := yamlTestConfig{
+		Name:    NewEnvString("APP_NAME", "app"),
+		Port:    NewEnvIntVariable("PORT"),
+		Hosts:   NewEnvStringSliceValue([]string{"a"}),
+		Headers: NewEnvMapString("HEADERS", map[string]string{"foo": "bar"}),
+	}
+
+	bytes, err := yaml.Marshal(input)
+	assertNilError(t, err)
+
+	var output yamlTestConfig
+
+	assertNilError(t, yaml.Unmarshal(bytes, &output))
+	assertDeepEqual(t, input, output)
+}
+
+func TestYAML_Errors(t *testing.T) {
+	var output yamlTestConfig
+
+	assertErrorContains(t, yaml.Unmarshal([]byte(`port: eighty`), &output), "invalid integer value")
+	assertErrorContains(t, yaml.Unmarshal([]byte(`debug: { env: [1] }`), &output), "invalid env field")
+	assertErrorContains(t, yaml.Unmarshal([]byte("name: [\n"), &output), "did not find expected node content")
+}
+
+func TestYAML_MarshalCompact(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    any
+		Expected string
+	}{
+		{Name: "variable", Input: NewEnvStringVariable("FOO").WithCompactEncoding(), Expected: "${FOO}\n"},
+		{Name: "value", Input: NewEnvIntValue(8080).WithCompactEncoding(), Expected: "8080\n"},
+		{Name: "both", Input: NewEnvBool("FOO", true).WithCompactEncoding(), Expected: "value: true\nenv: FOO\n"},
+		{Name: "variable_like_string_value", Input: NewEnvStringValue("${FOO}").WithCompactEncoding(), Expected: "value: ${FOO}\n"},
+		{Name: "bare_dollar_string_value", Input: NewEnvStringValue("$FOO").WithCompactEncoding(), Expected: "$FOO\n"},
+		{Name: "slice_value", Input: NewEnvFloatSliceValue([]float64{1.5}).WithCompactEncoding(), Expected: "- 1.5\n"},
+		{Name: "map_value", Input: NewEnvMapIntValue(map[string]int64{"a": 1}).WithCompactEncoding(), Expected: "a: 1\n"},
+		{
+			Name:     "map_value_like_object",
+			Input:    NewEnvMapStringValue(map[string]string{"env": "FOO"}).WithCompactEncoding(),
+			Expected: "value:\n    env: FOO\n",
+		},
+		{Name: "map_variable", Input: NewEnvMapBoolVariable("FOO").WithCompactEncoding(), Expected: "${FOO}\n"},
+		{Name: "zero", Input: EnvStringSlice{}.WithCompactEncoding(), Expected: "{}\n"},
+		{Name: "not_compact", Input: NewEnvStringVariable("FOO"), Expected: "env: FOO\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := yaml.Marshal(tc.Input)
+			assertNilError(t, err)
+			assertDeepEqual(t, tc.Expected, string(result))
+		})
+	}
+
+	t.Run("round_trip", func(t *testing.T) {
+		input := yamlTestConfig{
+			Name:     NewEnvStringVariable("APP_NAME").WithCompactEncoding(),
+			Port:     NewEnvIntValue(8080).WithCompactEncoding(),
+			Debug:    NewEnvBool("DEBUG", true).WithCompactEncoding(),
+			Extra:    NewEnvAnyValue(map[string]any{"foo": "bar"}).WithCompactEncoding(),
+			Hosts:    NewEnvStringSliceValue([]string{"a", "b"}).WithCompactEncoding(),
+			Headers:  NewEnvMapStringValue(map[string]string{"value": "bar"}).WithCompactEncoding(),
+			Features: NewEnvMapBoolValue(map[string]bool{"x": true}).WithCompactEncoding(),
+		}
+
+		bytes, err := yaml.Marshal(input)
+		assertNilError(t, err)
+		assertDeepEqual(t, `name: ${APP_NAME}
+port: 8080
+debug:
+    value: true
+    env: DEBUG
+ratio: {}
+extra:
+    foo: bar
+hosts:
+    - a
+    - b
+ports: {}
+weights: {}
+flags: {}
+headers:
+    value:
+        value: bar
+limits: {}
+scores: {}
+features:
+    x: true
+pointer: null
+`, string(bytes))
+
+		var output yamlTestConfig
+
+		assertNilError(t, yaml.Unmarshal(bytes, &output))
+		assertDeepEqual(t, NewEnvStringVariable("APP_NAME"), output.Name)
+		assertDeepEqual(t, NewEnvIntValue(8080), output.Port)
+		assertDeepEqual(t, NewEnvAnyValue(map[string]any{"foo": "bar"}), output.Extra)
+		assertDeepEqual(t, NewEnvStringSliceValue([]string{"a", "b"}), output.Hosts)
+		assertDeepEqual(t, NewEnvMapStringValue(map[string]string{"value": "bar"}), output.Headers)
+		assertDeepEqual(t, NewEnvMapBoolValue(map[string]bool{"x": true}), output.Features)
+	})
+}